@@ -0,0 +1,54 @@
+// Command rotate-keys re-encrypts target notes with the active master key.
+// Run it after adding a new active key, before removing the old one.
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+
+	"backend/config"
+	"backend/internal/app"
+)
+
+func main() {
+	batchSize, err := parseFlags(os.Args[1:], os.Stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			slog.Error("invalid flags", "err", err)
+		}
+		os.Exit(2)
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		slog.Error("config fill", "err", err)
+		os.Exit(1)
+	}
+
+	if err = app.RotateNotes(cfg, batchSize); err != nil {
+		slog.Error("notes key rotation failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+// parseFlags returns the number of targets re-encrypted per transaction,
+// usage goes to output.
+func parseFlags(args []string, output io.Writer) (batchSize int, err error) {
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.IntVar(&batchSize, "batch", 500, "targets re-encrypted per transaction")
+
+	if err = flags.Parse(args); err != nil {
+		return 0, err
+	}
+	if flags.NArg() > 0 {
+		return 0, errors.New("unexpected arguments")
+	}
+	if batchSize <= 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+	return batchSize, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{name: "default", args: nil, want: 500},
+		{name: "batch", args: []string{"-batch", "50"}, want: 50},
+		{name: "batch of one", args: []string{"-batch=1"}, want: 1},
+		{name: "zero batch", args: []string{"-batch", "0"}, wantErr: true},
+		{name: "negative batch", args: []string{"-batch", "-5"}, wantErr: true},
+		{name: "not a number", args: []string{"-batch", "many"}, wantErr: true},
+		{name: "unknown flag", args: []string{"-key", "k2"}, wantErr: true},
+		{name: "unexpected argument", args: []string{"now"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFlags(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("batch = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *app) catsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cats",
+		Short: "Manage spy cats",
+	}
+
+	var breed string
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List cats",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cats, err := a.client.GetCats(cmd.Context(), breed)
+			if err != nil {
+				return err
+			}
+			return a.print(cats, catsTable(cats))
+		},
+	}
+	list.Flags().StringVar(&breed, "breed", "", "filter by breed")
+
+	var body request.Cat
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a cat",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cat, err := a.client.CreateCat(cmd.Context(), body)
+			if err != nil {
+				return err
+			}
+			return a.print(cat, catsTable([]response.Cat{cat}))
+		},
+	}
+	create.Flags().StringVar(&body.Name, "name", "", "cat name")
+	create.Flags().StringVar(&body.Breed, "breed", "", "cat breed, validated with TheCatAPI")
+	create.Flags().Uint8Var(&body.YearsExperience, "experience", 0, "years of experience")
+	create.Flags().Int64Var(&body.Salary.Amount, "salary", 0, "monthly salary in minor units, e.g. cents")
+	create.Flags().StringVar(&body.Salary.Currency, "currency", "", "ISO-4217 salary currency, USD if empty")
+	create.MarkFlagRequired("name")
+	create.MarkFlagRequired("breed")
+
+	updateSalary := &cobra.Command{
+		Use:   "update-salary <cat_id> <amount> [currency]",
+		Short: "Update the salary of a cat, amount in minor units (e.g. cents)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catID, err := parseID("cat_id", args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid salary: %v", err)
+			}
+			var currency string
+			if len(args) == 3 {
+				currency = args[2]
+			}
+
+			body := request.UpdateCat{Salary: money.New(amount, currency)}
+			if err := a.client.UpdateCat(cmd.Context(), catID, body); err != nil {
+				return err
+			}
+			return a.done("salary updated")
+		},
+	}
+
+	del := &cobra.Command{
+		Use:   "delete <cat_id>",
+		Short: "Delete a cat",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catID, err := parseID("cat_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := a.client.DeleteCat(cmd.Context(), catID); err != nil {
+				return err
+			}
+			return a.done("cat deleted")
+		},
+	}
+
+	cmd.AddCommand(list, create, updateSalary, del)
+	return cmd
+}
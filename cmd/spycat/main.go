@@ -0,0 +1,14 @@
+// Command spycat is an admin tool for operators of the Spy Cats Agency API.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *app) missionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "missions",
+		Short: "Manage missions",
+	}
+
+	var (
+		filter  request.MissionFilter
+		overdue bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List missions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("overdue") {
+				filter.Overdue = fmt.Sprint(overdue)
+			}
+
+			missions, err := a.client.GetMissions(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+			return a.print(missions, missionsTable(missions))
+		},
+	}
+	list.Flags().StringVar(&filter.Priority, "priority", "", "comma separated priorities (low, normal, high, critical)")
+	list.Flags().BoolVar(&overdue, "overdue", false, "only overdue (or, =false, not overdue) missions")
+	list.Flags().StringVar(&filter.Sort, "sort", "", "sort field (created_at, priority, starts_at, due_at), - prefix for descending")
+
+	show := &cobra.Command{
+		Use:   "show <mission_id>",
+		Short: "Show a mission with its targets",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, err := parseID("mission_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			mission, err := a.client.GetMission(cmd.Context(), missionID)
+			if err != nil {
+				return err
+			}
+			return a.print(mission, missionTable(mission))
+		},
+	}
+
+	var (
+		catID   uint
+		typeID  uint
+		targets []string
+	)
+	create := &cobra.Command{
+		Use:   "create --target name:country[:notes] ...",
+		Short: "Create a mission with targets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var body request.Mission
+			if cmd.Flags().Changed("cat") {
+				body.CatID = &catID
+			}
+			if cmd.Flags().Changed("type") {
+				body.TypeID = &typeID
+			}
+			for _, t := range targets {
+				target, err := parseTarget(t)
+				if err != nil {
+					return err
+				}
+				body.Targets = append(body.Targets, target)
+			}
+
+			mission, err := a.client.CreateMission(cmd.Context(), body)
+			if err != nil {
+				return err
+			}
+			return a.print(mission, missionTable(mission))
+		},
+	}
+	create.Flags().UintVar(&catID, "cat", 0, "ID of the cat to assign")
+	create.Flags().UintVar(&typeID, "type", 0, "ID of the mission type, the default type if omitted")
+	create.Flags().StringArrayVar(&targets, "target", nil, "target as name:country[:notes], repeatable")
+	create.MarkFlagRequired("target")
+
+	assign := &cobra.Command{
+		Use:   "assign <mission_id> <cat_id>",
+		Short: "Assign a cat to a mission",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, err := parseID("mission_id", args[0])
+			if err != nil {
+				return err
+			}
+			catID, err := parseID("cat_id", args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := a.client.AssignCat(cmd.Context(), missionID, catID); err != nil {
+				return err
+			}
+			return a.done("cat assigned to mission")
+		},
+	}
+
+	watch := &cobra.Command{
+		Use:   "watch <mission_id>",
+		Short: "Print live updates of a mission until interrupted",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, err := parseID("mission_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.client.StreamMission(cmd.Context(), missionID, func(update response.MissionUpdate) error {
+				return a.print(update, updateTable(update))
+			})
+		},
+	}
+
+	var (
+		fillCatID uint
+		names     []string
+	)
+	fromTemplate := &cobra.Command{
+		Use:   "from-template <template_id> [--name target_name ...]",
+		Short: "Create a mission from a template, names replace the target placeholders in order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateID, err := parseID("template_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			var body request.MissionFromTemplate
+			if cmd.Flags().Changed("cat") {
+				body.CatID = &fillCatID
+			}
+			for _, name := range names {
+				body.Targets = append(body.Targets, request.TemplateFillTarget{Name: name})
+			}
+
+			mission, err := a.client.CreateMissionFromTemplate(cmd.Context(), templateID, body)
+			if err != nil {
+				return err
+			}
+			return a.print(mission, missionTable(mission))
+		},
+	}
+	fromTemplate.Flags().UintVar(&fillCatID, "cat", 0, "ID of the cat to assign")
+	fromTemplate.Flags().StringArrayVar(&names, "name", nil, "target name, repeatable, empty keeps the placeholder")
+
+	var cloneCatID uint
+	clone := &cobra.Command{
+		Use:   "clone <mission_id>",
+		Short: "Create a mission with the targets of another",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, err := parseID("mission_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			var body request.CloneMission
+			if cmd.Flags().Changed("cat") {
+				body.CatID = &cloneCatID
+			}
+
+			mission, err := a.client.CloneMission(cmd.Context(), missionID, body)
+			if err != nil {
+				return err
+			}
+			return a.print(mission, missionTable(mission))
+		},
+	}
+	clone.Flags().UintVar(&cloneCatID, "cat", 0, "ID of the cat to assign")
+
+	var format string
+	debrief := &cobra.Command{
+		Use:   "debrief <mission_id>",
+		Short: "Print the debrief of a completed mission as Markdown or HTML",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, err := parseID("mission_id", args[0])
+			if err != nil {
+				return err
+			}
+
+			if a.output == outputJSON {
+				debrief, err := a.client.GetDebrief(cmd.Context(), missionID)
+				if err != nil {
+					return err
+				}
+				return a.print(debrief, nil)
+			}
+			return a.client.RenderDebrief(cmd.Context(), missionID, format, os.Stdout)
+		},
+	}
+	debrief.Flags().StringVar(&format, "format", "markdown", "document format (markdown, html)")
+
+	cmd.AddCommand(list, show, create, fromTemplate, clone, assign, watch, debrief)
+	return cmd
+}
+
+func parseTarget(value string) (request.Target, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return request.Target{}, fmt.Errorf("invalid target %q, expected name:country[:notes]", value)
+	}
+
+	target := request.Target{Name: parts[0], Country: parts[1]}
+	if len(parts) == 3 {
+		target.Notes = parts[2]
+	}
+	return target, nil
+}
+
+func findTarget(mission response.Mission, targetID uint) (response.Target, error) {
+	for _, t := range mission.Targets {
+		if t.ID == targetID {
+			return t, nil
+		}
+	}
+	return response.Target{}, fmt.Errorf("target %d not found in mission %d", targetID, mission.ID)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	response "backend/pkg/api/response/cat"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// print writes v as indented JSON in json mode, otherwise renders the table.
+func (a *app) print(v any, table func(w io.Writer)) error {
+	if a.output == outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// done reports a successful action without a payload.
+func (a *app) done(message string) error {
+	return a.print(map[string]string{"message": message}, func(w io.Writer) {
+		fmt.Fprintln(w, message)
+	})
+}
+
+func catsTable(cats []response.Cat) func(w io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tBREED\tEXPERIENCE\tSALARY")
+		for _, c := range cats {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n",
+				c.ID, c.Name, c.Breed, c.YearsExperience, c.Salary)
+		}
+	}
+}
+
+func missionsTable(missions []response.Mission) func(w io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tCAT\tTARGETS\tPRIORITY\tDUE\tCOMPLETED")
+		for _, m := range missions {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%t\n",
+				m.ID, catName(m), len(m.Targets), m.Priority, dueDate(m), m.IsCompleted)
+		}
+	}
+}
+
+func missionTable(m response.Mission) func(w io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintf(w, "Mission:\t%d\n", m.ID)
+		fmt.Fprintf(w, "Cat:\t%s\n", catName(m))
+		fmt.Fprintf(w, "Priority:\t%s\n", m.Priority)
+		fmt.Fprintf(w, "Due:\t%s\n", dueDate(m))
+		fmt.Fprintf(w, "Completed:\t%t\n\n", m.IsCompleted)
+
+		fmt.Fprintln(w, "TARGET\tNAME\tCOUNTRY\tCOMPLETED\tNOTES")
+		for _, t := range m.Targets {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n",
+				t.ID, t.Name, t.Country, t.IsCompleted, firstLine(t.Notes))
+		}
+	}
+}
+
+// updateTable renders a live mission update as a single line.
+func updateTable(u response.MissionUpdate) func(w io.Writer) {
+	return func(w io.Writer) {
+		line := u.OccurredAt.Local().Format(time.DateTime) + "\t" + u.Type
+		if u.TargetID != nil {
+			line += fmt.Sprintf("\ttarget %d", *u.TargetID)
+		}
+		if u.CatID != nil && u.TargetID == nil {
+			line += fmt.Sprintf("\tcat %d", *u.CatID)
+		}
+		switch {
+		case u.Notes != nil:
+			line += "\t" + firstLine(*u.Notes)
+		case u.Truncated:
+			line += "\t(notes too long, see missions show)"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func dueDate(m response.Mission) string {
+	switch {
+	case m.DueAt == nil:
+		return "-"
+	case m.IsOverdue:
+		return m.DueAt.Format(time.DateTime) + " (overdue)"
+	default:
+		return m.DueAt.Format(time.DateTime)
+	}
+}
+
+func catName(m response.Mission) string {
+	if m.CatID == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (#%d)", m.Cat.Name, *m.CatID)
+}
+
+func firstLine(s string) string {
+	line, _, cut := strings.Cut(s, "\n")
+	if cut {
+		return line + " …"
+	}
+	return line
+}
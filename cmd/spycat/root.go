@@ -0,0 +1,76 @@
+package main
+
+import (
+	"backend/pkg/api/caller"
+	"backend/pkg/client"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// app holds the state shared by all subcommands.
+type app struct {
+	apiURL string
+	role   string
+	catID  uint
+	output string
+	client *client.Client
+}
+
+func newRootCmd() *cobra.Command {
+	a := &app{}
+
+	root := &cobra.Command{
+		Use:           "spycat",
+		Short:         "Manage the Spy Cats Agency through its HTTP API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if a.output != outputTable && a.output != outputJSON {
+				return fmt.Errorf("unknown output mode %q, use %s or %s", a.output, outputTable, outputJSON)
+			}
+			a.client = client.New(a.apiURL, client.Caller(a.role, a.catID))
+			return nil
+		},
+	}
+
+	apiURL := os.Getenv("SPYCAT_API_URL")
+	if apiURL == "" {
+		apiURL = "http://localhost:8080"
+	}
+
+	role := os.Getenv("SPYCAT_ROLE")
+	if role == "" {
+		role = caller.RoleHandler
+	}
+	catID, _ := strconv.ParseUint(os.Getenv("SPYCAT_CAT_ID"), 10, 32)
+
+	root.PersistentFlags().StringVar(&a.apiURL, "api-url", apiURL, "API base URL (env SPYCAT_API_URL)")
+	root.PersistentFlags().StringVar(&a.role, "role", role, "caller role sent to the API, handler or cat (env SPYCAT_ROLE)")
+	root.PersistentFlags().UintVar(&a.catID, "cat-id", uint(catID), "caller cat ID for the cat role (env SPYCAT_CAT_ID)")
+	root.PersistentFlags().StringVarP(&a.output, "output", "o", outputTable, "output mode: table or json")
+
+	root.AddCommand(
+		a.catsCmd(),
+		a.missionsCmd(),
+		a.targetsCmd(),
+		a.notesCmd(),
+	)
+
+	return root
+}
+
+func parseID(name, value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return uint(id), nil
+}
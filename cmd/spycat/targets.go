@@ -0,0 +1,135 @@
+package main
+
+import (
+	request "backend/pkg/api/request/cat"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *app) targetsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "targets",
+		Short: "Manage mission targets",
+	}
+
+	complete := &cobra.Command{
+		Use:   "complete <mission_id> <target_id>",
+		Short: "Mark a target as completed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, targetID, err := parseTargetArgs(args)
+			if err != nil {
+				return err
+			}
+
+			if err := a.client.CompleteTarget(cmd.Context(), missionID, targetID); err != nil {
+				return err
+			}
+			return a.done("target completed")
+		},
+	}
+
+	cmd.AddCommand(complete)
+	return cmd
+}
+
+func (a *app) notesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Manage target notes",
+	}
+
+	edit := &cobra.Command{
+		Use:   "edit <mission_id> <target_id>",
+		Short: "Edit target notes in $EDITOR",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			missionID, targetID, err := parseTargetArgs(args)
+			if err != nil {
+				return err
+			}
+
+			mission, err := a.client.GetMission(cmd.Context(), missionID)
+			if err != nil {
+				return err
+			}
+			target, err := findTarget(mission, targetID)
+			if err != nil {
+				return err
+			}
+
+			notes, err := editText(target.Notes)
+			if err != nil {
+				return err
+			}
+			if notes == target.Notes {
+				return a.done("notes unchanged")
+			}
+
+			err = a.client.UpdateTarget(cmd.Context(), missionID, targetID, request.UpdateTarget{Notes: notes})
+			if err != nil {
+				return err
+			}
+			return a.done("notes updated")
+		},
+	}
+
+	cmd.AddCommand(edit)
+	return cmd
+}
+
+func parseTargetArgs(args []string) (missionID, targetID uint, err error) {
+	if missionID, err = parseID("mission_id", args[0]); err != nil {
+		return
+	}
+	targetID, err = parseID("target_id", args[1])
+	return
+}
+
+// editText opens text in the user's editor and returns the saved content,
+// without the final newline editors add to text lacking one.
+func editText(text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "spycat-notes-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+
+	// EDITOR may contain arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], f.Name())...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("run editor: %w", err)
+	}
+
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("read temp file: %w", err)
+	}
+	res := string(edited)
+	if !strings.HasSuffix(text, "\n") {
+		res = strings.TrimSuffix(strings.TrimSuffix(res, "\n"), "\r")
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEditText(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor scripts need a POSIX shell")
+	}
+	// as most editors save files
+	const appendNewline = `[ -z "$(tail -c1 "$1")" ] || printf '\n' >> "$1"`
+
+	tests := []struct {
+		name   string
+		editor string // script run on the file
+		text   string
+		want   string
+	}{
+		{name: "saved as is", editor: `true`, text: "met twice", want: "met twice"},
+		{name: "final newline added", editor: appendNewline, text: "met twice", want: "met twice"},
+		{name: "final newline kept", editor: appendNewline, text: "met twice\n", want: "met twice\n"},
+		{name: "edited", editor: `printf 'met three times\r\n' > "$1"`, text: "met twice", want: "met three times"},
+		{name: "emptied", editor: `printf '\n' > "$1"`, text: "met twice", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := filepath.Join(t.TempDir(), "editor")
+			if err := os.WriteFile(script, []byte("#!/bin/sh\n"+tt.editor+"\n"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("VISUAL", script)
+
+			got, err := editText(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("editText = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,23 @@
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 type (
 	Config struct {
-		Server   Server
-		Postgres Postgres
+		Server     Server
+		Postgres   Postgres
+		Encryption Encryption
+		Scheduler  Scheduler
+		Notifier   Notifier
+		Webhooks   Webhooks
+		Outbox     Outbox
+		Stream     Stream
+		Blob       Blob
+		Evidence   Evidence
 	}
 
 	Server struct {
@@ -13,12 +25,90 @@ type (
 		IsDev bool   `envconfig:"SERVER_IS_DEV"`
 	}
 
+	// Postgres configures the database, data backfills run on startup
+	// are given BackfillTimeout.
 	Postgres struct {
-		User     string `envconfig:"POSTGRES_USER"`
-		Password string `envconfig:"POSTGRES_PASSWORD"`
-		Host     string `envconfig:"POSTGRES_HOST"`
-		Port     string `envconfig:"POSTGRES_PORT"`
-		DBName   string `envconfig:"POSTGRES_DB_NAME"`
+		User            string        `envconfig:"POSTGRES_USER"`
+		Password        string        `envconfig:"POSTGRES_PASSWORD"`
+		Host            string        `envconfig:"POSTGRES_HOST"`
+		Port            string        `envconfig:"POSTGRES_PORT"`
+		DBName          string        `envconfig:"POSTGRES_DB_NAME"`
+		BackfillTimeout time.Duration `envconfig:"POSTGRES_BACKFILL_TIMEOUT" default:"10m"`
+	}
+
+	// Encryption of target notes, enabled by either master keys
+	// ("id:base64key,...") or a local key file.
+	Encryption struct {
+		MasterKeys  string `envconfig:"ENCRYPTION_MASTER_KEYS"`
+		ActiveKeyID string `envconfig:"ENCRYPTION_ACTIVE_KEY_ID"`
+		KeyFile     string `envconfig:"ENCRYPTION_KEY_FILE"`
+		// SearchNotes keeps a full-text index of encrypted notes.
+		SearchNotes bool `envconfig:"ENCRYPTION_SEARCH_NOTES"`
+	}
+
+	Scheduler struct {
+		OverdueInterval time.Duration `envconfig:"SCHEDULER_OVERDUE_INTERVAL" default:"1m"`
+	}
+
+	// Notifier selects where notifications go, "log" or "webhook".
+	Notifier struct {
+		Kind       string        `envconfig:"NOTIFIER" default:"log"`
+		WebhookURL string        `envconfig:"NOTIFIER_WEBHOOK_URL"`
+		Timeout    time.Duration `envconfig:"NOTIFIER_TIMEOUT" default:"5s"`
+	}
+
+	// Outbox configures the relay publishing events to the sink,
+	// "webhooks" or "log". Events the sink fails on are retried after
+	// BackoffBase, doubled per attempt up to BackoffMax, and dead-lettered
+	// after MaxAttempts. Published events are kept for Retention.
+	Outbox struct {
+		PollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+		Sink         string        `envconfig:"OUTBOX_SINK" default:"webhooks"`
+		MaxAttempts  int           `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+		BackoffBase  time.Duration `envconfig:"OUTBOX_BACKOFF_BASE" default:"1s"`
+		BackoffMax   time.Duration `envconfig:"OUTBOX_BACKOFF_MAX" default:"5m"`
+		Retention    time.Duration `envconfig:"OUTBOX_RETENTION" default:"168h"`
+	}
+
+	// Stream configures the bus feeding live mission updates, "postgres" fans
+	// them out to every replica through LISTEN/NOTIFY on Channel, "local" keeps
+	// them in this process.
+	Stream struct {
+		Bus     string `envconfig:"STREAM_BUS" default:"postgres"`
+		Channel string `envconfig:"STREAM_CHANNEL" default:"spycat_updates"`
+	}
+
+	// Blob selects where files are stored, "local" under Dir or "s3" in a bucket
+	// of any S3 compatible service such as MinIO. Downloads of local files are
+	// served by the API through URLs signed with URLSecret.
+	Blob struct {
+		Store       string `envconfig:"BLOB_STORE" default:"local"`
+		Dir         string `envconfig:"BLOB_DIR" default:"data/blobs"`
+		S3Endpoint  string `envconfig:"BLOB_S3_ENDPOINT"`
+		S3Region    string `envconfig:"BLOB_S3_REGION"`
+		S3Bucket    string `envconfig:"BLOB_S3_BUCKET" default:"spycat"`
+		S3AccessKey string `envconfig:"BLOB_S3_ACCESS_KEY"`
+		S3SecretKey string `envconfig:"BLOB_S3_SECRET_KEY"`
+		S3UseSSL    bool   `envconfig:"BLOB_S3_USE_SSL"`
+		URLSecret   string `envconfig:"BLOB_URL_SECRET"`
+	}
+
+	// Evidence configures target evidence uploads. With Required a target
+	// can only be completed once it has evidence.
+	Evidence struct {
+		Required  bool          `envconfig:"EVIDENCE_REQUIRED"`
+		MaxSize   int64         `envconfig:"EVIDENCE_MAX_SIZE" default:"20971520"`
+		URLExpiry time.Duration `envconfig:"EVIDENCE_URL_EXPIRY" default:"15m"`
+	}
+
+	// Webhooks configures the delivery worker, failed deliveries are retried
+	// after BackoffBase, doubled per attempt up to BackoffMax.
+	Webhooks struct {
+		PollInterval time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"5s"`
+		Timeout      time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+		MaxAttempts  int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+		BackoffBase  time.Duration `envconfig:"WEBHOOK_BACKOFF_BASE" default:"30s"`
+		BackoffMax   time.Duration `envconfig:"WEBHOOK_BACKOFF_MAX" default:"1h"`
 	}
 )
 
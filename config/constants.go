@@ -1,7 +1,7 @@
 package config
 
 import (
-	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -18,24 +18,198 @@ const (
 	CodeForbidden           ServiceCode = 6
 	CodeConflict            ServiceCode = 7
 	CodeExternalRequestFail ServiceCode = 8
+	CodeInternal            ServiceCode = 9
 )
 
+// EventTypes lists the domain events webhooks can subscribe to.
+var EventTypes = []string{EventCatCreated, EventMissionAssigned, EventTargetCompleted, EventMissionCompleted}
+
+// Priorities lists priority levels from lowest to highest.
+var Priorities = []string{PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical}
+
+// TargetOutcomes lists the outcomes a debrief can record for a target.
+var TargetOutcomes = []string{OutcomeSuccess, OutcomePartial, OutcomeFailure, OutcomeAborted}
+
 var ( // Errors
 	ErrRecordNotFound = gorm.ErrRecordNotFound
 
-	ErrCatNotFound = errors.New("cat not found")
+	ErrInvalidCaller = NewError(CodeUnauthorized, "invalid caller")
+	ErrHandlerOnly   = NewError(CodeForbidden, "only handlers are allowed")
+
+	ErrCatNotFound  = NewError(CodeNotFound, "cat not found")
+	ErrInvalidBreed = NewError(CodeBadRequest, "invalid breed")
+
+	ErrSalaryDateInFuture = NewError(CodeBadRequest, "salary change can't be effective in the future")
+	ErrInvalidMonth       = NewError(CodeBadRequest, "invalid month, expected YYYY-MM")
+	ErrNegativeSalary     = NewError(CodeBadRequest, "salary can't be negative")
+
+	ErrInvalidCatProfile = NewError(CodeBadRequest, "invalid cat profile")
+
+	ErrInvalidCatStatus           = NewError(CodeBadRequest, "invalid cat status")
+	ErrCatStatusTransitionInvalid = NewError(CodeForbidden, "cat status transition not allowed")
+	ErrCatNotActive               = NewError(CodeForbidden, "cat is not active")
+	ErrCatStatusChanged           = NewError(CodeConflict, "cat status changed concurrently")
+
+	ErrInvalidCurrency      = NewError(CodeBadRequest, "invalid ISO-4217 currency")
+	ErrInvalidExchangeRate  = NewError(CodeBadRequest, "invalid exchange rate")
+	ErrExchangeRateNotFound = NewError(CodeUnprocessableEntity, "exchange rate not found")
+
+	ErrImportRejected     = NewError(CodeUnprocessableEntity, "import rejected, no rows were saved")
+	ErrImportTooManyRows  = NewError(CodeBadRequest, "import has too many rows")
+	ErrUnknownFormat      = NewError(CodeBadRequest, "unknown format")
+	ErrUnknownContentType = NewError(CodeBadRequest, "unsupported content type")
+
+	ErrMissionNotFound             = NewError(CodeNotFound, "mission not found")
+	ErrMissionAlreadyAssigned      = NewError(CodeForbidden, "mission has cat already assigned")
+	ErrMissionAlreadyComplete      = NewError(CodeForbidden, "mission already complete")
+	ErrMissionHasMaxTargets        = NewError(CodeForbidden, "mission already has max number of targets")
+	ErrMissionHasInvalidTargetsLen = NewError(CodeBadRequest, "mission has invalid number of targets")
+	ErrBundleUnsupportedVersion    = NewError(CodeBadRequest, "unsupported mission bundle version")
+	ErrBundleHasNoCat              = NewError(CodeBadRequest, "mission bundle has no cat to attach")
+	ErrInvalidBundleTarget         = NewError(CodeBadRequest, "invalid bundle target")
+	ErrNoCandidates                = NewError(CodeUnprocessableEntity, "no available cats to assign")
+	ErrInvalidPriority             = NewError(CodeBadRequest, "invalid priority")
+	ErrInvalidSchedule             = NewError(CodeBadRequest, "invalid schedule")
+	ErrInvalidMissionFilter        = NewError(CodeBadRequest, "invalid mission filter")
+	ErrMissionAccessDenied         = NewError(CodeForbidden, "mission is not assigned to this cat")
 
-	ErrMissionNotFound             = errors.New("mission not found")
-	ErrMissionAlreadyAssigned      = errors.New("mission has cat already assigned")
-	ErrMissionAlreadyComplete      = errors.New("mission already complete")
-	ErrMissionHasMaxTargets        = errors.New("mission already has max number of targets")
-	ErrMissionHasInvalidTargetsLen = errors.New("mission has invalid number of targets")
+	ErrMissionTypeNotFound  = NewError(CodeNotFound, "mission type not found")
+	ErrInvalidMissionType   = NewError(CodeBadRequest, "invalid mission type")
+	ErrMissionTypeDuplicate = NewError(CodeConflict, "mission type with this name already exists")
+	ErrMissionTypeInUse     = NewError(CodeConflict, "mission type is in use")
+	ErrCountryNotAllowed    = NewError(CodeBadRequest, "country is not allowed for the mission type")
 
-	ErrTargetNotFound        = errors.New("target not found")
-	ErrTargetAlreadyComplete = errors.New("target already complete")
+	ErrTemplateNotFound  = NewError(CodeNotFound, "mission template not found")
+	ErrInvalidTemplate   = NewError(CodeBadRequest, "invalid mission template")
+	ErrTemplateDuplicate = NewError(CodeConflict, "mission template with this name already exists")
+
+	ErrBonusRuleNotFound    = NewError(CodeNotFound, "bonus rule not found")
+	ErrInvalidBonusRule     = NewError(CodeBadRequest, "invalid bonus rule")
+	ErrInvalidBonusRuleKind = NewError(CodeBadRequest, "invalid bonus rule kind")
+
+	ErrTargetNotFound        = NewError(CodeNotFound, "target not found")
+	ErrTargetAlreadyComplete = NewError(CodeForbidden, "target already complete")
+	ErrUnknownCountry        = NewError(CodeBadRequest, "unknown country")
+
+	ErrEmptySearchQuery   = NewError(CodeBadRequest, "search query is empty")
+	ErrUnknownSearchType  = NewError(CodeBadRequest, "unknown search type")
+	ErrInvalidSearchLimit = NewError(CodeBadRequest, "invalid search limit")
+
+	ErrWebhookNotFound    = NewError(CodeNotFound, "webhook not found")
+	ErrInvalidWebhook     = NewError(CodeBadRequest, "invalid webhook")
+	ErrUnknownEventType   = NewError(CodeBadRequest, "unknown event type")
+	ErrNothingToRedeliver = NewError(CodeUnprocessableEntity, "no deliveries to redeliver")
+
+	ErrInvalidMessage     = NewError(CodeBadRequest, "invalid message")
+	ErrAttachmentNotFound = NewError(CodeNotFound, "attachment not found")
+	ErrAttachmentTooLarge = NewError(CodeBadRequest, "attachment is too large")
+	ErrThreadArchived     = NewError(CodeForbidden, "mission thread is archived")
+
+	ErrEvidenceNotFound   = NewError(CodeNotFound, "evidence not found")
+	ErrEvidenceTooLarge   = NewError(CodeBadRequest, "evidence file is too large")
+	ErrEvidenceType       = NewError(CodeBadRequest, "unsupported evidence file type")
+	ErrEvidenceRequired   = NewError(CodeUnprocessableEntity, "target needs evidence before completion")
+	ErrInvalidDownloadURL = NewError(CodeForbidden, "download link is invalid or expired")
+
+	ErrDebriefNotFound       = NewError(CodeNotFound, "debrief not found")
+	ErrInvalidDebrief        = NewError(CodeBadRequest, "invalid debrief")
+	ErrMissionNotCompleted   = NewError(CodeUnprocessableEntity, "mission is not completed yet")
+	ErrSelfAssessmentCatOnly = NewError(CodeForbidden, "only the assigned cat can write the self-assessment")
+
+	ErrDossierNotFound  = NewError(CodeNotFound, "dossier not found")
+	ErrDossierDuplicate = NewError(CodeConflict, "similar dossier already exists")
+	ErrInvalidDossier   = NewError(CodeBadRequest, "invalid dossier")
 )
 
 const (
-	MinMissionTargets = 1
-	MaxMissionTargets = 3
+	// Target limits of the default mission type when it is created,
+	// afterwards they are managed through the API like any other type.
+	DefaultMissionType = "standard"
+	MinMissionTargets  = 1
+	MaxMissionTargets  = 3
+	MaxTypeTargets     = 100 // upper bound of any mission type
+
+	MissionBundleVersion = 1
+
+	PriorityLow      = "low"
+	PriorityNormal   = "normal"
+	PriorityHigh     = "high"
+	PriorityCritical = "critical"
+
+	MissionSortCreatedAt = "created_at"
+	MissionSortPriority  = "priority"
+	MissionSortStartsAt  = "starts_at"
+	MissionSortDueAt     = "due_at"
+
+	NotificationMissionOverdue = "mission.overdue"
+
+	EventCatCreated       = "cat.created"
+	EventMissionAssigned  = "mission.assigned"
+	EventTargetCompleted  = "target.completed"
+	EventMissionCompleted = "mission.completed"
+
+	// Live mission updates, besides the events above
+	UpdateTargetNotes   = "target.notes_updated"
+	UpdateMessagePosted = "message.posted"
+
+	StreamBuffer    = 64 // updates queued per subscriber
+	StreamHeartbeat = 15 * time.Second
+
+	MaxMessageLen         = 4000 // characters
+	MaxMessageAttachments = 5
+	MaxAttachmentSize     = 1 << 20 // bytes
+
+	OutcomeSuccess = "success"
+	OutcomePartial = "partial"
+	OutcomeFailure = "failure"
+	OutcomeAborted = "aborted"
+
+	MinDebriefRating = 1
+	MaxDebriefRating = 5
+	MaxDebriefLen    = 20000 // characters of each debrief text
+
+	DebriefFormatJSON     = "json"
+	DebriefFormatMarkdown = "markdown"
+	DebriefFormatHTML     = "html"
+
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+
+	OutboxBatch = 100 // events claimed per relay
+
+	MinWebhookSecretLen  = 16
+	MaxWebhookDeliveries = 100 // listed per webhook
+	WebhookDeliveryBatch = 50  // claimed per worker poll
+
+	CatStatusActive  = "active"
+	CatStatusOnLeave = "on_leave"
+	CatStatusRetired = "retired"
+	CatStatusKIA     = "kia"
+
+	SearchTypeTarget   = "target"
+	SearchTypeCat      = "cat"
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	// Name similarity (0..1) from which a target is linked to an existing dossier
+	DossierMatchThreshold = 0.85
+
+	MinSkillProficiency = 1
+	MaxSkillProficiency = 5
+	MaxClearanceLevel   = 5
+
+	DateLayout  = "2006-01-02"
+	MonthLayout = "2006-01"
+
+	InitialSalaryReason = "initial salary"
+	DefaultCurrency     = "USD"
+
+	BonusPerTarget            = "per_target"
+	BonusPerMission           = "per_mission"
+	BonusCountryMultiplier    = "country_multiplier"
+	BonusExperienceMultiplier = "experience_multiplier"
+
+	MaxImportRows  = 1000
+	MaxImportBytes = 4 << 20 // 4 MiB
 )
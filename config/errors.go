@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Error is a domain error. Message and Detail are safe to show to clients,
+// while Err holds the internal cause, which is only logged.
+type Error struct {
+	Code    ServiceCode
+	Message string
+	Detail  string
+	Err     error
+}
+
+// NewError creates a domain error with the given service code and public message.
+func NewError(code ServiceCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap creates a domain error with the given service code and public message
+// which wraps the internal cause.
+func Wrap(code ServiceCode, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// BadRequest wraps a client input error, exposing its text as the public detail.
+func BadRequest(message string, err error) *Error {
+	e := &Error{Code: CodeBadRequest, Message: message, Err: err}
+	if err != nil {
+		e.Detail = err.Error()
+	}
+	return e
+}
+
+// DBError maps a database error to a domain error. Record not found becomes
+// CodeNotFound, everything else CodeDatabaseError with a generic message.
+func DBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Wrap(CodeNotFound, "record not found", err)
+	}
+	return Wrap(CodeDatabaseError, "database error", err)
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors by code and message, so copies of a sentinel
+// produced by WithDetail or WithCause still match it.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code && e.Message == t.Message
+}
+
+// Public returns the client-safe text of the error.
+func (e *Error) Public() string {
+	if e.Detail != "" {
+		return e.Message + ": " + e.Detail
+	}
+	return e.Message
+}
+
+// WithDetail returns a copy of the error with the given public detail.
+func (e *Error) WithDetail(detail string) *Error {
+	cp := *e
+	cp.Detail = detail
+	return &cp
+}
+
+// WithCause returns a copy of the error wrapping the given internal cause.
+func (e *Error) WithCause(err error) *Error {
+	cp := *e
+	cp.Err = err
+	return &cp
+}
+
+// AsError extracts the domain error from the chain. Unknown errors
+// are wrapped into a CodeInternal error, hiding their text.
+func AsError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Wrap(CodeInternal, "internal error", err)
+}
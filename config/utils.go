@@ -2,17 +2,6 @@ package config
 
 import "net/http"
 
-func DBErrToServiceCode(err error) ServiceCode {
-	switch err {
-	case nil:
-		return CodeOK
-	case ErrRecordNotFound:
-		return CodeNotFound
-	default:
-		return CodeDatabaseError
-	}
-}
-
 func CodeToHttpStatus(code ServiceCode) int {
 	switch code {
 	case CodeOK:
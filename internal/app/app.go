@@ -7,27 +7,67 @@ import (
 	"time"
 
 	"backend/internal/controller/http/middleware"
+	repobonus "backend/internal/storage/postgres/bonus"
 	repocat "backend/internal/storage/postgres/cat"
+	repodebrief "backend/internal/storage/postgres/debrief"
+	repodossier "backend/internal/storage/postgres/dossier"
+	repoevidence "backend/internal/storage/postgres/evidence"
+	repoexchangerate "backend/internal/storage/postgres/exchangerate"
+	repomessage "backend/internal/storage/postgres/message"
 	repomission "backend/internal/storage/postgres/mission"
+	repomissiontype "backend/internal/storage/postgres/missiontype"
+	repooutbox "backend/internal/storage/postgres/outbox"
+	reposalary "backend/internal/storage/postgres/salary"
+	reposearch "backend/internal/storage/postgres/search"
 	repotarget "backend/internal/storage/postgres/target"
+	repotemplate "backend/internal/storage/postgres/template"
+	repowebhook "backend/internal/storage/postgres/webhook"
 
+	svcbonus "backend/internal/service/bonus"
 	svccat "backend/internal/service/cat"
+	svcdebrief "backend/internal/service/debrief"
+	svcdossier "backend/internal/service/dossier"
+	svcevidence "backend/internal/service/evidence"
 	svcmission "backend/internal/service/mission"
+	svcmissiontype "backend/internal/service/missiontype"
+	svcoutbox "backend/internal/service/outbox"
+	svcoverdue "backend/internal/service/overdue"
+	svcpayroll "backend/internal/service/payroll"
+	svcsearch "backend/internal/service/search"
+	svctemplate "backend/internal/service/template"
+	svcwebhook "backend/internal/service/webhook"
 
+	"backend/internal/controller/http/docs"
+	handlerbonus "backend/internal/controller/http/v1/bonus"
 	handlercat "backend/internal/controller/http/v1/cat"
+	handlerdebrief "backend/internal/controller/http/v1/debrief"
+	handlerdossier "backend/internal/controller/http/v1/dossier"
+	handlerevidence "backend/internal/controller/http/v1/evidence"
 	handlermission "backend/internal/controller/http/v1/mission"
+	handlermissiontype "backend/internal/controller/http/v1/missiontype"
+	handlerpayroll "backend/internal/controller/http/v1/payroll"
+	handlersearch "backend/internal/controller/http/v1/search"
+	handlertemplate "backend/internal/controller/http/v1/template"
+	handlerwebhook "backend/internal/controller/http/v1/webhook"
 
 	"backend/config"
 	"backend/internal/entity/cat"
+	"backend/pkg/country"
+	"backend/pkg/eventbus"
 	"backend/pkg/httpserver"
+	"backend/pkg/notifier"
 	"backend/pkg/postgres"
 	"backend/pkg/validator/breed"
 	structvalidator "backend/pkg/validator/struct"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func Run(cfg config.Config) {
@@ -69,17 +109,150 @@ func Run(cfg config.Config) {
 	breedValidator := breed.NewValidator()
 	validator := structvalidator.NewValidator()
 
+	notesCipher, err := newNotesCipher(cfg.Encryption)
+	if err != nil {
+		logger.Error("unable to load encryption keys", "err", err)
+		return
+	}
+	searchEncrypted := cfg.Encryption.SearchNotes
+
 	catRepo := repocat.NewRepo(client)
-	missionRepo := repomission.NewRepo(client)
-	targetRepo := repotarget.NewRepo(client)
+	missionRepo := repomission.NewRepo(client, notesCipher)
+	targetRepo := repotarget.NewRepo(client, notesCipher, searchEncrypted)
+	salaryRepo := reposalary.NewRepo(client)
+	exchangeRateRepo := repoexchangerate.NewRepo(client)
+	bonusRepo := repobonus.NewRepo(client)
+	dossierRepo := repodossier.NewRepo(client, notesCipher)
+	searchRepo := reposearch.NewRepo(client, notesCipher, searchEncrypted)
+	webhookRepo := repowebhook.NewRepo(client)
+	outboxRepo := repooutbox.NewRepo(client)
+	messageRepo := repomessage.NewRepo(client)
+	evidenceRepo := repoevidence.NewRepo(client)
+	debriefRepo := repodebrief.NewRepo(client)
+	templateRepo := repotemplate.NewRepo(client)
+	missionTypeRepo := repomissiontype.NewRepo(client)
+
+	backfillCtx, cancelBackfills := context.WithTimeout(context.Background(), cfg.Postgres.BackfillTimeout)
+	defer cancelBackfills()
+
+	if err = runBackfills(backfillCtx, client.Instance(), []backfill{
+		{name: "initial salaries", run: func(ctx context.Context) error {
+			return salaryRepo.BackfillInitialSalaries(ctx, config.InitialSalaryReason)
+		}},
+		{name: "target countries", run: func(ctx context.Context) error {
+			unknown, err := targetRepo.NormalizeCountries(ctx, country.Normalize)
+			if len(unknown) > 0 {
+				logger.Warn("targets with unknown countries left as is", "countries", unknown)
+			}
+			return err
+		}},
+		{name: "bonus rule countries", run: func(ctx context.Context) error {
+			unknown, err := bonusRepo.NormalizeCountries(ctx, country.Normalize)
+			if len(unknown) > 0 {
+				logger.Warn("bonus rules with unknown countries left as is", "countries", unknown)
+			}
+			return err
+		}},
+		{name: "cat home countries", run: func(ctx context.Context) error {
+			unknown, err := catRepo.NormalizeHomeCountries(ctx, country.Normalize)
+			if len(unknown) > 0 {
+				logger.Warn("cats with unknown home countries left as is", "countries", unknown)
+			}
+			return err
+		}},
+		{name: "target dossiers", run: dossierRepo.BackfillDossiers},
+		{name: "default mission type", run: func(ctx context.Context) error {
+			return missionTypeRepo.EnsureDefaultType(ctx, cat.MissionType{
+				Name:        config.DefaultMissionType,
+				Description: "Default type of missions created without one",
+				MinTargets:  config.MinMissionTargets,
+				MaxTargets:  config.MaxMissionTargets,
+			})
+		}},
+		// runs again whenever notes search is switched on or off
+		{
+			name:     notesIndexBackfill(targetRepo.IndexesNotes()),
+			run:      targetRepo.ReindexNotes,
+			replaces: notesIndexBackfill(!targetRepo.IndexesNotes()),
+		},
+	}, logger); err != nil {
+		logger.Error("data backfill failed", "err", err)
+		return
+	}
+	cancelBackfills()
 
-	catSvc := svccat.NewService(catRepo, breedValidator, logger)
+	localBus := eventbus.New(config.StreamBuffer)
+	streamBus, listenBus, err := newStreamBus(cfg.Stream, localBus, client, logger)
+	if err != nil {
+		logger.Error("unable to configure stream bus", "err", err)
+		return
+	}
+
+	webhookSvc := svcwebhook.NewService(webhookRepo, cfg.Webhooks, logger)
+	catSvc := svccat.NewService(catRepo, salaryRepo, breedValidator, outboxRepo, logger)
 	missionSvc := svcmission.NewService(
 		missionRepo,
 		targetRepo,
 		catRepo,
+		bonusRepo,
+		dossierRepo,
+		messageRepo,
+		evidenceRepo,
+		templateRepo,
+		missionTypeRepo,
+		outboxRepo,
+		streamBus,
 		logger,
+		cfg.Evidence.Required,
 	)
+	payrollSvc := svcpayroll.NewService(catRepo, salaryRepo, exchangeRateRepo, logger)
+	bonusSvc := svcbonus.NewService(bonusRepo, missionRepo, catRepo, logger)
+	dossierSvc := svcdossier.NewService(dossierRepo, logger)
+	searchSvc := svcsearch.NewService(searchRepo, logger)
+
+	blobStore, err := newBlobStore(ctx, cfg.Blob)
+	if err != nil {
+		logger.Error("unable to configure blob store", "err", err)
+		return
+	}
+	urlSigner, err := newURLSigner(cfg.Blob, logger)
+	if err != nil {
+		logger.Error("unable to configure download url signer", "err", err)
+		return
+	}
+	templateSvc := svctemplate.NewService(templateRepo, missionSvc, logger)
+	missionTypeSvc := svcmissiontype.NewService(missionTypeRepo, logger)
+	debriefSvc := svcdebrief.NewService(debriefRepo, missionRepo, logger)
+	evidenceSvc := svcevidence.NewService(evidenceRepo, missionRepo, blobStore, urlSigner, cfg.Evidence, logger)
+
+	missionNotifier, err := newNotifier(cfg.Notifier, logger)
+	if err != nil {
+		logger.Error("unable to configure notifier", "err", err)
+		return
+	}
+	overdueSvc := svcoverdue.NewService(missionRepo, missionNotifier, logger)
+
+	outboxSink, err := newOutboxSink(cfg.Outbox, webhookSvc, logger)
+	if err != nil {
+		logger.Error("unable to configure outbox", "err", err)
+		return
+	}
+	outboxSvc := svcoutbox.NewService(outboxRepo, outboxSink, cfg.Outbox, logger)
+
+	// Background jobs
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobs sync.WaitGroup
+	jobs.Go(func() { overdueSvc.Run(jobsCtx, cfg.Scheduler.OverdueInterval) })
+	jobs.Go(func() { webhookSvc.Run(jobsCtx) })
+	jobs.Go(func() { outboxSvc.Run(jobsCtx) })
+	if listenBus != nil {
+		jobs.Go(func() { listenBus(jobsCtx) })
+	}
+	defer func() {
+		stopJobs()
+		jobs.Wait()
+	}()
 
 	// HTTP server
 
@@ -93,6 +266,8 @@ func Run(cfg config.Config) {
 	g.Use(
 		// gin.Logger(), gin.Recovery(),
 		mw.Logger(), mw.Recovery(),
+		mw.ErrorHandler(),
+		mw.Caller(),
 	)
 
 	g.GET("/ping", func(c *gin.Context) {
@@ -111,6 +286,66 @@ func Run(cfg config.Config) {
 		validator,
 	)
 
+	handlerpayroll.InitHandler(
+		g, logger,
+		payrollSvc,
+		validator,
+	)
+
+	handlerbonus.InitHandler(
+		g, logger,
+		bonusSvc,
+		validator,
+	)
+
+	handlerdossier.InitHandler(
+		g, logger,
+		dossierSvc,
+		validator,
+	)
+
+	handlertemplate.InitHandler(
+		g, logger,
+		templateSvc,
+		validator,
+	)
+
+	handlermissiontype.InitHandler(
+		g, logger,
+		missionTypeSvc,
+		validator,
+	)
+
+	handlerdebrief.InitHandler(
+		g, logger,
+		debriefSvc,
+	)
+
+	handlerevidence.InitHandler(
+		g, logger,
+		evidenceSvc,
+		cfg.Evidence.MaxSize,
+	)
+
+	handlersearch.InitHandler(
+		g, logger,
+		searchSvc,
+	)
+
+	handlerwebhook.InitHandler(
+		g, logger,
+		webhookSvc,
+		validator,
+	)
+
+	if err := docs.InitHandler(g); err != nil {
+		logger.Error("unable to build OpenAPI spec", "err", err)
+		return
+	}
+	for _, route := range docs.Undocumented(g.Routes()) {
+		logger.Warn("route missing from OpenAPI spec", "route", route)
+	}
+
 	server := httpserver.New(
 		g,
 		httpserver.Port(cfg.Server.Port),
@@ -128,6 +363,9 @@ func Run(cfg config.Config) {
 		logger.Error("HTTP server error", "err", err)
 	}
 
+	// ends open mission streams, so the shutdown does not wait on them
+	localBus.Close()
+
 	if err := server.Shutdown(); err != nil {
 		logger.Error("server shutdown error", "err", err)
 	} else {
@@ -135,11 +373,194 @@ func Run(cfg config.Config) {
 	}
 }
 
+func newNotifier(cfg config.Notifier, l *slog.Logger) (notifier.Notifier, error) {
+	switch cfg.Kind {
+	case "log":
+		return notifier.NewLog(l), nil
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("NOTIFIER_WEBHOOK_URL is required for the webhook notifier")
+		}
+		return notifier.NewWebhook(cfg.WebhookURL, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Kind)
+	}
+}
+
+type streamBus interface {
+	eventbus.Publisher
+	Subscribe(topic string) *eventbus.Subscription
+}
+
+// newStreamBus returns the bus of live mission updates delivered to local, and
+// for the postgres bus the listener to run, which feeds local from other replicas.
+func newStreamBus(cfg config.Stream, local *eventbus.Bus, client *postgres.Postgres, l *slog.Logger) (streamBus, func(context.Context), error) {
+	switch cfg.Bus {
+	case "local":
+		return local, nil, nil
+	case "postgres":
+		if cfg.Channel == "" {
+			return nil, nil, fmt.Errorf("STREAM_CHANNEL is required for the postgres bus")
+		}
+		bus := eventbus.NewPostgres(local, client, cfg.Channel, l)
+		return bus, bus.Run, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown stream bus %q", cfg.Bus)
+	}
+}
+
+func newOutboxSink(cfg config.Outbox, webhooks svcoutbox.Sink, l *slog.Logger) (svcoutbox.Sink, error) {
+	switch cfg.Sink {
+	case "webhooks":
+		return webhooks, nil
+	case "log":
+		return svcoutbox.NewLogSink(l), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
+	}
+}
+
 func runMigrations(client *postgres.Postgres) error {
 	slog.Info("running migrations...")
-	return client.Instance().AutoMigrate(
+	if err := migrateDuplicateDeliveries(client); err != nil {
+		return err
+	}
+	if err := client.Instance().AutoMigrate(
 		&cat.Cat{},
+		&cat.CatSkill{},
+		&cat.CatLanguage{},
+		&cat.CatLeave{},
+		&cat.CatStatusChange{},
+		&cat.MissionType{},
+		&cat.MissionTypeCountry{},
 		&cat.Mission{},
 		&cat.Target{},
-	)
+		&cat.Dossier{},
+		&cat.DossierAlias{},
+		&cat.DossierAttribute{},
+		&cat.SalaryChange{},
+		&cat.ExchangeRate{},
+		&cat.BonusRule{},
+		&cat.BonusEntry{},
+		&cat.Webhook{},
+		&cat.WebhookDelivery{},
+		&cat.OutboxEvent{},
+		&cat.Evidence{},
+		&cat.MissionTemplate{},
+		&cat.TemplateTarget{},
+		&cat.Debrief{},
+		&cat.DebriefTarget{},
+		&cat.Message{},
+		&cat.MessageAttachment{},
+	); err != nil {
+		return err
+	}
+
+	if err := migrateLegacySalaries(client); err != nil {
+		return err
+	}
+	if err := migrateSearchIndexes(client); err != nil {
+		return err
+	}
+	return migrateBackfills(client.Instance())
+}
+
+// migrateLegacySalaries moves single currency salary columns into
+// money columns with config.DefaultCurrency, then drops them.
+func migrateLegacySalaries(client *postgres.Postgres) error {
+	db := client.Instance()
+
+	columns := []struct {
+		model  any
+		table  string
+		legacy string
+		prefix string
+	}{
+		{&cat.Cat{}, "cats", "salary", "salary_"},
+		{&cat.SalaryChange{}, "salary_changes", "salary", "salary_"},
+		{&cat.SalaryChange{}, "salary_changes", "previous_salary", "previous_salary_"},
+	}
+
+	for _, c := range columns {
+		if !db.Migrator().HasColumn(c.model, c.legacy) {
+			continue
+		}
+
+		slog.Info("migrating legacy salary column", "table", c.table, "column", c.legacy)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(fmt.Sprintf(
+				`UPDATE %s SET %samount = %s, %scurrency = ? WHERE %scurrency IS NULL OR %scurrency = ''`,
+				c.table, c.prefix, c.legacy, c.prefix, c.prefix, c.prefix),
+				config.DefaultCurrency).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(c.model, c.legacy)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateDuplicateDeliveries turns the later first deliveries of an event to a
+// webhook into redeliveries of the earliest one, before the unique index on
+// first deliveries is created.
+func migrateDuplicateDeliveries(client *postgres.Postgres) error {
+	db := client.Instance()
+	if !db.Migrator().HasTable(&cat.WebhookDelivery{}) ||
+		db.Migrator().HasIndex(&cat.WebhookDelivery{}, "idx_webhook_event") {
+		return nil
+	}
+
+	return db.Exec(`
+		UPDATE webhook_deliveries d
+		SET redelivery_of = first.id
+		FROM (
+			SELECT webhook_id, event_id, MIN(id) AS id FROM webhook_deliveries
+			WHERE redelivery_of IS NULL
+			GROUP BY webhook_id, event_id
+			HAVING COUNT(*) > 1
+		) first
+		WHERE d.webhook_id = first.webhook_id AND d.event_id = first.event_id
+			AND d.redelivery_of IS NULL AND d.id <> first.id`).Error
+}
+
+// migrateSearchIndexes adds generated tsvector columns with GIN indexes
+// used by full-text search. Notes may be encrypted, so they are indexed
+// by the target repo in notes_search instead.
+func migrateSearchIndexes(client *postgres.Postgres) error {
+	return client.Instance().Transaction(func(tx *gorm.DB) error {
+		var expr string
+		if err := tx.Raw(`
+			SELECT COALESCE(generation_expression, '') FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'targets' AND column_name = 'search'`).
+			Scan(&expr).Error; err != nil {
+			return err
+		}
+		if strings.Contains(expr, "notes") {
+			if err := tx.Exec(`ALTER TABLE targets DROP COLUMN search`).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, stmt := range []string{
+			`ALTER TABLE targets ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english'::regconfig, COALESCE(name, '')), 'A') ||
+				setweight(to_tsvector('simple'::regconfig, COALESCE(country, '')), 'B')) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_targets_search ON targets USING GIN (search)`,
+			`ALTER TABLE targets ADD COLUMN IF NOT EXISTS notes_search tsvector`,
+			`CREATE INDEX IF NOT EXISTS idx_targets_notes_search ON targets USING GIN (notes_search)`,
+
+			`ALTER TABLE cats ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+				to_tsvector('simple'::regconfig, COALESCE(name, ''))) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_cats_search ON cats USING GIN (search)`,
+		} {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+// backfill is a data migration run once, on the first startup after it
+// was added. Applied backfills are recorded in data_backfills.
+type backfill struct {
+	name string
+	run  func(ctx context.Context) error
+	// replaces is the backfill this one undoes, run again once this one is.
+	replaces string
+}
+
+// notesIndexBackfill names the notes search index backfill of the setting.
+func notesIndexBackfill(indexNotes bool) string {
+	if indexNotes {
+		return "target notes index"
+	}
+	return "target notes index removal"
+}
+
+// migrateBackfills creates the table recording applied backfills.
+func migrateBackfills(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE TABLE IF NOT EXISTS data_backfills (
+			name text PRIMARY KEY,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`).Error
+}
+
+// runBackfills runs, in order, the backfills not applied yet. Backfills are
+// idempotent: replicas starting together may both run one before it is recorded.
+func runBackfills(ctx context.Context, db *gorm.DB, backfills []backfill, l *slog.Logger) error {
+	db = db.WithContext(ctx)
+
+	var applied []string
+	if err := db.Raw(`SELECT name FROM data_backfills`).Scan(&applied).Error; err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(applied))
+	for _, name := range applied {
+		done[name] = true
+	}
+
+	for _, b := range backfills {
+		if done[b.name] {
+			continue
+		}
+
+		l.Info("running backfill", "name", b.name)
+		if err := b.run(ctx); err != nil {
+			return err
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				INSERT INTO data_backfills (name)
+				VALUES (?)
+				ON CONFLICT (name) DO NOTHING`,
+				b.name).Error; err != nil {
+				return err
+			}
+			if b.replaces == "" {
+				return nil
+			}
+			return tx.Exec(`DELETE FROM data_backfills WHERE name = ?`, b.replaces).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"backend/pkg/postgres/postgrestest"
+)
+
+func TestRunBackfills(t *testing.T) {
+	errFailed := errors.New("backfill failed")
+
+	tests := []struct {
+		name        string
+		applied     []string
+		failing     string
+		wantRun     []string
+		wantApplied []string
+		wantDeleted []string
+		wantErr     error
+	}{
+		{
+			name:        "first startup",
+			wantRun:     []string{"salaries", "countries", "index"},
+			wantApplied: []string{"salaries", "countries", "index"},
+			wantDeleted: []string{"index removal"},
+		},
+		{
+			name:        "applied backfills are skipped",
+			applied:     []string{"salaries", "index"},
+			wantRun:     []string{"countries"},
+			wantApplied: []string{"countries"},
+		},
+		{
+			name:        "replaced backfill runs again",
+			applied:     []string{"salaries", "countries", "index removal"},
+			wantRun:     []string{"index"},
+			wantApplied: []string{"index"},
+			wantDeleted: []string{"index removal"},
+		},
+		{
+			name:        "failed backfill is not recorded",
+			failing:     "countries",
+			wantRun:     []string{"salaries", "countries"},
+			wantApplied: []string{"salaries"},
+			wantErr:     errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotApplied, gotDeleted []string
+			db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+				switch {
+				case strings.HasPrefix(q.SQL, "SELECT name FROM data_backfills"):
+					res := postgrestest.Result{Columns: []string{"name"}}
+					for _, name := range tt.applied {
+						res.Rows = append(res.Rows, []driver.Value{name})
+					}
+					return res
+				case strings.HasPrefix(q.SQL, "INSERT INTO data_backfills"):
+					gotApplied = append(gotApplied, q.Args[0].(string))
+					return postgrestest.Result{RowsAffected: 1}
+				case strings.HasPrefix(q.SQL, "DELETE FROM data_backfills"):
+					gotDeleted = append(gotDeleted, q.Args[0].(string))
+					return postgrestest.Result{RowsAffected: 1}
+				}
+				return postgrestest.Result{Err: errors.New("unexpected query " + q.SQL)}
+			})
+
+			var gotRun []string
+			step := func(name string) func(context.Context) error {
+				return func(context.Context) error {
+					gotRun = append(gotRun, name)
+					if name == tt.failing {
+						return errFailed
+					}
+					return nil
+				}
+			}
+
+			err := runBackfills(context.Background(), db.Instance(), []backfill{
+				{name: "salaries", run: step("salaries")},
+				{name: "countries", run: step("countries")},
+				{name: "index", run: step("index"), replaces: "index removal"},
+			}, slog.New(slog.DiscardHandler))
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotRun, tt.wantRun) {
+				t.Errorf("run = %v, want %v", gotRun, tt.wantRun)
+			}
+			if !reflect.DeepEqual(gotApplied, tt.wantApplied) {
+				t.Errorf("recorded = %v, want %v", gotApplied, tt.wantApplied)
+			}
+			if !reflect.DeepEqual(gotDeleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", gotDeleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"backend/config"
+	"backend/pkg/blob"
+)
+
+// newBlobStore returns the configured store of evidence files.
+func newBlobStore(ctx context.Context, cfg config.Blob) (blob.Store, error) {
+	switch cfg.Store {
+	case "local":
+		return blob.NewLocal(cfg.Dir)
+	case "s3":
+		if cfg.S3Endpoint == "" {
+			return nil, errors.New("BLOB_S3_ENDPOINT is required for the s3 store")
+		}
+		return blob.NewS3(ctx, cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket,
+			cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3UseSSL)
+	default:
+		return nil, fmt.Errorf("unknown blob store %q", cfg.Store)
+	}
+}
+
+// newURLSigner signs download URLs served by the API. Without BLOB_URL_SECRET
+// a random secret is used, URLs then only work on this instance until restart.
+func newURLSigner(cfg config.Blob, l *slog.Logger) (blob.URLSigner, error) {
+	if cfg.URLSecret != "" {
+		return blob.NewURLSigner([]byte(cfg.URLSecret)), nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return blob.URLSigner{}, err
+	}
+	if cfg.Store == "local" {
+		l.Warn("BLOB_URL_SECRET is not set, download URLs are only valid on this instance until restart")
+	}
+	return blob.NewURLSigner(secret), nil
+}
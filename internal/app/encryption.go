@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+
+	"backend/config"
+	repotarget "backend/internal/storage/postgres/target"
+	"backend/pkg/envelope"
+	"backend/pkg/postgres"
+)
+
+// newNotesCipher returns the target notes cipher, disabled when no
+// master keys are configured.
+func newNotesCipher(cfg config.Encryption) (envelope.Cipher, error) {
+	switch {
+	case cfg.MasterKeys != "" && cfg.KeyFile != "":
+		return envelope.Cipher{}, errors.New("ENCRYPTION_MASTER_KEYS and ENCRYPTION_KEY_FILE are exclusive")
+	case cfg.MasterKeys != "":
+		kms, err := envelope.ParseKeyring(cfg.MasterKeys, cfg.ActiveKeyID)
+		if err != nil {
+			return envelope.Cipher{}, err
+		}
+		return envelope.New(kms), nil
+	case cfg.KeyFile != "":
+		kms, err := envelope.LoadKeyFile(cfg.KeyFile)
+		if err != nil {
+			return envelope.Cipher{}, err
+		}
+		return envelope.New(kms), nil
+	}
+	return envelope.Cipher{}, nil
+}
+
+// RotateNotes re-encrypts target notes with the active master key,
+// batchSize targets per transaction. Plaintext notes get encrypted too.
+func RotateNotes(cfg config.Config, batchSize int) error {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	notesCipher, err := newNotesCipher(cfg.Encryption)
+	if err != nil {
+		return err
+	}
+	if !notesCipher.Enabled() {
+		return errors.New("no master keys configured")
+	}
+
+	ctx := context.Background()
+	client, err := postgres.New(ctx,
+		cfg.Postgres.User,
+		cfg.Postgres.Password,
+		cfg.Postgres.Host,
+		cfg.Postgres.Port,
+		cfg.Postgres.DBName,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err = runMigrations(client); err != nil {
+		return err
+	}
+
+	targetRepo := repotarget.NewRepo(client, notesCipher, cfg.Encryption.SearchNotes)
+	rotated, err := targetRepo.RotateNotes(ctx, batchSize)
+	logger.Info("target notes rotated", "count", rotated)
+	return err
+}
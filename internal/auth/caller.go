@@ -0,0 +1,75 @@
+// Package auth carries the identity of the API caller through the context.
+//
+// The service is expected to run behind a gateway which authenticates users
+// and sets the caller headers, they are trusted as is.
+package auth
+
+import (
+	"backend/config"
+	"backend/pkg/api/caller"
+	"context"
+)
+
+// Caller is a handler with full access, or a field cat limited to its own missions.
+// The zero Caller, for requests without caller headers, has no access.
+type Caller struct {
+	Role  string
+	CatID uint // caller.RoleCat only
+}
+
+type callerKey struct{}
+
+func WithCaller(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// FromContext returns the caller, the zero Caller if there is none.
+func FromContext(ctx context.Context) Caller {
+	c, _ := ctx.Value(callerKey{}).(Caller)
+	return c
+}
+
+func (c Caller) IsHandler() bool {
+	return c.Role == caller.RoleHandler
+}
+
+// CanAccessMission reports whether the caller may access a mission assigned
+// to catID, nil for unassigned missions.
+func (c Caller) CanAccessMission(catID *uint) bool {
+	if c.IsHandler() {
+		return true
+	}
+	return c.Role == caller.RoleCat && catID != nil && *catID == c.CatID
+}
+
+// RequireCaller returns config.ErrInvalidCaller if the request has no caller.
+func RequireCaller(ctx context.Context) error {
+	if FromContext(ctx).Role == "" {
+		return config.ErrInvalidCaller.WithDetail("missing " + caller.HeaderRole)
+	}
+	return nil
+}
+
+// RequireHandler returns config.ErrInvalidCaller if the request has no caller
+// and config.ErrHandlerOnly if the caller is a cat.
+func RequireHandler(ctx context.Context) error {
+	if err := RequireCaller(ctx); err != nil {
+		return err
+	}
+	if !FromContext(ctx).IsHandler() {
+		return config.ErrHandlerOnly
+	}
+	return nil
+}
+
+// RequireMission returns config.ErrInvalidCaller if the request has no caller and
+// config.ErrMissionAccessDenied if the caller may not access a mission assigned to catID.
+func RequireMission(ctx context.Context, catID *uint) error {
+	if err := RequireCaller(ctx); err != nil {
+		return err
+	}
+	if !FromContext(ctx).CanAccessMission(catID) {
+		return config.ErrMissionAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"backend/config"
+	"backend/pkg/api/caller"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequire(t *testing.T) {
+	catID, otherCatID := uint(7), uint(8)
+	handler := Caller{Role: caller.RoleHandler}
+	cat := Caller{Role: caller.RoleCat, CatID: catID}
+
+	tests := []struct {
+		name        string
+		caller      *Caller // nil for a request without caller
+		wantCaller  error
+		wantHandler error
+		wantMission error // of a mission assigned to catID
+		wantOther   error // of a mission assigned to otherCatID
+		wantFree    error // of an unassigned mission
+	}{
+		{
+			name:        "no caller",
+			wantCaller:  config.ErrInvalidCaller,
+			wantHandler: config.ErrInvalidCaller,
+			wantMission: config.ErrInvalidCaller,
+			wantOther:   config.ErrInvalidCaller,
+			wantFree:    config.ErrInvalidCaller,
+		},
+		{
+			name:   "handler",
+			caller: &handler,
+		},
+		{
+			name:        "cat",
+			caller:      &cat,
+			wantHandler: config.ErrHandlerOnly,
+			wantOther:   config.ErrMissionAccessDenied,
+			wantFree:    config.ErrMissionAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = WithCaller(ctx, *tt.caller)
+			}
+
+			check := func(what string, err, want error) {
+				t.Helper()
+				if !errors.Is(err, want) || (err != nil) != (want != nil) {
+					t.Errorf("%s: err = %v, want %v", what, err, want)
+				}
+			}
+			check("caller", RequireCaller(ctx), tt.wantCaller)
+			check("handler", RequireHandler(ctx), tt.wantHandler)
+			check("own mission", RequireMission(ctx, &catID), tt.wantMission)
+			check("other mission", RequireMission(ctx, &otherCatID), tt.wantOther)
+			check("unassigned mission", RequireMission(ctx, nil), tt.wantFree)
+		})
+	}
+}
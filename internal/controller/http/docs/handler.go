@@ -0,0 +1,52 @@
+package docs
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	specPath = "/openapi.json"
+	uiPath   = "/docs"
+)
+
+// ui holds the docs page with swagger-ui-dist, so the docs work offline
+// and without loading scripts from other origins.
+//
+//go:embed ui/index.html ui/init.js ui/swagger-ui-bundle.js ui/swagger-ui.css
+var ui embed.FS
+
+// InitHandler serves the OpenAPI document and the docs UI.
+func InitHandler(g *gin.Engine) error {
+	spec, err := json.Marshal(Spec())
+	if err != nil {
+		return err
+	}
+	indexHTML, err := ui.ReadFile("ui/index.html")
+	if err != nil {
+		return err
+	}
+	assets, err := fs.Sub(ui, "ui")
+	if err != nil {
+		return err
+	}
+
+	g.GET(specPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", spec)
+	})
+	g.GET(uiPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", indexHTML)
+	})
+	g.StaticFS(uiPath+"/ui", http.FS(assets))
+
+	return nil
+}
+
+func isDocsRoute(path string) bool {
+	return path == specPath || strings.HasPrefix(path, uiPath)
+}
@@ -0,0 +1,781 @@
+package docs
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	"backend/pkg/api/caller"
+	request "backend/pkg/api/request/cat"
+	rescat "backend/pkg/api/response/cat"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// operation describes a single documented route. Path uses gin syntax,
+	// path parameters are derived from it.
+	operation struct {
+		method  string
+		path    string
+		tag     string
+		summary string
+		query   []string
+		body    any
+		data    map[string]any // response data keys
+		errors  []int          // HTTP statuses of possible errors
+
+		consumes []string // non-JSON request body content types
+		produces []string // non-JSON response content types, streamed as is
+		raw      any      // JSON response sent without the envelope
+		access   access
+	}
+
+	// access is who may call an operation, as identified by the caller headers.
+	access int
+)
+
+const (
+	accessHandler access = iota // handlers only, the default
+	accessMission               // handlers and the cat assigned to the mission
+	accessCaller                // any caller, cats see their own records only
+	accessPublic                // no caller headers needed
+)
+
+var accessDescriptions = map[access]string{
+	accessHandler: "Handlers only.",
+	accessMission: "Handlers and the cat assigned to the mission.",
+	accessCaller:  "Any caller, cats see their own records only.",
+}
+
+var operations = []operation{
+	{
+		method: http.MethodGet, path: "/ping", tag: "health",
+		summary: "Health check",
+		access:  accessPublic,
+	},
+
+	{
+		method: http.MethodGet, path: "/cats", tag: "cats",
+		summary: "List cats", query: []string{"breed"},
+		data:   map[string]any{"cats": []rescat.Cat{}},
+		errors: []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/cats/:cat_id", tag: "cats",
+		summary: "Get cat by ID",
+		data:    map[string]any{"cat": rescat.Cat{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/cats", tag: "cats",
+		summary: "Create cat", body: request.Cat{},
+		data:   map[string]any{"cat": rescat.Cat{}},
+		errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/cats/import", tag: "cats",
+		summary:  "Import cats from CSV or NDJSON",
+		query:    []string{"mode"},
+		consumes: []string{"text/csv", "application/x-ndjson"},
+		data:     map[string]any{"report": rescat.ImportReport{}},
+		errors:   []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/cats/export", tag: "cats",
+		summary:  "Export cats as CSV or JSON",
+		query:    []string{"format"},
+		produces: []string{"text/csv", "application/json"},
+		errors:   []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/cats/:cat_id", tag: "cats",
+		summary: "Update cat salary", body: request.UpdateCat{},
+		errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/cats/:cat_id", tag: "cats",
+		summary: "Delete cat",
+		errors:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/cats/:cat_id/status", tag: "cats",
+		summary: "Change cat status (active, on_leave, retired, kia) with a reason",
+		body:    request.CatStatus{},
+		data:    map[string]any{"cat": rescat.Cat{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/cats/:cat_id/status-history", tag: "cats",
+		summary: "List status changes of a cat",
+		data:    map[string]any{"status_history": []rescat.CatStatusChange{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/cats/:cat_id/profile", tag: "cats",
+		summary: "Update skills, languages, clearance, home country and availability of a cat",
+		body:    request.CatProfile{},
+		data:    map[string]any{"cat": rescat.Cat{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/cats/:cat_id/salary-history", tag: "cats",
+		summary: "List salary changes of a cat",
+		data:    map[string]any{"salary_history": []rescat.SalaryChange{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/payroll/:month", tag: "payroll",
+		summary: "Monthly payroll report (month as YYYY-MM), JSON or CSV",
+		query:   []string{"format", "currency"},
+		data:    map[string]any{"payroll": rescat.PayrollReport{}},
+		errors:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/exchange-rates", tag: "payroll",
+		summary: "List exchange rates",
+		data:    map[string]any{"exchange_rates": []rescat.ExchangeRate{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPut, path: "/exchange-rates/:base/:quote", tag: "payroll",
+		summary: "Set the price of one base currency unit in the quote currency",
+		body:    request.ExchangeRate{},
+		errors:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/exchange-rates/:base/:quote", tag: "payroll",
+		summary: "Delete exchange rate",
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/missions", tag: "missions",
+		summary: "List missions, filtered by priority, due date and overdue, sorted by sort=[-]field",
+		query:   []string{"priority", "due_after", "due_before", "overdue", "completed", "sort"},
+		data:    map[string]any{"missions": []rescat.Mission{}},
+		errors:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		access:  accessCaller,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id", tag: "missions",
+		summary: "Get mission by ID",
+		data:    map[string]any{"mission": rescat.Mission{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/stream", tag: "missions",
+		summary:  "Stream live mission updates as Server-Sent Events named after the update type",
+		produces: []string{"text/event-stream"},
+		errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:   accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/ws", tag: "missions",
+		summary: "Upgrade to a WebSocket receiving live mission updates as JSON text messages",
+		raw:     rescat.MissionUpdate{},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/messages", tag: "messages",
+		summary: "Get the mission thread after after_id, oldest first (assigned cat and handlers only)",
+		query:   []string{"after_id"},
+		data:    map[string]any{"messages": []rescat.Message{}, "archived": false},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/messages", tag: "messages",
+		summary: "Post a message with base64 attachments, archived threads are read-only",
+		body:    request.Message{},
+		data:    map[string]any{"message": rescat.Message{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/messages/read", tag: "messages",
+		summary: "Mark messages of the other side read up to up_to_id, all without a body",
+		body:    request.MarkRead{},
+		data:    map[string]any{"marked": int64(0)},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/messages/:message_id/attachments/:attachment_id", tag: "messages",
+		summary:  "Download a message attachment",
+		produces: []string{"application/octet-stream"},
+		errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:   accessMission,
+	},
+	{
+		method: http.MethodPost, path: "/missions/from-template/:template_id", tag: "missions",
+		summary: "Create a mission from a template, targets are filled in by position (the body is optional)",
+		body:    request.MissionFromTemplate{},
+		data:    map[string]any{"mission": rescat.Mission{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/clone", tag: "missions",
+		summary: "Create a mission with the targets of another, without its cat, dates or completion (the body is optional)",
+		body:    request.CloneMission{},
+		data:    map[string]any{"mission": rescat.Mission{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/mission-templates", tag: "templates",
+		summary: "List mission templates",
+		data:    map[string]any{"templates": []rescat.MissionTemplate{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/mission-templates/:template_id", tag: "templates",
+		summary: "Get mission template by ID",
+		data:    map[string]any{"template": rescat.MissionTemplate{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/mission-templates", tag: "templates",
+		summary: "Create a mission template with target skeletons",
+		body:    request.MissionTemplate{},
+		data:    map[string]any{"template": rescat.MissionTemplate{}},
+		errors:  []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPut, path: "/mission-templates/:template_id", tag: "templates",
+		summary: "Replace a mission template, missions created from it are not changed",
+		body:    request.MissionTemplate{},
+		data:    map[string]any{"template": rescat.MissionTemplate{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/mission-templates/:template_id", tag: "templates",
+		summary: "Delete a mission template",
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/mission-types", tag: "mission types",
+		summary: "List mission types with their target limits and allowed countries",
+		data:    map[string]any{"types": []rescat.MissionType{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+		access:  accessCaller,
+	},
+	{
+		method: http.MethodGet, path: "/mission-types/:type_id", tag: "mission types",
+		summary: "Get mission type by ID",
+		data:    map[string]any{"type": rescat.MissionType{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessCaller,
+	},
+	{
+		method: http.MethodPost, path: "/mission-types", tag: "mission types",
+		summary: "Create a mission type, no countries allows any (handler only)",
+		body:    request.MissionType{},
+		data:    map[string]any{"type": rescat.MissionType{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPut, path: "/mission-types/:type_id", tag: "mission types",
+		summary: "Replace a mission type, existing missions are not validated again (handler only)",
+		body:    request.MissionType{},
+		data:    map[string]any{"type": rescat.MissionType{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/mission-types/:type_id", tag: "mission types",
+		summary: "Delete a mission type no mission or template has (handler only)",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/debrief", tag: "debriefs",
+		summary:  "Get the mission debrief as JSON, or as a Markdown or self-contained HTML document",
+		query:    []string{"format"},
+		data:     map[string]any{"debrief": rescat.Debrief{}},
+		produces: []string{"text/markdown", "text/html"},
+		errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:   accessMission,
+	},
+	{
+		method: http.MethodPut, path: "/missions/:mission_id/debrief", tag: "debriefs",
+		summary: "Write the summary, rating and target outcomes of a completed mission (handlers only)",
+		body:    request.Debrief{},
+		data:    map[string]any{"debrief": rescat.Debrief{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPut, path: "/missions/:mission_id/debrief/self-assessment", tag: "debriefs",
+		summary: "Write the self-assessment of a completed mission (assigned cat only)",
+		body:    request.SelfAssessment{},
+		data:    map[string]any{"debrief": rescat.Debrief{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/targets/:target_id/evidence", tag: "evidence",
+		summary: "List target evidence with short-lived download URLs (assigned cat and handlers only)",
+		data:    map[string]any{"evidence": []rescat.Evidence{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/targets/:target_id/evidence", tag: "evidence",
+		summary:  "Upload an evidence file as the multipart part file, frozen once the target is completed",
+		consumes: []string{"multipart/form-data"},
+		data:     map[string]any{"evidence": rescat.Evidence{}},
+		errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:   accessMission,
+	},
+	{
+		method: http.MethodDelete, path: "/missions/:mission_id/targets/:target_id/evidence/:evidence_id", tag: "evidence",
+		summary: "Delete an evidence file of an uncompleted target",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodGet, path: "/evidence/:evidence_id/download", tag: "evidence",
+		summary:  "Download an evidence file through a signed URL from the local store",
+		query:    []string{"expires", "signature"},
+		produces: []string{"application/octet-stream"},
+		errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:   accessPublic,
+	},
+	{
+		method: http.MethodPost, path: "/missions", tag: "missions",
+		summary: "Create mission with targets, limited by its type (the default type if type_id is omitted)", body: request.Mission{},
+		data:   map[string]any{"mission": rescat.Mission{}},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/bundle", tag: "missions",
+		summary: "Export mission as a portable bundle (sent without envelope)",
+		raw:     rescat.MissionBundle{},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/missions/bundle", tag: "missions",
+		summary: "Import mission bundle", query: []string{"attach_cat"},
+		body:   request.MissionBundle{},
+		data:   map[string]any{"mission": rescat.Mission{}, "target_ids": map[string]uint{}},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/candidates", tag: "missions",
+		summary: "Rank cats available today for the mission, with score breakdown",
+		data:    map[string]any{"candidates": []rescat.Candidate{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/auto-assign", tag: "missions",
+		summary: "Assign the best ranked candidate to the mission",
+		data:    map[string]any{"candidate": rescat.Candidate{}},
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/missions/:mission_id/assign/:cat_id", tag: "missions",
+		summary: "Assign an active cat to mission",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/missions/:mission_id/complete", tag: "missions",
+		summary: "Complete mission and pay out bonuses to the assigned cat",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPut, path: "/missions/:mission_id/schedule", tag: "missions",
+		summary: "Set mission priority, start and due dates", body: request.Schedule{},
+		data:   map[string]any{"mission": rescat.Mission{}},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/missions/:mission_id", tag: "missions",
+		summary: "Delete unassigned mission",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodPost, path: "/missions/:mission_id/targets", tag: "targets",
+		summary: "Add target to mission, limited by the mission type", body: request.Target{},
+		data:   map[string]any{"target": rescat.Target{}},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/missions/:mission_id/targets/:target_id", tag: "targets",
+		summary: "Update target notes", body: request.UpdateTarget{},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access: accessMission,
+	},
+	{
+		method: http.MethodPatch, path: "/missions/:mission_id/targets/:target_id/complete", tag: "targets",
+		summary: "Complete target",
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		access:  accessMission,
+	},
+	{
+		method: http.MethodPut, path: "/missions/:mission_id/targets/:target_id/schedule", tag: "targets",
+		summary: "Set target priority, start and due dates", body: request.Schedule{},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/missions/:mission_id/targets/:target_id", tag: "targets",
+		summary: "Delete target",
+		errors:  []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/search", tag: "search",
+		summary: "Full-text search over targets (name, country, notes) and cats, ranked with snippets",
+		query:   []string{"q", "type", "limit"},
+		data:    map[string]any{"results": []rescat.SearchResult{}},
+		errors:  []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity},
+		access:  accessCaller,
+	},
+
+	{
+		method: http.MethodGet, path: "/dossiers", tag: "dossiers",
+		summary: "List target dossiers, q filters by name or alias",
+		query:   []string{"q"},
+		data:    map[string]any{"dossiers": []rescat.Dossier{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/dossiers/:dossier_id", tag: "dossiers",
+		summary: "Get dossier with every mission target and note linked to it",
+		data:    map[string]any{"dossier": rescat.DossierDetail{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/dossiers", tag: "dossiers",
+		summary: "Create dossier, rejected if a similar one exists unless force is set",
+		query:   []string{"force"},
+		body:    request.Dossier{},
+		data:    map[string]any{"dossier": rescat.Dossier{}},
+		errors:  []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPatch, path: "/dossiers/:dossier_id", tag: "dossiers",
+		summary: "Update dossier name, country, aliases or attributes",
+		body:    request.UpdateDossier{},
+		data:    map[string]any{"dossier": rescat.Dossier{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/webhooks", tag: "webhooks",
+		summary: "List webhook subscriptions",
+		data:    map[string]any{"webhooks": []rescat.Webhook{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/:webhook_id", tag: "webhooks",
+		summary: "Get webhook subscription",
+		data:    map[string]any{"webhook": rescat.Webhook{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/:webhook_id/deliveries", tag: "webhooks",
+		summary: "Webhook delivery log, newest first",
+		data:    map[string]any{"deliveries": []rescat.WebhookDelivery{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/webhooks", tag: "webhooks",
+		summary: "Subscribe a URL to events, the signing secret is only returned here",
+		body:    request.Webhook{},
+		data:    map[string]any{"webhook": rescat.Webhook{}},
+		errors:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/webhooks/:webhook_id/redeliver", tag: "webhooks",
+		summary: "Replay deliveries, every failed one if no IDs are given",
+		body:    request.Redeliver{},
+		data:    map[string]any{"deliveries": []rescat.WebhookDelivery{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/webhooks/:webhook_id", tag: "webhooks",
+		summary: "Delete webhook subscription, pending deliveries fail",
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+
+	{
+		method: http.MethodGet, path: "/bonus-rules", tag: "bonuses",
+		summary: "List bonus rules",
+		data:    map[string]any{"bonus_rules": []rescat.BonusRule{}},
+		errors:  []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodPost, path: "/bonus-rules", tag: "bonuses",
+		summary: "Create bonus rule", body: request.BonusRule{},
+		data:   map[string]any{"bonus_rule": rescat.BonusRule{}},
+		errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/bonus-rules/:rule_id", tag: "bonuses",
+		summary: "Delete bonus rule",
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/missions/:mission_id/bonus-preview", tag: "bonuses",
+		summary: "Preview the bonus the mission would pay if completed now",
+		data:    map[string]any{"bonus_preview": rescat.BonusPreview{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/cats/:cat_id/bonuses", tag: "bonuses",
+		summary: "List bonus ledger entries of a cat",
+		data:    map[string]any{"bonuses": []rescat.BonusEntry{}},
+		errors:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+}
+
+var (
+	ginParam = regexp.MustCompile(`:(\w+)`)
+
+	serviceCodes = map[config.ServiceCode]string{
+		config.CodeOK:                  "OK",
+		config.CodeBadRequest:          "BadRequest",
+		config.CodeUnprocessableEntity: "UnprocessableEntity",
+		config.CodeDatabaseError:       "DatabaseError",
+		config.CodeNotFound:            "NotFound",
+		config.CodeUnauthorized:        "Unauthorized",
+		config.CodeForbidden:           "Forbidden",
+		config.CodeConflict:            "Conflict",
+		config.CodeExternalRequestFail: "ExternalRequestFail",
+		config.CodeInternal:            "Internal",
+	}
+)
+
+// Spec builds the OpenAPI 3.1 document describing every documented route.
+func Spec() map[string]any {
+	s := newSchemas()
+	s.components["Response"] = s.object(reflect.TypeOf(response.Response{}))
+	s.components["ServiceCode"] = serviceCodeSchema()
+
+	envelope := s.components["Response"].(map[string]any)
+	envelope["properties"].(map[string]any)["code"] = map[string]any{"$ref": "#/components/schemas/ServiceCode"}
+
+	paths := make(map[string]any)
+	for _, op := range operations {
+		path := openAPIPath(op.path)
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = make(map[string]any)
+			paths[path] = item
+		}
+		item[strings.ToLower(op.method)] = op.build(s)
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   "Spy Cats Agency API",
+			"version": "1.0.0",
+			"description": "All responses are wrapped into the Response envelope. " +
+				"Errors carry a service code, a public message and an optional detail.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas":         s.components,
+			"securitySchemes": callerSchemes,
+			"responses": map[string]any{
+				"Error": map[string]any{
+					"description": "Error response",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{"$ref": "#/components/schemas/Response"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// callerSchemes are the caller headers set by the authenticating gateway,
+// a missing or invalid role is refused with 401.
+var callerSchemes = map[string]any{
+	"callerRole": map[string]any{
+		"type": "apiKey", "in": "header", "name": caller.HeaderRole,
+		"description": "Caller role: " + caller.RoleHandler + " or " + caller.RoleCat,
+	},
+	"callerCatID": map[string]any{
+		"type": "apiKey", "in": "header", "name": caller.HeaderCatID,
+		"description": "ID of the calling cat, required with the " + caller.RoleCat + " role",
+	},
+}
+
+// callerSecurity requires the role, and the cat ID for cats.
+var callerSecurity = []any{
+	map[string]any{"callerRole": []string{}},
+	map[string]any{"callerRole": []string{}, "callerCatID": []string{}},
+}
+
+// Undocumented returns routes registered in gin that are missing from the spec.
+func Undocumented(routes gin.RoutesInfo) []string {
+	documented := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		documented[op.method+" "+op.path] = true
+	}
+
+	var missing []string
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		if !documented[key] && !isDocsRoute(r.Path) {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func (op operation) build(s *schemas) map[string]any {
+	res := map[string]any{
+		"tags":        []string{op.tag},
+		"summary":     op.summary,
+		"operationId": operationID(op),
+	}
+
+	params := make([]any, 0)
+	for _, m := range ginParam.FindAllStringSubmatch(op.path, -1) {
+		schema := map[string]any{"type": "integer", "minimum": 0}
+		if !strings.HasSuffix(m[1], "_id") {
+			schema = map[string]any{"type": "string"}
+		}
+		params = append(params, map[string]any{
+			"name": m[1], "in": "path", "required": true, "schema": schema,
+		})
+	}
+	for _, q := range op.query {
+		params = append(params, map[string]any{
+			"name": q, "in": "query",
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		res["parameters"] = params
+	}
+
+	if len(op.consumes) > 0 {
+		content := make(map[string]any, len(op.consumes))
+		for _, ct := range op.consumes {
+			content[ct] = map[string]any{"schema": map[string]any{"type": "string"}}
+		}
+		res["requestBody"] = map[string]any{"required": true, "content": content}
+	}
+
+	if op.body != nil {
+		res["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.of(op.body)},
+			},
+		}
+	}
+
+	data := make(map[string]any, len(op.data))
+	for key, v := range op.data {
+		data[key] = s.of(v)
+	}
+	responses := map[string]any{}
+	if len(op.produces) > 0 {
+		content := make(map[string]any, len(op.produces))
+		for _, ct := range op.produces {
+			content[ct] = map[string]any{"schema": map[string]any{"type": "string"}}
+		}
+		if len(op.data) > 0 { // JSON by default, the other types on request
+			content["application/json"] = map[string]any{"schema": envelopeSchema(data)}
+		}
+		responses["200"] = map[string]any{"description": "OK", "content": content}
+	} else if op.raw != nil {
+		responses["200"] = map[string]any{
+			"description": "OK",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.of(op.raw)},
+			},
+		}
+	} else {
+		responses["200"] = map[string]any{
+			"description": "OK",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": envelopeSchema(data)},
+			},
+		}
+	}
+	errors := op.errors
+	if op.access != accessPublic {
+		res["description"] = accessDescriptions[op.access]
+		res["security"] = callerSecurity
+		errors = append(errors, http.StatusUnauthorized)
+		if op.access != accessCaller {
+			errors = append(errors, http.StatusForbidden)
+		}
+	}
+	for _, status := range append(errors, http.StatusInternalServerError) {
+		responses[strconv.Itoa(status)] = map[string]any{"$ref": "#/components/responses/Error"}
+	}
+	res["responses"] = responses
+
+	return res
+}
+
+// envelopeSchema narrows the Response envelope to the given data keys.
+func envelopeSchema(data map[string]any) map[string]any {
+	return map[string]any{
+		"allOf": []any{
+			map[string]any{"$ref": "#/components/schemas/Response"},
+			map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"data": map[string]any{"type": "object", "properties": data},
+				},
+			},
+		},
+	}
+}
+
+func serviceCodeSchema() map[string]any {
+	codes := make([]int, 0, len(serviceCodes))
+	for code := range serviceCodes {
+		codes = append(codes, int(code))
+	}
+	sort.Ints(codes)
+
+	names := make([]string, 0, len(codes))
+	for _, code := range codes {
+		names = append(names, serviceCodes[config.ServiceCode(code)])
+	}
+
+	return map[string]any{
+		"type":        "integer",
+		"enum":        codes,
+		"x-enumNames": names,
+		"description": "Service code of the response, 0 on success",
+	}
+}
+
+func openAPIPath(path string) string {
+	return ginParam.ReplaceAllString(path, "{$1}")
+}
+
+func operationID(op operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.method))
+	for _, part := range strings.Split(op.path, "/") {
+		part = strings.TrimPrefix(part, ":")
+		for _, word := range strings.Split(part, "_") {
+			if word != "" {
+				b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+	}
+	return b.String()
+}
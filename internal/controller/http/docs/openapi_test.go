@@ -0,0 +1,149 @@
+package docs_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"backend/internal/controller/http/docs"
+	handlerbonus "backend/internal/controller/http/v1/bonus"
+	handlercat "backend/internal/controller/http/v1/cat"
+	handlerdebrief "backend/internal/controller/http/v1/debrief"
+	handlerdossier "backend/internal/controller/http/v1/dossier"
+	handlerevidence "backend/internal/controller/http/v1/evidence"
+	handlermission "backend/internal/controller/http/v1/mission"
+	handlermissiontype "backend/internal/controller/http/v1/missiontype"
+	handlerpayroll "backend/internal/controller/http/v1/payroll"
+	handlersearch "backend/internal/controller/http/v1/search"
+	handlertemplate "backend/internal/controller/http/v1/template"
+	handlerwebhook "backend/internal/controller/http/v1/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter registers the routes of every v1 handler, the services are never called.
+func newRouter(t *testing.T) (*gin.Engine, int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	g.GET("/ping", func(c *gin.Context) {})
+	inits := []func(){
+		func() { handlercat.InitHandler(g, l, nil, nil) },
+		func() { handlermission.InitHandler(g, l, nil, nil) },
+		func() { handlerpayroll.InitHandler(g, l, nil, nil) },
+		func() { handlerbonus.InitHandler(g, l, nil, nil) },
+		func() { handlerdossier.InitHandler(g, l, nil, nil) },
+		func() { handlertemplate.InitHandler(g, l, nil, nil) },
+		func() { handlermissiontype.InitHandler(g, l, nil, nil) },
+		func() { handlerdebrief.InitHandler(g, l, nil) },
+		func() { handlerevidence.InitHandler(g, l, nil, 0) },
+		func() { handlersearch.InitHandler(g, l, nil) },
+		func() { handlerwebhook.InitHandler(g, l, nil, nil) },
+	}
+	for _, init := range inits {
+		init()
+	}
+	if err := docs.InitHandler(g); err != nil {
+		t.Fatalf("init docs: %v", err)
+	}
+	return g, len(inits)
+}
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	g, handlers := newRouter(t)
+
+	packages, err := os.ReadDir("../v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packages) != handlers {
+		t.Fatalf("%d v1 handler packages but %d registered here, register the new handler in newRouter",
+			len(packages), handlers)
+	}
+
+	if missing := docs.Undocumented(g.Routes()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI spec: %v", missing)
+	}
+}
+
+func TestServeDocs(t *testing.T) {
+	g, _ := newRouter(t)
+
+	tests := []struct {
+		path        string
+		contentType string
+	}{
+		{"/openapi.json", "application/json"},
+		{"/docs", "text/html; charset=utf-8"},
+		{"/docs/ui/swagger-ui-bundle.js", "text/javascript; charset=utf-8"},
+		{"/docs/ui/swagger-ui.css", "text/css; charset=utf-8"},
+		{"/docs/ui/init.js", "text/javascript; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("content type = %q, want %q", got, tt.contentType)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if spec.OpenAPI != "3.1.0" || len(spec.Paths) == 0 {
+		t.Errorf("spec = openapi %q with %d paths", spec.OpenAPI, len(spec.Paths))
+	}
+}
+
+func TestCallerAccessDocumented(t *testing.T) {
+	paths := docs.Spec()["paths"].(map[string]any)
+
+	tests := []struct {
+		path, method  string
+		wantSecurity  bool
+		wantForbidden bool
+	}{
+		{path: "/ping", method: "get"},
+		{path: "/evidence/{evidence_id}/download", method: "get", wantForbidden: true}, // invalid signature
+		{path: "/search", method: "get", wantSecurity: true},
+		{path: "/missions", method: "get", wantSecurity: true},
+		{path: "/missions/{mission_id}", method: "get", wantSecurity: true, wantForbidden: true},
+		{path: "/cats", method: "get", wantSecurity: true, wantForbidden: true},
+		{path: "/exchange-rates", method: "get", wantSecurity: true, wantForbidden: true},
+		{path: "/webhooks", method: "post", wantSecurity: true, wantForbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			op := paths[tt.path].(map[string]any)[tt.method].(map[string]any)
+			responses := op["responses"].(map[string]any)
+
+			_, security := op["security"]
+			_, unauthorized := responses["401"]
+			_, forbidden := responses["403"]
+			if security != tt.wantSecurity || unauthorized != tt.wantSecurity {
+				t.Errorf("security = %v, 401 = %v, want %v", security, unauthorized, tt.wantSecurity)
+			}
+			if forbidden != tt.wantForbidden {
+				t.Errorf("403 = %v, want %v", forbidden, tt.wantForbidden)
+			}
+		})
+	}
+}
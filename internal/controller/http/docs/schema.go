@@ -0,0 +1,125 @@
+package docs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// schemas generates JSON schemas for Go types via reflection, registering
+// named structs as reusable components.
+type schemas struct {
+	components map[string]any
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: make(map[string]any)}
+}
+
+// of returns the schema for the value's type.
+func (s *schemas) of(v any) map[string]any {
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		inner := s.schema(t.Elem())
+		return map[string]any{"oneOf": []any{inner, map[string]any{"type": "null"}}}
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		return map[string]any{} // any JSON value
+	}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name := componentName(t)
+		if _, ok := s.components[name]; !ok {
+			s.components[name] = nil // guards recursive types
+			s.components[name] = s.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (s *schemas) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := make([]string, 0)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			// embedded structs are flattened by encoding/json
+			embedded := s.object(f.Type)
+			for k, v := range embedded["properties"].(map[string]any) {
+				properties[k] = v
+			}
+			if req, ok := embedded["required"].([]string); ok {
+				required = append(required, req...)
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		properties[name] = s.schema(f.Type)
+		if isRequired(f) {
+			required = append(required, name)
+		}
+	}
+
+	obj := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		obj["required"] = required
+	}
+	return obj
+}
+
+func isRequired(f reflect.StructField) bool {
+	return strings.Contains(f.Tag.Get("valid"), "required") ||
+		strings.Contains(f.Tag.Get("binding"), "required")
+}
+
+// componentName suffixes request types, so that request.Cat and
+// response.Cat don't collide in the components section.
+func componentName(t reflect.Type) string {
+	if strings.Contains(t.PkgPath(), "/request/") {
+		return t.Name() + "Request"
+	}
+	return t.Name()
+}
@@ -1,7 +1,13 @@
 package middleware
 
 import (
+	"backend/config"
+	"backend/internal/auth"
+	"backend/internal/controller/http/response"
+	"backend/pkg/api/caller"
 	"log/slog"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -53,3 +59,61 @@ func (m Middleware) Recovery() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ErrorHandler renders the last error attached to the context with c.Error.
+// Only the public part of domain errors is sent, internal causes are logged.
+func (m Middleware) ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := config.AsError(c.Errors.Last().Err)
+		status := config.CodeToHttpStatus(err.Code)
+
+		if status >= http.StatusInternalServerError ||
+			err.Code == config.CodeDatabaseError ||
+			err.Code == config.CodeExternalRequestFail {
+			m.logger.Error("request failed",
+				slog.Int("code", int(err.Code)),
+				slog.String("path", c.Request.URL.Path),
+				slog.String("method", c.Request.Method),
+				slog.String("err", c.Errors.Last().Err.Error()),
+			)
+		}
+
+		c.JSON(status, response.NewErr(err))
+	}
+}
+
+// Caller puts the caller identity from the gateway headers into the request context.
+// Requests without a role get the zero Caller, the services refuse it where a caller
+// is required. Invalid headers are rejected.
+func (m Middleware) Caller() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var identity auth.Caller
+
+		switch role := c.GetHeader(caller.HeaderRole); role {
+		case "":
+		case caller.RoleHandler:
+			identity = auth.Caller{Role: caller.RoleHandler}
+		case caller.RoleCat:
+			catID, err := strconv.ParseUint(c.GetHeader(caller.HeaderCatID), 10, 32)
+			if err != nil || catID == 0 {
+				c.Error(config.ErrInvalidCaller.WithDetail("cat caller requires " + caller.HeaderCatID))
+				c.Abort()
+				return
+			}
+			identity = auth.Caller{Role: caller.RoleCat, CatID: uint(catID)}
+		default:
+			c.Error(config.ErrInvalidCaller.WithDetail("unknown role " + role))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithCaller(c.Request.Context(), identity))
+		c.Next()
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"backend/internal/auth"
+	"backend/pkg/api/caller"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCaller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mw := NewMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var got auth.Caller
+	g := gin.New()
+	g.Use(mw.ErrorHandler(), mw.Caller())
+	g.GET("/whoami", func(c *gin.Context) {
+		got = auth.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		headers    map[string]string
+		wantStatus int
+		want       auth.Caller
+	}{
+		{name: "no caller", path: "/whoami", wantStatus: http.StatusOK},
+		{name: "unknown route without caller", path: "/nowhere", wantStatus: http.StatusNotFound},
+		{
+			name:       "handler",
+			path:       "/whoami",
+			headers:    map[string]string{caller.HeaderRole: caller.RoleHandler},
+			wantStatus: http.StatusOK,
+			want:       auth.Caller{Role: caller.RoleHandler},
+		},
+		{
+			name:       "cat",
+			path:       "/whoami",
+			headers:    map[string]string{caller.HeaderRole: caller.RoleCat, caller.HeaderCatID: "7"},
+			wantStatus: http.StatusOK,
+			want:       auth.Caller{Role: caller.RoleCat, CatID: 7},
+		},
+		{
+			name:       "cat without ID",
+			path:       "/whoami",
+			headers:    map[string]string{caller.HeaderRole: caller.RoleCat},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown role",
+			path:       "/whoami",
+			headers:    map[string]string{caller.HeaderRole: "admin"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = auth.Caller{}
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got != tt.want {
+				t.Errorf("caller = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ type Response struct {
 	Code    config.ServiceCode `json:"code"`
 	Data    map[string]any     `json:"data,omitempty"`
 	Message string             `json:"message,omitempty"`
+	Detail  string             `json:"detail,omitempty"`
 }
 
 // NewResponse generates the response structure with the given service code
@@ -29,10 +30,12 @@ func (r *Response) SetMessage(value string) *Response {
 	return r
 }
 
-// NewErr generates the response with the given service code and error
-func NewErr(code config.ServiceCode, err error) Response {
+// NewErr generates the response from the domain error,
+// exposing only its public message and detail
+func NewErr(err *config.Error) Response {
 	return Response{
-		Code:    code,
-		Message: err.Error(),
+		Code:    err.Code,
+		Message: err.Message,
+		Detail:  err.Detail,
 	}
 }
@@ -0,0 +1,98 @@
+package bonus
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getBonusRules(c *gin.Context) {
+	rules, err := h.svc.GetBonusRules(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("bonus_rules", rules))
+}
+
+func (h handler) createBonusRule(c *gin.Context) {
+	var body request.BonusRule
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateBonusRule(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rule, err := h.svc.CreateBonusRule(c.Request.Context(), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("bonus_rule", rule).
+		SetMessage("record created"))
+}
+
+func (h handler) deleteBonusRule(c *gin.Context) {
+	ruleID, err := strconv.ParseUint(c.Param("rule_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid rule_id", err))
+		return
+	}
+
+	err = h.svc.DeleteBonusRule(c.Request.Context(), uint(ruleID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("record deleted"))
+}
+
+func (h handler) previewBonus(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	preview, err := h.svc.PreviewBonus(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("bonus_preview", preview))
+}
+
+func (h handler) getCatBonuses(c *gin.Context) {
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid cat_id", err))
+		return
+	}
+
+	entries, err := h.svc.GetCatBonuses(c.Request.Context(), uint(catID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("bonuses", entries))
+}
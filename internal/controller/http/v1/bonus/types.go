@@ -0,0 +1,50 @@
+package bonus
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetBonusRules(ctx context.Context) ([]response.BonusRule, error)
+		CreateBonusRule(ctx context.Context, body request.BonusRule) (response.BonusRule, error)
+		DeleteBonusRule(ctx context.Context, ruleID uint) error
+
+		PreviewBonus(ctx context.Context, missionID uint) (response.BonusPreview, error)
+		GetCatBonuses(ctx context.Context, catID uint) ([]response.BonusEntry, error)
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	rules := g.Group("bonus-rules")
+	{
+		rules.GET("", h.getBonusRules)
+		rules.POST("", h.createBonusRule)
+		rules.DELETE("/:rule_id", h.deleteBonusRule)
+	}
+
+	g.GET("/missions/:mission_id/bonus-preview", h.previewBonus)
+	g.GET("/cats/:cat_id/bonuses", h.getCatBonuses)
+}
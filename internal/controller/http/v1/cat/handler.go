@@ -2,9 +2,9 @@ package cat
 
 import (
 	"backend/config"
-	request "backend/internal/controller/http/request/cat"
 	"backend/internal/controller/http/response"
-	"fmt"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
 	"net/http"
 	"strconv"
 
@@ -14,9 +14,9 @@ import (
 func (h handler) getCats(c *gin.Context) {
 	breed := c.Query("breed")
 
-	cats, svcCode, err := h.svc.GetCats(c.Request.Context(), breed)
+	cats, err := h.svc.GetCats(c.Request.Context(), breed)
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
@@ -26,14 +26,13 @@ func (h handler) getCats(c *gin.Context) {
 func (h handler) getCatByID(c *gin.Context) {
 	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid cat_id: %v", err)))
+		c.Error(config.BadRequest("invalid cat_id", err))
 		return
 	}
 
-	cat, svcCode, err := h.svc.GetCatByID(c.Request.Context(), uint(catID))
+	cat, err := h.svc.GetCatByID(c.Request.Context(), uint(catID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
@@ -42,23 +41,28 @@ func (h handler) getCatByID(c *gin.Context) {
 
 func (h handler) createCat(c *gin.Context) {
 	var body request.Cat
-	if err := c.BindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
 		return
 	}
 
 	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+		c.Error(config.BadRequest("validation failed", err))
 		return
 	}
 
-	cat, svcCode, err := h.svc.CreateCat(c.Request.Context(), body)
+	if err := dto.ValidateCat(body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	cat, err := h.svc.CreateCat(c.Request.Context(), body)
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
 		AddKey("cat", cat).
 		SetMessage("record created"))
 }
@@ -66,44 +70,147 @@ func (h handler) createCat(c *gin.Context) {
 func (h handler) updateCat(c *gin.Context) {
 	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid cat_id: %v", err)))
+		c.Error(config.BadRequest("invalid cat_id", err))
 		return
 	}
 
 	var body request.UpdateCat
-	if err := c.BindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateUpdateCat(body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err = h.svc.UpdateCat(c.Request.Context(), body, uint(catID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("record updated"))
+}
+
+func (h handler) updateCatProfile(c *gin.Context) {
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid cat_id", err))
+		return
+	}
+
+	var body request.CatProfile
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
 		return
 	}
 
 	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateCatProfile(&body); err != nil {
+		c.Error(err)
 		return
 	}
 
-	svcCode, err := h.svc.UpdateCat(c.Request.Context(), body, uint(catID))
+	cat, err := h.svc.UpdateCatProfile(c.Request.Context(), body, uint(catID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("record updated"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("cat", cat).
+		SetMessage("record updated"))
 }
 
 func (h handler) deleteCat(c *gin.Context) {
 	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid cat_id: %v", err)))
+		c.Error(config.BadRequest("invalid cat_id", err))
+		return
+	}
+
+	err = h.svc.DeleteCat(c.Request.Context(), uint(catID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("record deleted"))
+}
+
+func (h handler) getSalaryHistory(c *gin.Context) {
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid cat_id", err))
+		return
+	}
+
+	history, err := h.svc.GetSalaryHistory(c.Request.Context(), uint(catID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("salary_history", history))
+}
+
+func (h handler) updateCatStatus(c *gin.Context) {
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid cat_id", err))
+		return
+	}
+
+	var body request.CatStatus
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateCatStatus(body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	cat, err := h.svc.UpdateCatStatus(c.Request.Context(), body, uint(catID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("cat", cat).
+		SetMessage("record updated"))
+}
+
+func (h handler) getStatusHistory(c *gin.Context) {
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid cat_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.DeleteCat(c.Request.Context(), uint(catID))
+	history, err := h.svc.GetStatusHistory(c.Request.Context(), uint(catID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("record deleted"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("status_history", history))
 }
@@ -0,0 +1,199 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	rescat "backend/pkg/api/response/cat"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+
+	// exportFlushEvery is how many rows are written between flushes
+	exportFlushEvery = 100
+	// exportChunkTimeout is how long writing a chunk of rows may take,
+	// the write deadline is extended by it for every chunk
+	exportChunkTimeout = 10 * time.Second
+)
+
+func (h handler) importCats(c *gin.Context) {
+	mode := c.DefaultQuery("mode", dto.ImportModeAtomic)
+	if mode != dto.ImportModeAtomic && mode != dto.ImportModeBestEffort {
+		c.Error(config.NewError(config.CodeBadRequest, "invalid mode").WithDetail(mode))
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(c.ContentType())
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, config.MaxImportBytes)
+
+	var (
+		rows dto.ImportRows
+		err  error
+	)
+	switch contentType {
+	case "text/csv":
+		rows, err = dto.DecodeCatsCSV(body, config.MaxImportRows)
+	case "application/x-ndjson", "application/jsonl":
+		rows, err = dto.DecodeCatsNDJSON(body, config.MaxImportRows)
+	default:
+		c.Error(config.ErrUnknownContentType.WithDetail(contentType))
+		return
+	}
+	if err != nil {
+		if !errors.Is(err, config.ErrImportTooManyRows) {
+			err = config.BadRequest("invalid import file", err)
+		}
+		c.Error(err)
+		return
+	}
+
+	for i := range rows {
+		if rows[i].Err != nil {
+			continue
+		}
+		if valid, err := h.validator.ValidateStruct(rows[i].Cat); !valid || err != nil {
+			rows[i].Err = config.BadRequest("validation failed", err)
+		} else if err := dto.ValidateCat(rows[i].Cat); err != nil {
+			rows[i].Err = err
+		}
+	}
+
+	report, err := h.svc.ImportCats(c.Request.Context(), rows, mode)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if !report.Committed && report.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, response.New(config.CodeUnprocessableEntity).
+			AddKey("report", report).
+			SetMessage(config.ErrImportRejected.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("report", report).
+		SetMessage("cats imported"))
+}
+
+func (h handler) exportCats(c *gin.Context) {
+	format := c.DefaultQuery("format", exportFormatJSON)
+
+	// The export outlives the server write timeout, so every chunk
+	// gets its own deadline instead
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() error {
+		err := rc.SetWriteDeadline(time.Now().Add(exportChunkTimeout))
+		if errors.Is(err, http.ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+
+	var (
+		rows   int
+		write  func(rescat.Cat) error
+		finish func() error
+	)
+	switch format {
+	case exportFormatCSV:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="cats.csv"`)
+
+		w := csv.NewWriter(c.Writer)
+		write = func(cat rescat.Cat) error {
+			if rows == 0 {
+				if err := w.Write(rescat.CatCSVHeader); err != nil {
+					return err
+				}
+			}
+			rows++
+			if err := w.Write(cat.CSVRecord()); err != nil {
+				return err
+			}
+			if rows%exportFlushEvery == 0 {
+				w.Flush()
+				c.Writer.Flush()
+				if err := extendDeadline(); err != nil {
+					return err
+				}
+			}
+			return w.Error()
+		}
+		finish = func() error {
+			if rows == 0 {
+				if err := w.Write(rescat.CatCSVHeader); err != nil {
+					return err
+				}
+			}
+			w.Flush()
+			return w.Error()
+		}
+	case exportFormatJSON:
+		c.Header("Content-Type", "application/json; charset=utf-8")
+
+		write = func(cat rescat.Cat) error {
+			prefix := ","
+			if rows == 0 {
+				prefix = "["
+			}
+			rows++
+
+			b, err := json.Marshal(cat)
+			if err != nil {
+				return err
+			}
+			if _, err := c.Writer.WriteString(prefix); err != nil {
+				return err
+			}
+			if _, err := c.Writer.Write(b); err != nil {
+				return err
+			}
+			if rows%exportFlushEvery == 0 {
+				c.Writer.Flush()
+				return extendDeadline()
+			}
+			return nil
+		}
+		finish = func() error {
+			end := "]"
+			if rows == 0 {
+				end = "[]"
+			}
+			_, err := c.Writer.WriteString(end)
+			return err
+		}
+	default:
+		c.Error(config.ErrUnknownFormat.WithDetail(format))
+		return
+	}
+
+	err := extendDeadline()
+	if err == nil {
+		err = h.svc.ExportCats(c.Request.Context(), write)
+	}
+	if err == nil {
+		err = finish()
+	}
+	if err != nil {
+		// Once rows are streamed the status can't be changed anymore
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.Error(err)
+			return
+		}
+		h.l.Error("cats export interrupted", "format", format, "rows", rows, "err", err)
+		c.Abort()
+	}
+}
@@ -1,9 +1,9 @@
 package cat
 
 import (
-	"backend/config"
-	request "backend/internal/controller/http/request/cat"
-	response "backend/internal/controller/http/response/cat"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
 	"context"
 	"log/slog"
 
@@ -12,11 +12,18 @@ import (
 
 type (
 	service interface {
-		GetCats(ctx context.Context, breed string) ([]response.Cat, config.ServiceCode, error)
-		GetCatByID(ctx context.Context, catID uint) (response.Cat, config.ServiceCode, error)
-		CreateCat(ctx context.Context, body request.Cat) (response.Cat, config.ServiceCode, error)
-		UpdateCat(ctx context.Context, body request.UpdateCat, catID uint) (config.ServiceCode, error)
-		DeleteCat(ctx context.Context, catID uint) (config.ServiceCode, error)
+		GetCats(ctx context.Context, breed string) ([]response.Cat, error)
+		GetCatByID(ctx context.Context, catID uint) (response.Cat, error)
+		CreateCat(ctx context.Context, body request.Cat) (response.Cat, error)
+		UpdateCat(ctx context.Context, body request.UpdateCat, catID uint) error
+		UpdateCatProfile(ctx context.Context, body request.CatProfile, catID uint) (response.Cat, error)
+		UpdateCatStatus(ctx context.Context, body request.CatStatus, catID uint) (response.Cat, error)
+		GetStatusHistory(ctx context.Context, catID uint) ([]response.CatStatusChange, error)
+		DeleteCat(ctx context.Context, catID uint) error
+		GetSalaryHistory(ctx context.Context, catID uint) ([]response.SalaryChange, error)
+
+		ImportCats(ctx context.Context, rows dto.ImportRows, mode string) (response.ImportReport, error)
+		ExportCats(ctx context.Context, fn func(response.Cat) error) error
 	}
 
 	validator interface {
@@ -41,10 +48,16 @@ func InitHandler(
 	cats := g.Group("cats")
 	{
 		cats.GET("", h.getCats)
+		cats.GET("/export", h.exportCats)
 		cats.GET("/:cat_id", h.getCatByID)
+		cats.GET("/:cat_id/salary-history", h.getSalaryHistory)
+		cats.GET("/:cat_id/status-history", h.getStatusHistory)
 
 		cats.POST("", h.createCat)
+		cats.POST("/import", h.importCats)
 		cats.PATCH("/:cat_id", h.updateCat)
+		cats.PATCH("/:cat_id/profile", h.updateCatProfile)
+		cats.PATCH("/:cat_id/status", h.updateCatStatus)
 		cats.DELETE("/:cat_id", h.deleteCat)
 	}
 }
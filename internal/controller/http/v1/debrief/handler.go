@@ -0,0 +1,104 @@
+package debrief
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getDebrief returns the debrief as JSON, or rendered as a document with
+// format=markdown or format=html.
+func (h handler) getDebrief(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	format := c.DefaultQuery("format", config.DebriefFormatJSON)
+	if format == config.DebriefFormatJSON {
+		debrief, err := h.svc.GetDebrief(c.Request.Context(), uint(missionID))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("debrief", debrief))
+		return
+	}
+
+	doc, err := h.svc.RenderDebrief(c.Request.Context(), uint(missionID), format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": doc.Filename}))
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, doc.ContentType, doc.Content)
+}
+
+func (h handler) saveDebrief(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var body request.Debrief
+	if err = c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err = dto.ValidateDebrief(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	debrief, err := h.svc.SaveDebrief(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("debrief", debrief).
+		SetMessage("debrief saved"))
+}
+
+func (h handler) saveSelfAssessment(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var body request.SelfAssessment
+	if err = c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err = dto.ValidateSelfAssessment(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	debrief, err := h.svc.SaveSelfAssessment(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("debrief", debrief).
+		SetMessage("self-assessment saved"))
+}
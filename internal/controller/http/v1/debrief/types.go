@@ -0,0 +1,40 @@
+package debrief
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetDebrief(ctx context.Context, missionID uint) (response.Debrief, error)
+		RenderDebrief(ctx context.Context, missionID uint, format string) (response.DebriefDocument, error)
+
+		SaveDebrief(ctx context.Context, body request.Debrief, missionID uint) (response.Debrief, error)
+		SaveSelfAssessment(ctx context.Context, body request.SelfAssessment, missionID uint) (response.Debrief, error)
+	}
+
+	handler struct {
+		svc service
+		l   *slog.Logger
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+) {
+	h := handler{svc, l}
+
+	missions := g.Group("missions")
+	{
+		missions.GET("/:mission_id/debrief", h.getDebrief)
+		missions.PUT("/:mission_id/debrief", h.saveDebrief)
+		missions.PUT("/:mission_id/debrief/self-assessment", h.saveSelfAssessment)
+	}
+}
@@ -0,0 +1,106 @@
+package dossier
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getDossiers(c *gin.Context) {
+	dossiers, err := h.svc.GetDossiers(c.Request.Context(), c.Query("q"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("dossiers", dossiers))
+}
+
+func (h handler) getDossier(c *gin.Context) {
+	dossierID, err := strconv.ParseUint(c.Param("dossier_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid dossier_id", err))
+		return
+	}
+
+	dossier, err := h.svc.GetDossier(c.Request.Context(), uint(dossierID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("dossier", dossier))
+}
+
+func (h handler) createDossier(c *gin.Context) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.Error(config.BadRequest("invalid force", err))
+		return
+	}
+
+	var body request.Dossier
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateDossier(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	dossier, err := h.svc.CreateDossier(c.Request.Context(), body, force)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("dossier", dossier).
+		SetMessage("record created"))
+}
+
+func (h handler) updateDossier(c *gin.Context) {
+	dossierID, err := strconv.ParseUint(c.Param("dossier_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid dossier_id", err))
+		return
+	}
+
+	var body request.UpdateDossier
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateUpdateDossier(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	dossier, err := h.svc.UpdateDossier(c.Request.Context(), body, uint(dossierID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("dossier", dossier).
+		SetMessage("record updated"))
+}
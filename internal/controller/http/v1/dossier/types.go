@@ -0,0 +1,47 @@
+package dossier
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetDossiers(ctx context.Context, q string) ([]response.Dossier, error)
+		GetDossier(ctx context.Context, dossierID uint) (response.DossierDetail, error)
+		CreateDossier(ctx context.Context, body request.Dossier, force bool) (response.Dossier, error)
+		UpdateDossier(ctx context.Context, body request.UpdateDossier, dossierID uint) (response.Dossier, error)
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	dossiers := g.Group("dossiers")
+	{
+		dossiers.GET("", h.getDossiers)
+		dossiers.GET("/:dossier_id", h.getDossier)
+
+		dossiers.POST("", h.createDossier)
+		dossiers.PATCH("/:dossier_id", h.updateDossier)
+	}
+}
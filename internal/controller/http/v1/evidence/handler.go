@@ -0,0 +1,145 @@
+package evidence
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is allowed on top of the file size for part headers and boundaries.
+const multipartOverhead = 64 << 10
+
+func (h handler) getEvidence(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid target_id", err))
+		return
+	}
+
+	evidence, err := h.svc.GetEvidence(c.Request.Context(), uint(missionID), uint(targetID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("evidence", evidence))
+}
+
+// uploadEvidence takes the file from the "file" part of a multipart/form-data
+// body, streamed to the service without buffering the form.
+func (h handler) uploadEvidence(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid target_id", err))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.Error(config.ErrUnknownContentType.WithDetail("expected multipart/form-data with a file part"))
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			c.Error(config.BadRequest("file part is missing", nil))
+			return
+		}
+		if err != nil {
+			c.Error(uploadError(config.BadRequest("invalid multipart body", err)))
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		evidence, err := h.svc.UploadEvidence(c.Request.Context(), uint(missionID), uint(targetID), part.FileName(), part)
+		if err != nil {
+			c.Error(uploadError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, response.New(config.CodeOK).
+			AddKey("evidence", evidence).
+			SetMessage("evidence uploaded"))
+		return
+	}
+}
+
+func (h handler) deleteEvidence(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid target_id", err))
+		return
+	}
+
+	evidenceID, err := strconv.ParseUint(c.Param("evidence_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid evidence_id", err))
+		return
+	}
+
+	err = h.svc.DeleteEvidence(c.Request.Context(), uint(missionID), uint(targetID), uint(evidenceID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("evidence deleted"))
+}
+
+// download serves the evidence of a signed URL as an attachment.
+func (h handler) download(c *gin.Context) {
+	evidenceID, err := strconv.ParseUint(c.Param("evidence_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid evidence_id", err))
+		return
+	}
+
+	file, err := h.svc.OpenDownload(c.Request.Context(), uint(evidenceID), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Content.Close()
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, file.Content, nil)
+}
+
+// uploadError reports bodies over the size limit as too large evidence.
+func uploadError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return config.ErrEvidenceTooLarge
+	}
+	return err
+}
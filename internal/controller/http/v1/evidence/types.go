@@ -0,0 +1,44 @@
+package evidence
+
+import (
+	response "backend/pkg/api/response/cat"
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetEvidence(ctx context.Context, missionID, targetID uint) ([]response.Evidence, error)
+		UploadEvidence(ctx context.Context, missionID, targetID uint, filename string, r io.Reader) (response.Evidence, error)
+		DeleteEvidence(ctx context.Context, missionID, targetID, evidenceID uint) error
+		OpenDownload(ctx context.Context, evidenceID uint, expires, signature string) (response.EvidenceFile, error)
+	}
+
+	handler struct {
+		svc     service
+		l       *slog.Logger
+		maxSize int64
+	}
+)
+
+// InitHandler registers the evidence routes, uploads are limited to maxSize bytes.
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	maxSize int64,
+) {
+	h := handler{svc, l, maxSize}
+
+	missions := g.Group("missions")
+	{
+		missions.GET("/:mission_id/targets/:target_id/evidence", h.getEvidence)
+		missions.POST("/:mission_id/targets/:target_id/evidence", h.uploadEvidence)
+		missions.DELETE("/:mission_id/targets/:target_id/evidence/:evidence_id", h.deleteEvidence)
+	}
+
+	g.GET("/evidence/:evidence_id/download", h.download)
+}
@@ -0,0 +1,56 @@
+package mission
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	request "backend/pkg/api/request/cat"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) exportMissionBundle(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	bundle, err := h.svc.ExportMissionBundle(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The bundle is a standalone document, so it's sent without the response envelope
+	c.Header("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="mission-%d-bundle.json"`, missionID))
+	c.JSON(http.StatusOK, bundle)
+}
+
+func (h handler) importMissionBundle(c *gin.Context) {
+	attachCat, err := strconv.ParseBool(c.DefaultQuery("attach_cat", "false"))
+	if err != nil {
+		c.Error(config.BadRequest("invalid attach_cat", err))
+		return
+	}
+
+	var body request.MissionBundle
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	imported, err := h.svc.ImportMissionBundle(c.Request.Context(), body, attachCat)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("mission", imported.Mission).
+		AddKey("target_ids", imported.TargetIDs).
+		SetMessage("mission bundle imported"))
+}
@@ -2,9 +2,9 @@ package mission
 
 import (
 	"backend/config"
-	request "backend/internal/controller/http/request/cat"
 	"backend/internal/controller/http/response"
-	"fmt"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
 	"net/http"
 	"strconv"
 
@@ -12,9 +12,18 @@ import (
 )
 
 func (h handler) getMissions(c *gin.Context) {
-	missions, svcCode, err := h.svc.GetMissions(c.Request.Context())
+	filter := request.MissionFilter{
+		Priority:  c.Query("priority"),
+		DueAfter:  c.Query("due_after"),
+		DueBefore: c.Query("due_before"),
+		Overdue:   c.Query("overdue"),
+		Completed: c.Query("completed"),
+		Sort:      c.Query("sort"),
+	}
+
+	missions, err := h.svc.GetMissions(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
@@ -24,14 +33,13 @@ func (h handler) getMissions(c *gin.Context) {
 func (h handler) getMission(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
-	mission, svcCode, err := h.svc.GetMission(c.Request.Context(), uint(missionID))
+	mission, err := h.svc.GetMission(c.Request.Context(), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
@@ -40,197 +48,311 @@ func (h handler) getMission(c *gin.Context) {
 
 func (h handler) createMission(c *gin.Context) {
 	var body request.Mission
-	if err := c.BindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
 		return
 	}
 
+	h.validateAndCreate(c, body, "mission created")
+}
+
+// validateAndCreate creates the mission after the checks every created
+// mission goes through, from a body, a template or a clone. The number
+// of targets and their countries are limited by the mission type.
+func (h handler) validateAndCreate(c *gin.Context, body request.Mission, message string) {
 	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	missionType, err := h.svc.GetMissionType(c.Request.Context(), body.TypeID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	body.TypeID = &missionType.ID
+
+	if err := dto.ValidateMissionTargetsLen(body, missionType); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := body.ValidateTargetsLen(); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := dto.ValidateMission(&body); err != nil {
+		c.Error(err)
 		return
 	}
 
-	mission, svcCode, err := h.svc.CreateMission(c.Request.Context(), body)
+	if err := dto.ValidateMissionCountries(body, missionType); err != nil {
+		c.Error(err)
+		return
+	}
+
+	mission, err := h.svc.CreateMission(c.Request.Context(), body)
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
 		AddKey("mission", mission).
-		SetMessage("mission created"))
+		SetMessage(message))
 }
 
 func (h handler) assignCat(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
 	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid cat_id: %v", err)))
+		c.Error(config.BadRequest("invalid cat_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.AssignCat(c.Request.Context(), uint(missionID), uint(catID))
+	err = h.svc.AssignCat(c.Request.Context(), uint(missionID), uint(catID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("cat assigned to mission"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("cat assigned to mission"))
+}
+
+func (h handler) getCandidates(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	candidates, err := h.svc.GetCandidates(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("candidates", candidates))
+}
+
+func (h handler) autoAssign(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	candidate, err := h.svc.AutoAssign(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("candidate", candidate).
+		SetMessage("cat assigned to mission"))
 }
 
 func (h handler) completeMission(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.CompleteMission(c.Request.Context(), uint(missionID))
+	err = h.svc.CompleteMission(c.Request.Context(), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("mission completed"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("mission completed"))
 }
 
 func (h handler) deleteMission(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.DeleteMission(c.Request.Context(), uint(missionID))
+	err = h.svc.DeleteMission(c.Request.Context(), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("mission deleted"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("mission deleted"))
 }
 
 func (h handler) createTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
 	var body request.Target
-	if err := c.BindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
 		return
 	}
 
 	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateTarget(&body); err != nil {
+		c.Error(err)
 		return
 	}
 
-	svcCode, err := h.svc.CreateTarget(c.Request.Context(), body, uint(missionID))
+	target, err := h.svc.CreateTarget(c.Request.Context(), body, uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("target created"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("target", target).
+		SetMessage("target created"))
 }
 
 func (h handler) updateTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
 	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid target_id: %v", err)))
+		c.Error(config.BadRequest("invalid target_id", err))
 		return
 	}
 
 	var body request.UpdateTarget
-	if err := c.BindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
 		return
 	}
 
 	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErr(config.CodeBadRequest, err))
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	err = h.svc.UpdateTarget(c.Request.Context(), body, uint(targetID), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("target updated"))
+}
+
+func (h handler) updateMissionSchedule(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var body request.Schedule
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := dto.ValidateSchedule(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	mission, err := h.svc.UpdateMissionSchedule(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("mission", mission).SetMessage("record updated"))
+}
+
+func (h handler) updateTargetSchedule(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid target_id", err))
+		return
+	}
+
+	var body request.Schedule
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := dto.ValidateSchedule(&body); err != nil {
+		c.Error(err)
 		return
 	}
 
-	svcCode, err := h.svc.UpdateTarget(c.Request.Context(), body, uint(targetID), uint(missionID))
+	err = h.svc.UpdateTargetSchedule(c.Request.Context(), body, uint(targetID), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("target updated"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("target updated"))
 }
 
 func (h handler) completeTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
 	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid target_id: %v", err)))
+		c.Error(config.BadRequest("invalid target_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.CompleteTarget(c.Request.Context(), uint(targetID), uint(missionID))
+	err = h.svc.CompleteTarget(c.Request.Context(), uint(targetID), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("target completed"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("target completed"))
 }
 
 func (h handler) deleteTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid mission_id: %v", err)))
+		c.Error(config.BadRequest("invalid mission_id", err))
 		return
 	}
 
 	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			response.NewErr(config.CodeBadRequest, fmt.Errorf("invalid target_id: %v", err)))
+		c.Error(config.BadRequest("invalid target_id", err))
 		return
 	}
 
-	svcCode, err := h.svc.DeleteTarget(c.Request.Context(), uint(targetID), uint(missionID))
+	err = h.svc.DeleteTarget(c.Request.Context(), uint(targetID), uint(missionID))
 	if err != nil {
-		c.JSON(config.CodeToHttpStatus(svcCode), response.NewErr(svcCode, err))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response.New(svcCode).SetMessage("target deleted"))
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("target deleted"))
 }
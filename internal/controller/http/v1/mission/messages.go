@@ -0,0 +1,126 @@
+package mission
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getMessages(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var afterID uint64
+	if after := c.Query("after_id"); after != "" {
+		if afterID, err = strconv.ParseUint(after, 10, 32); err != nil {
+			c.Error(config.BadRequest("invalid after_id", err))
+			return
+		}
+	}
+
+	thread, err := h.svc.GetMessages(c.Request.Context(), uint(missionID), uint(afterID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("messages", thread.Messages).
+		AddKey("archived", thread.Archived))
+}
+
+func (h handler) postMessage(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var body request.Message
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := dto.ValidateMessage(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	message, err := h.svc.PostMessage(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("message", message).
+		SetMessage("message posted"))
+}
+
+func (h handler) markMessagesRead(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	// the body is optional, everything is marked read without it
+	var body request.MarkRead
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(config.BadRequest("invalid request body", err))
+			return
+		}
+	}
+
+	marked, err := h.svc.MarkMessagesRead(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("marked", marked).
+		SetMessage("messages marked read"))
+}
+
+// getMessageAttachment sends the attachment as a download, never rendered inline.
+func (h handler) getMessageAttachment(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid message_id", err))
+		return
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachment_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid attachment_id", err))
+		return
+	}
+
+	file, err := h.svc.GetMessageAttachment(c.Request.Context(), uint(missionID), uint(messageID), uint(attachmentID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -0,0 +1,149 @@
+package mission
+
+import (
+	"backend/config"
+	"backend/pkg/eventbus"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsMaxMessageSize = 512 // clients only send control frames
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// subscribe subscribes to the updates of the mission in the path,
+// errors are attached to c and nil returned.
+func (h handler) subscribe(c *gin.Context) *eventbus.Subscription {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return nil
+	}
+
+	sub, err := h.svc.SubscribeMission(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return nil
+	}
+	return sub
+}
+
+// streamMission pushes mission updates as Server-Sent Events named after the
+// update type, with a comment line as heartbeat.
+func (h handler) streamMission(c *gin.Context) {
+	sub := h.subscribe(c)
+	if sub == nil {
+		return
+	}
+	defer sub.Close()
+
+	// streams outlive the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.l.Warn("unable to clear stream write deadline", "err", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(config.StreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			var update struct {
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal(msg, &update); err != nil {
+				h.l.Warn("malformed mission update", "err", err)
+				continue
+			}
+			c.SSEvent(update.Type, string(msg))
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// streamMissionWS pushes mission updates as WebSocket text messages and pings
+// the client, the connection is closed when the client stops answering.
+func (h handler) streamMissionWS(c *gin.Context) {
+	sub := h.subscribe(c)
+	if sub == nil {
+		return
+	}
+	defer sub.Close()
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader has replied already
+		return
+	}
+	defer conn.Close()
+
+	readTimeout := 2 * config.StreamHeartbeat
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
+	// reading processes pongs and close frames
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(config.StreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+					time.Now().Add(wsWriteWait))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err = conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package mission
+
+import (
+	"backend/config"
+	request "backend/pkg/api/request/cat"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// createFromTemplate creates a mission from a template, the body is optional.
+func (h handler) createFromTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("template_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid template_id", err))
+		return
+	}
+
+	var body request.MissionFromTemplate
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(config.BadRequest("invalid request body", err))
+			return
+		}
+	}
+
+	mission, err := h.svc.MissionFromTemplate(c.Request.Context(), body, uint(templateID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.validateAndCreate(c, mission, "mission created from template")
+}
+
+// cloneMission creates a copy of a mission, the body is optional.
+func (h handler) cloneMission(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("mission_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid mission_id", err))
+		return
+	}
+
+	var body request.CloneMission
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(config.BadRequest("invalid request body", err))
+			return
+		}
+	}
+
+	mission, err := h.svc.MissionFromClone(c.Request.Context(), body, uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.validateAndCreate(c, mission, "mission cloned")
+}
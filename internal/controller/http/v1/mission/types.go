@@ -1,9 +1,9 @@
 package mission
 
 import (
-	"backend/config"
-	request "backend/internal/controller/http/request/cat"
-	response "backend/internal/controller/http/response/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/eventbus"
 	"context"
 	"log/slog"
 
@@ -12,18 +12,35 @@ import (
 
 type (
 	service interface {
-		GetMissions(ctx context.Context) ([]response.Mission, config.ServiceCode, error)
-		GetMission(ctx context.Context, missionID uint) (response.Mission, config.ServiceCode, error)
-
-		CreateMission(ctx context.Context, mission request.Mission) (response.Mission, config.ServiceCode, error)
-		AssignCat(ctx context.Context, missionID, catID uint) (config.ServiceCode, error)
-		CompleteMission(ctx context.Context, missionID uint) (config.ServiceCode, error)
-		DeleteMission(ctx context.Context, missionID uint) (config.ServiceCode, error)
-
-		CreateTarget(ctx context.Context, body request.Target, missionID uint) (config.ServiceCode, error)
-		UpdateTarget(ctx context.Context, body request.UpdateTarget, targetID, missionID uint) (config.ServiceCode, error)
-		CompleteTarget(ctx context.Context, targetID, missionID uint) (config.ServiceCode, error)
-		DeleteTarget(ctx context.Context, targetID, missionID uint) (config.ServiceCode, error)
+		GetMissions(ctx context.Context, filter request.MissionFilter) ([]response.Mission, error)
+		GetMission(ctx context.Context, missionID uint) (response.Mission, error)
+
+		GetMissionType(ctx context.Context, typeID *uint) (response.MissionType, error)
+		CreateMission(ctx context.Context, mission request.Mission) (response.Mission, error)
+		MissionFromTemplate(ctx context.Context, body request.MissionFromTemplate, templateID uint) (request.Mission, error)
+		MissionFromClone(ctx context.Context, body request.CloneMission, missionID uint) (request.Mission, error)
+		AssignCat(ctx context.Context, missionID, catID uint) error
+		UpdateMissionSchedule(ctx context.Context, body request.Schedule, missionID uint) (response.Mission, error)
+		GetCandidates(ctx context.Context, missionID uint) ([]response.Candidate, error)
+		AutoAssign(ctx context.Context, missionID uint) (response.Candidate, error)
+		CompleteMission(ctx context.Context, missionID uint) error
+		DeleteMission(ctx context.Context, missionID uint) error
+
+		CreateTarget(ctx context.Context, body request.Target, missionID uint) (response.Target, error)
+		UpdateTarget(ctx context.Context, body request.UpdateTarget, targetID, missionID uint) error
+		UpdateTargetSchedule(ctx context.Context, body request.Schedule, targetID, missionID uint) error
+		CompleteTarget(ctx context.Context, targetID, missionID uint) error
+		DeleteTarget(ctx context.Context, targetID, missionID uint) error
+
+		SubscribeMission(ctx context.Context, missionID uint) (*eventbus.Subscription, error)
+
+		GetMessages(ctx context.Context, missionID, afterID uint) (response.Thread, error)
+		PostMessage(ctx context.Context, body request.Message, missionID uint) (response.Message, error)
+		MarkMessagesRead(ctx context.Context, body request.MarkRead, missionID uint) (int64, error)
+		GetMessageAttachment(ctx context.Context, missionID, messageID, attachmentID uint) (response.AttachmentFile, error)
+
+		ExportMissionBundle(ctx context.Context, missionID uint) (response.MissionBundle, error)
+		ImportMissionBundle(ctx context.Context, bundle request.MissionBundle, attachCat bool) (response.BundleImport, error)
 	}
 
 	validator interface {
@@ -49,17 +66,33 @@ func InitHandler(
 	{
 		missions.GET("", h.getMissions)
 		missions.GET("/:mission_id", h.getMission)
+		missions.GET("/:mission_id/stream", h.streamMission)
+		missions.GET("/:mission_id/ws", h.streamMissionWS)
 
 		missions.POST("", h.createMission)
+		missions.POST("/from-template/:template_id", h.createFromTemplate)
+		missions.POST("/:mission_id/clone", h.cloneMission)
+
+		missions.GET("/:mission_id/bundle", h.exportMissionBundle)
+		missions.POST("/bundle", h.importMissionBundle)
 
+		missions.GET("/:mission_id/candidates", h.getCandidates)
+		missions.POST("/:mission_id/auto-assign", h.autoAssign)
 		missions.PATCH("/:mission_id/assign/:cat_id", h.assignCat)
 		missions.PATCH("/:mission_id/complete", h.completeMission)
+		missions.PUT("/:mission_id/schedule", h.updateMissionSchedule)
 
 		missions.DELETE("/:mission_id", h.deleteMission)
 
+		missions.GET("/:mission_id/messages", h.getMessages)
+		missions.POST("/:mission_id/messages", h.postMessage)
+		missions.POST("/:mission_id/messages/read", h.markMessagesRead)
+		missions.GET("/:mission_id/messages/:message_id/attachments/:attachment_id", h.getMessageAttachment)
+
 		missions.POST("/:mission_id/targets", h.createTarget)
 		missions.PATCH("/:mission_id/targets/:target_id", h.updateTarget)
 		missions.PATCH("/:mission_id/targets/:target_id/complete", h.completeTarget)
+		missions.PUT("/:mission_id/targets/:target_id/schedule", h.updateTargetSchedule)
 		missions.DELETE("/:mission_id/targets/:target_id", h.deleteTarget)
 	}
 }
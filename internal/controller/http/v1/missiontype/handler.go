@@ -0,0 +1,115 @@
+package missiontype
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getMissionTypes(c *gin.Context) {
+	types, err := h.svc.GetMissionTypes(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("types", types))
+}
+
+func (h handler) getMissionType(c *gin.Context) {
+	typeID, err := strconv.ParseUint(c.Param("type_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid type_id", err))
+		return
+	}
+
+	missionType, err := h.svc.GetMissionType(c.Request.Context(), uint(typeID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("type", missionType))
+}
+
+func (h handler) createMissionType(c *gin.Context) {
+	var body request.MissionType
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateMissionType(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	missionType, err := h.svc.CreateMissionType(c.Request.Context(), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("type", missionType).
+		SetMessage("mission type created"))
+}
+
+func (h handler) updateMissionType(c *gin.Context) {
+	typeID, err := strconv.ParseUint(c.Param("type_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid type_id", err))
+		return
+	}
+
+	var body request.MissionType
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateMissionType(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	missionType, err := h.svc.UpdateMissionType(c.Request.Context(), body, uint(typeID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("type", missionType).
+		SetMessage("mission type updated"))
+}
+
+func (h handler) deleteMissionType(c *gin.Context) {
+	typeID, err := strconv.ParseUint(c.Param("type_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid type_id", err))
+		return
+	}
+
+	if err := h.svc.DeleteMissionType(c.Request.Context(), uint(typeID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("mission type deleted"))
+}
@@ -0,0 +1,50 @@
+package missiontype
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetMissionTypes(ctx context.Context) ([]response.MissionType, error)
+		GetMissionType(ctx context.Context, typeID uint) (response.MissionType, error)
+
+		CreateMissionType(ctx context.Context, body request.MissionType) (response.MissionType, error)
+		UpdateMissionType(ctx context.Context, body request.MissionType, typeID uint) (response.MissionType, error)
+		DeleteMissionType(ctx context.Context, typeID uint) error
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	types := g.Group("mission-types")
+	{
+		types.GET("", h.getMissionTypes)
+		types.GET("/:type_id", h.getMissionType)
+
+		types.POST("", h.createMissionType)
+		types.PUT("/:type_id", h.updateMissionType)
+		types.DELETE("/:type_id", h.deleteMissionType)
+	}
+}
@@ -0,0 +1,100 @@
+package payroll
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	request "backend/pkg/api/request/cat"
+	rescat "backend/pkg/api/response/cat"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getPayroll(c *gin.Context) {
+	month := c.Param("month")
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.Error(config.ErrUnknownFormat.WithDetail(format))
+		return
+	}
+
+	currency := strings.ToUpper(c.DefaultQuery("currency", config.DefaultCurrency))
+
+	report, err := h.svc.GetPayroll(c.Request.Context(), month, currency)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("payroll", report))
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="payroll-%s.csv"`, report.Month))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write(rescat.PayrollCSVHeader)
+	for _, p := range report.Payouts {
+		w.Write(p.CSVRecord())
+	}
+	for _, total := range report.Totals {
+		w.Write([]string{"", "total", "", strconv.FormatInt(total.Amount, 10), total.Currency})
+	}
+	w.Write([]string{"", "total converted", "", strconv.FormatInt(report.Total.Amount, 10), report.Total.Currency})
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		h.l.Error("payroll csv write failed", "month", report.Month, "err", err)
+	}
+}
+
+func (h handler) getExchangeRates(c *gin.Context) {
+	rates, err := h.svc.GetExchangeRates(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("exchange_rates", rates))
+}
+
+func (h handler) setExchangeRate(c *gin.Context) {
+	var body request.ExchangeRate
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	err := h.svc.SetExchangeRate(c.Request.Context(), body, c.Param("base"), c.Param("quote"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("exchange rate set"))
+}
+
+func (h handler) deleteExchangeRate(c *gin.Context) {
+	base, quote := strings.ToUpper(c.Param("base")), strings.ToUpper(c.Param("quote"))
+
+	err := h.svc.DeleteExchangeRate(c.Request.Context(), base, quote)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("exchange rate deleted"))
+}
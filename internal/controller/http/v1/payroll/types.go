@@ -0,0 +1,51 @@
+package payroll
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetPayroll(ctx context.Context, month, currency string) (response.PayrollReport, error)
+
+		GetExchangeRates(ctx context.Context) ([]response.ExchangeRate, error)
+		SetExchangeRate(ctx context.Context, body request.ExchangeRate, base, quote string) error
+		DeleteExchangeRate(ctx context.Context, base, quote string) error
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	payroll := g.Group("payroll")
+	{
+		payroll.GET("/:month", h.getPayroll)
+	}
+
+	rates := g.Group("exchange-rates")
+	{
+		rates.GET("", h.getExchangeRates)
+		rates.PUT("/:base/:quote", h.setExchangeRate)
+		rates.DELETE("/:base/:quote", h.deleteExchangeRate)
+	}
+}
@@ -0,0 +1,35 @@
+package search
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) search(c *gin.Context) {
+	var types []string
+	if t := c.Query("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			c.Error(config.BadRequest("invalid limit", err))
+			return
+		}
+	}
+
+	results, err := h.svc.Search(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("results", results))
+}
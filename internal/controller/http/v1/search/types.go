@@ -0,0 +1,30 @@
+package search
+
+import (
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		Search(ctx context.Context, q string, types []string, limit int) ([]response.SearchResult, error)
+	}
+
+	handler struct {
+		svc service
+		l   *slog.Logger
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+) {
+	h := handler{svc, l}
+
+	g.GET("/search", h.search)
+}
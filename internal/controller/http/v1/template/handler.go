@@ -0,0 +1,115 @@
+package template
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getTemplates(c *gin.Context) {
+	templates, err := h.svc.GetTemplates(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("templates", templates))
+}
+
+func (h handler) getTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("template_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid template_id", err))
+		return
+	}
+
+	template, err := h.svc.GetTemplate(c.Request.Context(), uint(templateID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("template", template))
+}
+
+func (h handler) createTemplate(c *gin.Context) {
+	var body request.MissionTemplate
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateTemplate(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.svc.CreateTemplate(c.Request.Context(), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("template", template).
+		SetMessage("mission template created"))
+}
+
+func (h handler) updateTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("template_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid template_id", err))
+		return
+	}
+
+	var body request.MissionTemplate
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateTemplate(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.svc.UpdateTemplate(c.Request.Context(), body, uint(templateID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("template", template).
+		SetMessage("mission template updated"))
+}
+
+func (h handler) deleteTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("template_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid template_id", err))
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(c.Request.Context(), uint(templateID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("mission template deleted"))
+}
@@ -0,0 +1,50 @@
+package template
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetTemplates(ctx context.Context) ([]response.MissionTemplate, error)
+		GetTemplate(ctx context.Context, templateID uint) (response.MissionTemplate, error)
+
+		CreateTemplate(ctx context.Context, body request.MissionTemplate) (response.MissionTemplate, error)
+		UpdateTemplate(ctx context.Context, body request.MissionTemplate, templateID uint) (response.MissionTemplate, error)
+		DeleteTemplate(ctx context.Context, templateID uint) error
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	templates := g.Group("mission-templates")
+	{
+		templates.GET("", h.getTemplates)
+		templates.GET("/:template_id", h.getTemplate)
+
+		templates.POST("", h.createTemplate)
+		templates.PUT("/:template_id", h.updateTemplate)
+		templates.DELETE("/:template_id", h.deleteTemplate)
+	}
+}
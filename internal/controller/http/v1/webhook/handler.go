@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"backend/config"
+	"backend/internal/controller/http/response"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h handler) getWebhooks(c *gin.Context) {
+	webhooks, err := h.svc.GetWebhooks(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("webhooks", webhooks))
+}
+
+func (h handler) getWebhook(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid webhook_id", err))
+		return
+	}
+
+	webhook, err := h.svc.GetWebhook(c.Request.Context(), uint(webhookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("webhook", webhook))
+}
+
+func (h handler) getDeliveries(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid webhook_id", err))
+		return
+	}
+
+	deliveries, err := h.svc.GetDeliveries(c.Request.Context(), uint(webhookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).AddKey("deliveries", deliveries))
+}
+
+func (h handler) createWebhook(c *gin.Context) {
+	var body request.Webhook
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(config.BadRequest("invalid request body", err))
+		return
+	}
+
+	if valid, err := h.validator.ValidateStruct(body); !valid || err != nil {
+		c.Error(config.BadRequest("validation failed", err))
+		return
+	}
+
+	if err := dto.ValidateWebhook(&body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := h.svc.CreateWebhook(c.Request.Context(), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("webhook", webhook).
+		SetMessage("record created"))
+}
+
+func (h handler) redeliver(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid webhook_id", err))
+		return
+	}
+
+	// the body is optional, every failed delivery is replayed without it
+	var body request.Redeliver
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(config.BadRequest("invalid request body", err))
+			return
+		}
+	}
+
+	deliveries, err := h.svc.Redeliver(c.Request.Context(), body, uint(webhookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).
+		AddKey("deliveries", deliveries).
+		SetMessage("record created"))
+}
+
+func (h handler) deleteWebhook(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.Error(config.BadRequest("invalid webhook_id", err))
+		return
+	}
+
+	err = h.svc.DeleteWebhook(c.Request.Context(), uint(webhookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(config.CodeOK).SetMessage("record deleted"))
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	service interface {
+		GetWebhooks(ctx context.Context) ([]response.Webhook, error)
+		GetWebhook(ctx context.Context, webhookID uint) (response.Webhook, error)
+		CreateWebhook(ctx context.Context, body request.Webhook) (response.Webhook, error)
+		DeleteWebhook(ctx context.Context, webhookID uint) error
+
+		GetDeliveries(ctx context.Context, webhookID uint) ([]response.WebhookDelivery, error)
+		Redeliver(ctx context.Context, body request.Redeliver, webhookID uint) ([]response.WebhookDelivery, error)
+	}
+
+	validator interface {
+		ValidateStruct(i any) (bool, error)
+	}
+
+	handler struct {
+		svc       service
+		l         *slog.Logger
+		validator validator
+	}
+)
+
+func InitHandler(
+	g *gin.Engine,
+	l *slog.Logger,
+	svc service,
+	validator validator,
+) {
+	h := handler{svc, l, validator}
+
+	webhooks := g.Group("webhooks")
+	{
+		webhooks.GET("", h.getWebhooks)
+		webhooks.GET("/:webhook_id", h.getWebhook)
+		webhooks.GET("/:webhook_id/deliveries", h.getDeliveries)
+
+		webhooks.POST("", h.createWebhook)
+		webhooks.POST("/:webhook_id/redeliver", h.redeliver)
+		webhooks.DELETE("/:webhook_id", h.deleteWebhook)
+	}
+}
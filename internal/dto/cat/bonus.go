@@ -0,0 +1,102 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"time"
+)
+
+// ValidateBonusRule checks the rule fields of its kind and normalizes the country.
+func ValidateBonusRule(r *request.BonusRule) error {
+	switch r.Kind {
+	case config.BonusPerTarget, config.BonusPerMission:
+		if r.Amount.Amount <= 0 {
+			return config.ErrInvalidBonusRule.WithDetail("amount should be positive")
+		}
+		if r.Multiplier != "" {
+			return config.ErrInvalidBonusRule.WithDetail("multiplier is only allowed for multiplier kinds")
+		}
+	case config.BonusCountryMultiplier, config.BonusExperienceMultiplier:
+		if !r.Amount.IsZero() {
+			return config.ErrInvalidBonusRule.WithDetail("amount is only allowed for per_target and per_mission kinds")
+		}
+		if _, err := money.ParseRate(r.Multiplier); err != nil {
+			return config.ErrInvalidBonusRule.WithDetail("multiplier should be a positive decimal")
+		}
+		if r.Kind == config.BonusCountryMultiplier {
+			code, err := normalizeCountry(r.Country)
+			if err != nil {
+				return err
+			}
+			r.Country = code
+		}
+	default:
+		return config.ErrInvalidBonusRuleKind.WithDetail(r.Kind)
+	}
+	return nil
+}
+
+func BonusRuleToEntity(r request.BonusRule) entity.BonusRule {
+	rule := entity.BonusRule{
+		Kind:          r.Kind,
+		Multiplier:    r.Multiplier,
+		Country:       r.Country,
+		MinExperience: r.MinExperience,
+		Description:   r.Description,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if r.Kind == config.BonusPerTarget || r.Kind == config.BonusPerMission {
+		rule.Amount = withDefaultCurrency(r.Amount)
+	}
+	return rule
+}
+
+func BonusRuleToResponse(r entity.BonusRule) response.BonusRule {
+	return response.BonusRule{
+		ID:            r.ID,
+		Kind:          r.Kind,
+		Amount:        r.Amount,
+		Multiplier:    r.Multiplier,
+		Country:       r.Country,
+		MinExperience: r.MinExperience,
+		Description:   r.Description,
+
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func BonusRulesToResponse(rules []entity.BonusRule) []response.BonusRule {
+	res := make([]response.BonusRule, 0, len(rules))
+	for _, r := range rules {
+		res = append(res, BonusRuleToResponse(r))
+	}
+	return res
+}
+
+func BonusEntryToResponse(e entity.BonusEntry) response.BonusEntry {
+	return response.BonusEntry{
+		ID:          e.ID,
+		CatID:       e.CatID,
+		MissionID:   e.MissionID,
+		TargetID:    e.TargetID,
+		RuleID:      e.RuleID,
+		Amount:      e.Amount,
+		Description: e.Description,
+
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func BonusEntriesToResponse(entries []entity.BonusEntry) []response.BonusEntry {
+	res := make([]response.BonusEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, BonusEntryToResponse(e))
+	}
+	return res
+}
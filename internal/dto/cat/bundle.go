@@ -0,0 +1,143 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"fmt"
+	"time"
+)
+
+// ValidateBundleVersion checks the bundle can be read by this version.
+func ValidateBundleVersion(b request.MissionBundle) error {
+	if b.Version != config.MissionBundleVersion {
+		return config.ErrBundleUnsupportedVersion.WithDetail(
+			fmt.Sprintf("got %d, supported %d", b.Version, config.MissionBundleVersion))
+	}
+	return nil
+}
+
+// ValidateBundle checks the number of targets and schedules against the mission
+// type, and normalizes target countries. Target source IDs must be unique and non
+// zero, they key the imported target IDs. The version is checked by ValidateBundleVersion.
+func ValidateBundle(b *request.MissionBundle, missionType response.MissionType) error {
+	if err := validateTargetsLen(missionType, len(b.Mission.Targets)); err != nil {
+		return err
+	}
+
+	priority, err := validateSchedule(b.Mission.Priority, b.Mission.StartsAt, b.Mission.DueAt)
+	if err != nil {
+		return err
+	}
+	b.Mission.Priority = priority
+
+	sourceIDs := make(map[uint]bool, len(b.Mission.Targets))
+	for i, t := range b.Mission.Targets {
+		if t.Name == "" || t.Country == "" {
+			return config.ErrInvalidBundleTarget.WithDetail(fmt.Sprintf("target %d: name and country are required", i))
+		}
+		if t.SourceID == 0 {
+			return config.ErrInvalidBundleTarget.WithDetail(fmt.Sprintf("target %d: source_id is required", i))
+		}
+		if sourceIDs[t.SourceID] {
+			return config.ErrInvalidBundleTarget.WithDetail(fmt.Sprintf("target %d: duplicate source_id %d", i, t.SourceID))
+		}
+		sourceIDs[t.SourceID] = true
+
+		if b.Mission.Targets[i].Priority, err = validateSchedule(t.Priority, t.StartsAt, t.DueAt); err != nil {
+			return err
+		}
+		code, err := normalizeCountry(t.Country)
+		if err != nil {
+			return err
+		}
+		if err = validateCountry(missionType, code); err != nil {
+			return err
+		}
+		b.Mission.Targets[i].Country = code
+	}
+
+	return nil
+}
+
+// BundleToEntity returns the mission without source IDs, keeping its completion
+// state and timestamps. The cat is resolved separately.
+func BundleToEntity(b request.MissionBundle) entity.Mission {
+	m := b.Mission
+	mission := entity.Mission{
+		IsCompleted: m.IsCompleted,
+		Priority:    m.Priority,
+		StartsAt:    m.StartsAt,
+		DueAt:       m.DueAt,
+		CreatedAt:   orNow(m.CreatedAt),
+		UpdatedAt:   time.Now(),
+		Targets:     make([]entity.Target, 0, len(m.Targets)),
+	}
+
+	for _, t := range m.Targets {
+		mission.Targets = append(mission.Targets, entity.Target{
+			Name:        t.Name,
+			Country:     t.Country,
+			Notes:       t.Notes,
+			IsCompleted: t.IsCompleted,
+			Priority:    t.Priority,
+			StartsAt:    t.StartsAt,
+			DueAt:       t.DueAt,
+			CreatedAt:   orNow(t.CreatedAt),
+			UpdatedAt:   time.Now(),
+		})
+	}
+
+	return mission
+}
+
+func orNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
+func MissionToBundle(m entity.Mission) response.MissionBundle {
+	bundle := response.MissionBundle{
+		Version:    config.MissionBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Mission: response.BundleMission{
+			SourceID:    m.ID,
+			IsCompleted: m.IsCompleted,
+			Priority:    m.Priority,
+			StartsAt:    m.StartsAt,
+			DueAt:       m.DueAt,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+			Targets:     make([]response.BundleTarget, 0, len(m.Targets)),
+		},
+	}
+
+	if m.CatID != nil && m.Cat.ID != 0 {
+		bundle.Mission.Cat = &response.BundleCat{
+			SourceID:        m.Cat.ID,
+			Name:            m.Cat.Name,
+			Breed:           m.Cat.Breed,
+			YearsExperience: m.Cat.YearsExperience,
+		}
+	}
+
+	for _, t := range m.Targets {
+		bundle.Mission.Targets = append(bundle.Mission.Targets, response.BundleTarget{
+			SourceID:    t.ID,
+			Name:        t.Name,
+			Country:     t.Country,
+			Notes:       t.Notes,
+			IsCompleted: t.IsCompleted,
+			Priority:    t.Priority,
+			StartsAt:    t.StartsAt,
+			DueAt:       t.DueAt,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   t.UpdatedAt,
+		})
+	}
+
+	return bundle
+}
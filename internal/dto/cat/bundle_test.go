@@ -0,0 +1,123 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	catID := uint(3)
+	created := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mission := entity.Mission{
+		ID:          7,
+		CatID:       &catID,
+		Cat:         entity.Cat{ID: 3, Name: "Tom", Breed: "Siamese", YearsExperience: 4},
+		IsCompleted: true,
+		Priority:    config.PriorityHigh,
+		DueAt:       &due,
+		CreatedAt:   created,
+		Targets: []entity.Target{
+			{ID: 11, MissionID: 7, Name: "Ivan", Country: "UA", Notes: "met twice", IsCompleted: true, Priority: config.PriorityCritical, CreatedAt: created},
+			{ID: 12, MissionID: 7, Name: "Olga", Country: "PL", Priority: config.PriorityNormal, DueAt: &due, CreatedAt: created},
+		},
+	}
+	missionType := response.MissionType{Name: "standard", MinTargets: 1, MaxTargets: 3}
+
+	// exported as JSON, imported from it
+	data, err := json.Marshal(MissionToBundle(mission))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle request.MissionBundle
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		t.Fatal(err)
+	}
+	if err = ValidateBundleVersion(bundle); err != nil {
+		t.Fatal(err)
+	}
+	if err = ValidateBundle(&bundle, missionType); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := bundle.Mission.Cat; c == nil || c.SourceID != 3 || c.Name != "Tom" {
+		t.Errorf("bundle cat = %+v", c)
+	}
+
+	got := BundleToEntity(bundle)
+	if got.ID != 0 || got.CatID != nil || !got.IsCompleted || got.Priority != config.PriorityHigh ||
+		!got.DueAt.Equal(due) || !got.CreatedAt.Equal(created) {
+		t.Errorf("mission = %+v, want the exported state without IDs and cat", got)
+	}
+	if len(got.Targets) != len(mission.Targets) {
+		t.Fatalf("targets = %d, want %d", len(got.Targets), len(mission.Targets))
+	}
+	for i, target := range got.Targets {
+		want := mission.Targets[i]
+		if bundle.Mission.Targets[i].SourceID != want.ID {
+			t.Errorf("target %d source id = %d, want %d", i, bundle.Mission.Targets[i].SourceID, want.ID)
+		}
+		if target.ID != 0 || target.MissionID != 0 || target.Name != want.Name || target.Country != want.Country ||
+			target.Notes != want.Notes || target.IsCompleted != want.IsCompleted || target.Priority != want.Priority ||
+			!reflect.DeepEqual(target.DueAt, want.DueAt) || !target.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("target %d = %+v, want %+v without IDs", i, target, want)
+		}
+	}
+}
+
+func TestValidateBundle(t *testing.T) {
+	missionType := response.MissionType{Name: "border", MinTargets: 1, MaxTargets: 3, Countries: []string{"PL", "UA"}}
+	bundle := func(targets ...response.BundleTarget) request.MissionBundle {
+		var b request.MissionBundle
+		b.Version = config.MissionBundleVersion
+		b.Mission.Targets = targets
+		return b
+	}
+
+	tests := []struct {
+		name          string
+		bundle        request.MissionBundle
+		wantCountries []string
+		wantErr       error
+	}{
+		{
+			name:          "valid",
+			bundle:        bundle(response.BundleTarget{SourceID: 1, Name: "Ivan", Country: "Ukraine"}, response.BundleTarget{SourceID: 2, Name: "Olga", Country: "pl"}),
+			wantCountries: []string{"UA", "PL"},
+		},
+		{name: "no targets", bundle: bundle(), wantErr: config.ErrMissionHasInvalidTargetsLen},
+		{name: "no name", bundle: bundle(response.BundleTarget{SourceID: 1, Country: "UA"}), wantErr: config.ErrInvalidBundleTarget},
+		{name: "no source id", bundle: bundle(response.BundleTarget{Name: "Ivan", Country: "UA"}), wantErr: config.ErrInvalidBundleTarget},
+		{
+			name:    "duplicate source id",
+			bundle:  bundle(response.BundleTarget{SourceID: 4, Name: "Ivan", Country: "UA"}, response.BundleTarget{SourceID: 4, Name: "Olga", Country: "PL"}),
+			wantErr: config.ErrInvalidBundleTarget,
+		},
+		{name: "unknown country", bundle: bundle(response.BundleTarget{SourceID: 1, Name: "Ivan", Country: "Atlantis"}), wantErr: config.ErrUnknownCountry},
+		{name: "country not allowed", bundle: bundle(response.BundleTarget{SourceID: 1, Name: "Ivan", Country: "FR"}), wantErr: config.ErrCountryNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBundle(&tt.bundle, missionType)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for i, target := range tt.bundle.Mission.Targets {
+				if target.Country != tt.wantCountries[i] {
+					t.Errorf("target %d country = %q, want %q", i, target.Country, tt.wantCountries[i])
+				}
+			}
+		})
+	}
+}
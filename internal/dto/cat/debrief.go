@@ -0,0 +1,114 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateDebrief trims the texts and checks the rating and outcomes. Whether the
+// targets belong to the mission is checked by the service.
+func ValidateDebrief(d *request.Debrief) error {
+	d.Summary = strings.TrimSpace(d.Summary)
+	if d.Summary == "" {
+		return config.ErrInvalidDebrief.WithDetail("summary is required")
+	}
+	if err := validateDebriefText("summary", d.Summary); err != nil {
+		return err
+	}
+	if d.Rating != nil && (*d.Rating < config.MinDebriefRating || *d.Rating > config.MaxDebriefRating) {
+		return config.ErrInvalidDebrief.WithDetail(
+			fmt.Sprintf("rating should be from %d to %d", config.MinDebriefRating, config.MaxDebriefRating))
+	}
+
+	seen := make(map[uint]bool, len(d.Targets))
+	for i := range d.Targets {
+		t := &d.Targets[i]
+		if t.TargetID == 0 {
+			return config.ErrInvalidDebrief.WithDetail("target_id is required")
+		}
+		if seen[t.TargetID] {
+			return config.ErrInvalidDebrief.WithDetail(fmt.Sprintf("target %d is listed twice", t.TargetID))
+		}
+		seen[t.TargetID] = true
+
+		if !slices.Contains(config.TargetOutcomes, t.Outcome) {
+			return config.ErrInvalidDebrief.WithDetail(fmt.Sprintf("target %d: outcome should be one of %s",
+				t.TargetID, strings.Join(config.TargetOutcomes, ", ")))
+		}
+		t.Notes = strings.TrimSpace(t.Notes)
+		if err := validateDebriefText("notes", t.Notes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DebriefToEntity(d request.Debrief, missionID uint) entity.Debrief {
+	targets := make([]entity.DebriefTarget, 0, len(d.Targets))
+	for _, t := range d.Targets {
+		targets = append(targets, entity.DebriefTarget{
+			TargetID: t.TargetID,
+			Outcome:  t.Outcome,
+			Notes:    t.Notes,
+		})
+	}
+	return entity.Debrief{
+		MissionID: missionID,
+		Summary:   d.Summary,
+		Rating:    d.Rating,
+		Targets:   targets,
+	}
+}
+
+func ValidateSelfAssessment(s *request.SelfAssessment) error {
+	s.SelfAssessment = strings.TrimSpace(s.SelfAssessment)
+	if s.SelfAssessment == "" {
+		return config.ErrInvalidDebrief.WithDetail("self_assessment is required")
+	}
+	return validateDebriefText("self_assessment", s.SelfAssessment)
+}
+
+func validateDebriefText(field, text string) error {
+	if utf8.RuneCountInString(text) > config.MaxDebriefLen {
+		return config.ErrInvalidDebrief.WithDetail(
+			fmt.Sprintf("%s is longer than %d characters", field, config.MaxDebriefLen))
+	}
+	return nil
+}
+
+func DebriefToResponse(d entity.Debrief, mission entity.Mission) response.Debrief {
+	outcomes := make(map[uint]entity.DebriefTarget, len(d.Targets))
+	for _, t := range d.Targets {
+		outcomes[t.TargetID] = t
+	}
+
+	targets := make([]response.DebriefTarget, 0, len(mission.Targets))
+	for _, t := range mission.Targets {
+		targets = append(targets, response.DebriefTarget{
+			TargetID: t.ID,
+			Name:     t.Name,
+			Country:  t.Country,
+			Outcome:  outcomes[t.ID].Outcome,
+			Notes:    outcomes[t.ID].Notes,
+		})
+	}
+
+	return response.Debrief{
+		MissionID:      d.MissionID,
+		CatID:          mission.CatID,
+		CatName:        mission.Cat.Name,
+		Priority:       mission.Priority,
+		Summary:        d.Summary,
+		Rating:         d.Rating,
+		SelfAssessment: d.SelfAssessment,
+		Targets:        targets,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
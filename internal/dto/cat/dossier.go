@@ -0,0 +1,202 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/country"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidateDossier normalizes the country and aliases.
+func ValidateDossier(d *request.Dossier) error {
+	code, err := normalizeCountry(d.Country)
+	if err != nil {
+		return err
+	}
+	d.Country = code
+	d.Name = strings.TrimSpace(d.Name)
+
+	d.Aliases, err = normalizeAliases(d.Aliases)
+	if err != nil {
+		return err
+	}
+	return validateAttributes(d.Attributes)
+}
+
+func DossierToEntity(d request.Dossier) entity.Dossier {
+	return entity.Dossier{
+		Name:       d.Name,
+		Country:    d.Country,
+		Aliases:    aliasesToEntity(d.Aliases),
+		Attributes: attributesToEntity(d.Attributes),
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// ValidateUpdateDossier normalizes the country and aliases.
+func ValidateUpdateDossier(d *request.UpdateDossier) error {
+	if d.Name != nil {
+		name := strings.TrimSpace(*d.Name)
+		if name == "" {
+			return config.ErrInvalidDossier.WithDetail("name can't be empty")
+		}
+		d.Name = &name
+	}
+	if d.Country != nil {
+		code, err := normalizeCountry(*d.Country)
+		if err != nil {
+			return err
+		}
+		d.Country = &code
+	}
+
+	var err error
+	if d.Aliases, err = normalizeAliases(d.Aliases); err != nil {
+		return err
+	}
+	return validateAttributes(d.Attributes)
+}
+
+// UpdateDossierToEntity applies the update to the dossier.
+func UpdateDossierToEntity(d request.UpdateDossier, dossier entity.Dossier) entity.Dossier {
+	if d.Name != nil {
+		dossier.Name = *d.Name
+	}
+	if d.Country != nil {
+		dossier.Country = *d.Country
+	}
+	// nil lists are kept by the repo
+	dossier.Aliases = aliasesToEntity(d.Aliases)
+	dossier.Attributes = attributesToEntity(d.Attributes)
+	return dossier
+}
+
+func normalizeAliases(aliases []string) ([]string, error) {
+	if aliases == nil {
+		return nil, nil
+	}
+
+	res := make([]string, 0, len(aliases))
+	seen := make(map[string]bool)
+	for i, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return nil, config.ErrInvalidDossier.WithDetail(fmt.Sprintf("aliases[%d]: alias can't be empty", i))
+		}
+		if seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		res = append(res, alias)
+	}
+	return res, nil
+}
+
+func validateAttributes(attributes map[string]string) error {
+	for k := range attributes {
+		if strings.TrimSpace(k) == "" {
+			return config.ErrInvalidDossier.WithDetail("attribute key can't be empty")
+		}
+	}
+	return nil
+}
+
+func aliasesToEntity(aliases []string) []entity.DossierAlias {
+	if aliases == nil {
+		return nil
+	}
+	res := make([]entity.DossierAlias, 0, len(aliases))
+	for _, a := range aliases {
+		res = append(res, entity.DossierAlias{Alias: a})
+	}
+	return res
+}
+
+func attributesToEntity(attributes map[string]string) []entity.DossierAttribute {
+	if attributes == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]entity.DossierAttribute, 0, len(attributes))
+	for _, k := range keys {
+		res = append(res, entity.DossierAttribute{Key: k, Value: attributes[k]})
+	}
+	return res
+}
+
+func DossierLinkToResponse(l *entity.DossierLink) *response.DossierLink {
+	if l == nil {
+		return nil
+	}
+	return &response.DossierLink{
+		DossierID:  l.DossierID,
+		Name:       l.Name,
+		Similarity: l.Similarity,
+		Created:    l.Created,
+	}
+}
+
+func DossierToResponse(d entity.Dossier) response.Dossier {
+	res := response.Dossier{
+		ID:          d.ID,
+		Name:        d.Name,
+		Country:     d.Country,
+		CountryName: country.Name(d.Country),
+		Aliases:     make([]string, 0, len(d.Aliases)),
+		Attributes:  make(map[string]string, len(d.Attributes)),
+
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+	for _, a := range d.Aliases {
+		res.Aliases = append(res.Aliases, a.Alias)
+	}
+	for _, a := range d.Attributes {
+		res.Attributes[a.Key] = a.Value
+	}
+	return res
+}
+
+func DossiersToResponse(dossiers []entity.Dossier) []response.Dossier {
+	res := make([]response.Dossier, 0, len(dossiers))
+	for _, d := range dossiers {
+		res = append(res, DossierToResponse(d))
+	}
+	return res
+}
+
+// DossierDetailToResponse lists the targets in their order, with their missions.
+func DossierDetailToResponse(d entity.Dossier, targets []entity.Target, missions []entity.Mission) response.DossierDetail {
+	byID := make(map[uint]entity.Mission, len(missions))
+	for _, m := range missions {
+		byID[m.ID] = m
+	}
+
+	res := response.DossierDetail{
+		Dossier: DossierToResponse(d),
+		Targets: make([]response.DossierTarget, 0, len(targets)),
+	}
+	for _, t := range targets {
+		m := byID[t.MissionID]
+		res.Targets = append(res.Targets, response.DossierTarget{
+			MissionID:        t.MissionID,
+			MissionCompleted: m.IsCompleted,
+			MissionDeletedAt: m.DeletedAt,
+			CatID:            m.CatID,
+			Target:           TargetToResponse(t),
+		})
+	}
+	return res
+}
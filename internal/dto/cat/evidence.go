@@ -0,0 +1,23 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"time"
+)
+
+func EvidenceToResponse(e entity.Evidence, url string, expiresAt time.Time) response.Evidence {
+	return response.Evidence{
+		ID:           e.ID,
+		MissionID:    e.MissionID,
+		TargetID:     e.TargetID,
+		Filename:     e.Filename,
+		ContentType:  e.ContentType,
+		Size:         e.Size,
+		SHA256:       e.SHA256,
+		UploadedBy:   response.Author{Role: e.UploadedByRole, CatID: e.UploadedByCatID},
+		CreatedAt:    e.CreatedAt,
+		DownloadURL:  url,
+		URLExpiresAt: expiresAt,
+	}
+}
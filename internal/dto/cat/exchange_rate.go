@@ -0,0 +1,32 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"backend/pkg/money"
+	"strings"
+)
+
+func ExchangeRateToEntity(r request.ExchangeRate, base, quote string) (entity.ExchangeRate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	for _, cur := range []string{base, quote} {
+		if !money.IsCurrency(cur) {
+			return entity.ExchangeRate{}, config.ErrInvalidCurrency.WithDetail(cur)
+		}
+	}
+	if base == quote {
+		return entity.ExchangeRate{}, config.ErrInvalidExchangeRate.WithDetail("base and quote currencies are the same")
+	}
+
+	rate, err := money.ParseRate(r.Rate)
+	if err != nil {
+		return entity.ExchangeRate{}, config.ErrInvalidExchangeRate.WithDetail(r.Rate)
+	}
+
+	return entity.ExchangeRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          rate.FloatString(10),
+	}, nil
+}
@@ -0,0 +1,161 @@
+package cat
+
+import (
+	"backend/config"
+	request "backend/pkg/api/request/cat"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportModeAtomic     = "atomic"
+	ImportModeBestEffort = "best_effort"
+)
+
+type (
+	// ImportRow is a single parsed row of a cat import. Err is set
+	// when the row could not be parsed or failed validation.
+	ImportRow struct {
+		Row int
+		Cat request.Cat
+		Err error
+	}
+
+	ImportRows []ImportRow
+)
+
+// catCSVColumns are the required columns, an optional "currency"
+// column sets the salary currency.
+var catCSVColumns = []string{"name", "years_experience", "breed", "salary"}
+
+// DecodeCatsCSV parses a CSV import with a header row. Columns are matched
+// by name, so their order is free. Row numbers start at 1 after the header.
+func DecodeCatsCSV(r io.Reader, maxRows int) (ImportRows, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range catCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header is missing column %q", name)
+		}
+	}
+	reader.FieldsPerRecord = len(header)
+
+	rows := make(ImportRows, 0)
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) == maxRows {
+			return nil, config.ErrImportTooManyRows.WithDetail(fmt.Sprintf("max %d rows", maxRows))
+		}
+
+		row := ImportRow{Row: n}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("read csv: %w", err)
+			}
+			row.Err = config.BadRequest("invalid row", parseErr.Err)
+			rows = append(rows, row)
+			continue
+		}
+
+		if row.Cat, err = catFromRecord(record, columns); err != nil {
+			row.Err = config.BadRequest("invalid row", err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// DecodeCatsNDJSON parses an import with one JSON cat object per line.
+// Empty lines are skipped but still counted.
+func DecodeCatsNDJSON(r io.Reader, maxRows int) (ImportRows, error) {
+	scanner := bufio.NewScanner(r)
+	rows := make(ImportRows, 0)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, config.ErrImportTooManyRows.WithDetail(fmt.Sprintf("max %d rows", maxRows))
+		}
+
+		row := ImportRow{Row: n}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row.Cat); err != nil {
+			row.Err = config.BadRequest("invalid json", err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ndjson: %w", err)
+	}
+
+	return rows, nil
+}
+
+func catFromRecord(record []string, columns map[string]int) (request.Cat, error) {
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	cat := request.Cat{
+		Name:  field("name"),
+		Breed: field("breed"),
+	}
+
+	if v := field("years_experience"); v != "" {
+		experience, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return cat, fmt.Errorf("invalid years_experience: %v", err)
+		}
+		cat.YearsExperience = uint8(experience)
+	}
+
+	if v := field("salary"); v != "" {
+		salary, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || salary < 0 {
+			return cat, fmt.Errorf("invalid salary: %q", v)
+		}
+		cat.Salary.Amount = salary
+	}
+	if _, ok := columns["currency"]; ok {
+		cat.Salary.Currency = strings.ToUpper(field("currency"))
+	}
+
+	return cat, nil
+}
+
+// Valid returns rows without errors.
+func (r ImportRows) Valid() ImportRows {
+	res := make(ImportRows, 0, len(r))
+	for _, row := range r {
+		if row.Err == nil {
+			res = append(res, row)
+		}
+	}
+	return res
+}
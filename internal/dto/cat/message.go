@@ -0,0 +1,97 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateMessage checks the message limits and fills in missing content types.
+func ValidateMessage(m *request.Message) error {
+	m.Body = strings.TrimSpace(m.Body)
+	if m.Body == "" && len(m.Attachments) == 0 {
+		return config.ErrInvalidMessage.WithDetail("body or attachments are required")
+	}
+	if utf8.RuneCountInString(m.Body) > config.MaxMessageLen {
+		return config.ErrInvalidMessage.WithDetail("body is longer than " + strconv.Itoa(config.MaxMessageLen) + " characters")
+	}
+	if len(m.Attachments) > config.MaxMessageAttachments {
+		return config.ErrInvalidMessage.WithDetail("at most " + strconv.Itoa(config.MaxMessageAttachments) + " attachments")
+	}
+
+	for i := range m.Attachments {
+		a := &m.Attachments[i]
+		if a.Filename != path.Base(a.Filename) || strings.ContainsAny(a.Filename, `\/`) ||
+			a.Filename == "." || a.Filename == ".." || len(a.Filename) > 255 {
+			return config.ErrInvalidMessage.WithDetail("attachment filename should be a plain file name")
+		}
+		if len(a.Data) == 0 {
+			return config.ErrInvalidMessage.WithDetail(a.Filename + " is empty")
+		}
+		if len(a.Data) > config.MaxAttachmentSize {
+			return config.ErrAttachmentTooLarge.WithDetail(a.Filename)
+		}
+
+		if a.ContentType == "" {
+			a.ContentType = http.DetectContentType(a.Data)
+		}
+		if _, _, err := mime.ParseMediaType(a.ContentType); err != nil {
+			return config.ErrInvalidMessage.WithDetail("invalid content type of " + a.Filename)
+		}
+	}
+	return nil
+}
+
+func MessageToEntity(m request.Message, missionID uint) entity.Message {
+	message := entity.Message{
+		MissionID: missionID,
+		Body:      m.Body,
+	}
+	for _, a := range m.Attachments {
+		message.Attachments = append(message.Attachments, entity.MessageAttachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        len(a.Data),
+			Data:        a.Data,
+		})
+	}
+	return message
+}
+
+func MessageToResponse(m entity.Message) response.Message {
+	attachments := make([]response.MessageAttachment, 0, len(m.Attachments))
+	for _, a := range m.Attachments {
+		attachments = append(attachments, response.MessageAttachment{
+			ID:          a.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+		})
+	}
+
+	return response.Message{
+		ID:              m.ID,
+		MissionID:       m.MissionID,
+		Author:          response.Author{Role: m.AuthorRole, CatID: m.AuthorCatID},
+		Body:            m.Body,
+		Attachments:     attachments,
+		ReadByCatAt:     m.ReadByCatAt,
+		ReadByHandlerAt: m.ReadByHandlerAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+func MessagesToResponse(messages []entity.Message) []response.Message {
+	res := make([]response.Message, 0, len(messages))
+	for _, m := range messages {
+		res = append(res, MessageToResponse(m))
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateMessage(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	attachments := func(n int) []request.Attachment {
+		res := make([]request.Attachment, n)
+		for i := range res {
+			res[i] = request.Attachment{Filename: "a.txt", Data: []byte("a")}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name            string
+		message         request.Message
+		wantErr         error
+		wantBody        string
+		wantContentType string // of the first attachment
+	}{
+		{name: "body", message: request.Message{Body: "  on my way \n"}, wantBody: "on my way"},
+		{name: "empty", message: request.Message{Body: " "}, wantErr: config.ErrInvalidMessage},
+		{name: "too long", message: request.Message{Body: strings.Repeat("я", config.MaxMessageLen+1)}, wantErr: config.ErrInvalidMessage},
+		{name: "longest", message: request.Message{Body: strings.Repeat("я", config.MaxMessageLen)}, wantBody: strings.Repeat("я", config.MaxMessageLen)},
+		{
+			name:            "attachment only, content type sniffed",
+			message:         request.Message{Attachments: []request.Attachment{{Filename: "map.png", Data: png}}},
+			wantContentType: "image/png",
+		},
+		{
+			name:            "content type kept",
+			message:         request.Message{Attachments: []request.Attachment{{Filename: "notes", ContentType: "text/markdown", Data: []byte("# a")}}},
+			wantContentType: "text/markdown",
+		},
+		{name: "too many attachments", message: request.Message{Attachments: attachments(config.MaxMessageAttachments + 1)}, wantErr: config.ErrInvalidMessage},
+		{name: "path in filename", message: request.Message{Attachments: []request.Attachment{{Filename: "../etc/passwd", Data: []byte("a")}}}, wantErr: config.ErrInvalidMessage},
+		{name: "windows path in filename", message: request.Message{Attachments: []request.Attachment{{Filename: `c:\boot.ini`, Data: []byte("a")}}}, wantErr: config.ErrInvalidMessage},
+		{name: "dot filename", message: request.Message{Attachments: []request.Attachment{{Filename: "..", Data: []byte("a")}}}, wantErr: config.ErrInvalidMessage},
+		{name: "empty attachment", message: request.Message{Attachments: []request.Attachment{{Filename: "a.txt"}}}, wantErr: config.ErrInvalidMessage},
+		{
+			name:    "attachment too large",
+			message: request.Message{Attachments: []request.Attachment{{Filename: "a.bin", Data: make([]byte, config.MaxAttachmentSize+1)}}},
+			wantErr: config.ErrAttachmentTooLarge,
+		},
+		{
+			name:    "invalid content type",
+			message: request.Message{Attachments: []request.Attachment{{Filename: "a.txt", ContentType: "text/", Data: []byte("a")}}},
+			wantErr: config.ErrInvalidMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMessage(&tt.message)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.message.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", tt.message.Body, tt.wantBody)
+			}
+			if tt.wantContentType != "" && tt.message.Attachments[0].ContentType != tt.wantContentType {
+				t.Errorf("content type = %q, want %q", tt.message.Attachments[0].ContentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestMessageAttachments(t *testing.T) {
+	message := MessageToEntity(request.Message{
+		Body:        "photos",
+		Attachments: []request.Attachment{{Filename: "a.jpg", ContentType: "image/jpeg", Data: []byte("jpeg")}},
+	}, 7)
+
+	want := entity.Message{
+		MissionID:   7,
+		Body:        "photos",
+		Attachments: []entity.MessageAttachment{{Filename: "a.jpg", ContentType: "image/jpeg", Size: 4, Data: []byte("jpeg")}},
+	}
+	if !reflect.DeepEqual(message, want) {
+		t.Fatalf("message = %+v, want %+v", message, want)
+	}
+
+	// the response lists attachments without their data
+	message.Attachments[0].ID = 3
+	res := MessageToResponse(message)
+	if len(res.Attachments) != 1 || res.Attachments[0].ID != 3 || res.Attachments[0].Size != 4 {
+		t.Errorf("attachments = %+v", res.Attachments)
+	}
+}
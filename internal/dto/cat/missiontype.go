@@ -0,0 +1,101 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ValidateMissionType checks the target limits and normalizes the countries.
+func ValidateMissionType(t *request.MissionType) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return config.ErrInvalidMissionType.WithDetail("name can't be empty")
+	}
+	if t.MinTargets < 1 || t.MaxTargets < t.MinTargets || t.MaxTargets > config.MaxTypeTargets {
+		return config.ErrInvalidMissionType.WithDetail(
+			fmt.Sprintf("targets should be 1 <= min_targets <= max_targets <= %d", config.MaxTypeTargets))
+	}
+
+	countries := make([]string, 0, len(t.Countries))
+	for _, name := range t.Countries {
+		code, err := normalizeCountry(name)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(countries, code) {
+			countries = append(countries, code)
+		}
+	}
+	slices.Sort(countries)
+	t.Countries = countries
+	return nil
+}
+
+func MissionTypeToEntity(t request.MissionType, typeID uint) entity.MissionType {
+	countries := make([]entity.MissionTypeCountry, 0, len(t.Countries))
+	for _, c := range t.Countries {
+		countries = append(countries, entity.MissionTypeCountry{MissionTypeID: typeID, Country: c})
+	}
+
+	return entity.MissionType{
+		ID:          typeID,
+		Name:        t.Name,
+		Description: t.Description,
+		MinTargets:  t.MinTargets,
+		MaxTargets:  t.MaxTargets,
+		Countries:   countries,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func validateTargetsLen(missionType response.MissionType, targetsLen int) error {
+	if targetsLen < missionType.MinTargets || targetsLen > missionType.MaxTargets {
+		return config.ErrMissionHasInvalidTargetsLen.WithDetail(
+			fmt.Sprintf("%s mission targets amount should be in range (%d|%d): %d",
+				missionType.Name, missionType.MinTargets, missionType.MaxTargets, targetsLen))
+	}
+	return nil
+}
+
+func validateCountry(missionType response.MissionType, code string) error {
+	if !missionType.AllowsCountry(code) {
+		return config.ErrCountryNotAllowed.WithDetail(
+			fmt.Sprintf("%s for %s missions, allowed: %s",
+				code, missionType.Name, strings.Join(missionType.Countries, ", ")))
+	}
+	return nil
+}
+
+func MissionTypeToResponse(t entity.MissionType) response.MissionType {
+	countries := make([]string, 0, len(t.Countries))
+	for _, c := range t.Countries {
+		countries = append(countries, c.Country)
+	}
+
+	return response.MissionType{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		MinTargets:  t.MinTargets,
+		MaxTargets:  t.MaxTargets,
+		Countries:   countries,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func MissionTypesToResponse(types []entity.MissionType) []response.MissionType {
+	res := make([]response.MissionType, 0, len(types))
+	for _, t := range types {
+		res = append(res, MissionTypeToResponse(t))
+	}
+	return res
+}
@@ -0,0 +1,108 @@
+package cat
+
+import (
+	"backend/config"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateMissionType(t *testing.T) {
+	tests := []struct {
+		name        string
+		missionType request.MissionType
+		want        []string // normalized countries, if valid
+		wantErr     error
+	}{
+		{
+			name:        "any country",
+			missionType: request.MissionType{Name: "standard", MinTargets: 1, MaxTargets: 3},
+			want:        []string{},
+		},
+		{
+			name:        "countries normalized, sorted and deduplicated",
+			missionType: request.MissionType{Name: "border", MinTargets: 2, MaxTargets: 2, Countries: []string{"Ukraine", "pl", "UA"}},
+			want:        []string{"PL", "UA"},
+		},
+		{
+			name:        "largest type",
+			missionType: request.MissionType{Name: "sweep", MinTargets: 1, MaxTargets: config.MaxTypeTargets},
+			want:        []string{},
+		},
+		{name: "no name", missionType: request.MissionType{Name: " ", MinTargets: 1, MaxTargets: 3}, wantErr: config.ErrInvalidMissionType},
+		{name: "no targets", missionType: request.MissionType{Name: "empty", MinTargets: 0, MaxTargets: 3}, wantErr: config.ErrInvalidMissionType},
+		{name: "max below min", missionType: request.MissionType{Name: "odd", MinTargets: 3, MaxTargets: 2}, wantErr: config.ErrInvalidMissionType},
+		{
+			name:        "above the upper bound",
+			missionType: request.MissionType{Name: "sweep", MinTargets: 1, MaxTargets: config.MaxTypeTargets + 1},
+			wantErr:     config.ErrInvalidMissionType,
+		},
+		{
+			name:        "unknown country",
+			missionType: request.MissionType{Name: "lost", MinTargets: 1, MaxTargets: 1, Countries: []string{"Atlantis"}},
+			wantErr:     config.ErrUnknownCountry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMissionType(&tt.missionType)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(tt.missionType.Countries, tt.want) {
+				t.Errorf("countries = %v, want %v", tt.missionType.Countries, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissionTypeLimits(t *testing.T) {
+	border := response.MissionType{Name: "border", MinTargets: 2, MaxTargets: 3, Countries: []string{"PL", "UA"}}
+	anywhere := response.MissionType{Name: "standard", MinTargets: 1, MaxTargets: 3}
+	targets := func(countries ...string) request.Targets {
+		res := make(request.Targets, 0, len(countries))
+		for _, c := range countries {
+			res = append(res, request.Target{Name: "target", Country: c})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		missionType response.MissionType
+		targets     request.Targets
+		wantErr     error
+	}{
+		{name: "fewest targets", missionType: border, targets: targets("UA", "PL")},
+		{name: "most targets", missionType: border, targets: targets("UA", "PL", "UA")},
+		{name: "too few targets", missionType: border, targets: targets("UA"), wantErr: config.ErrMissionHasInvalidTargetsLen},
+		{name: "too many targets", missionType: border, targets: targets("UA", "PL", "UA", "PL"), wantErr: config.ErrMissionHasInvalidTargetsLen},
+		{name: "country not allowed", missionType: border, targets: targets("UA", "FR"), wantErr: config.ErrCountryNotAllowed},
+		{name: "any country", missionType: anywhere, targets: targets("FR", "JP")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := request.Mission{Targets: tt.targets}
+			err := ValidateMissionTargetsLen(mission, tt.missionType)
+			if err == nil {
+				err = ValidateMissionCountries(mission, tt.missionType)
+			}
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("mission: err = %v, want %v", err, tt.wantErr)
+			}
+
+			// templates are held to the same limits
+			template := request.MissionTemplate{Name: "template"}
+			for _, target := range tt.targets {
+				template.Targets = append(template.Targets, request.TemplateTarget{Name: target.Name, Country: target.Country})
+			}
+			if err := ValidateTemplateTargets(template, tt.missionType); !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("template: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"backend/pkg/country"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// ValidateCatProfile checks the profile and normalizes codes and skill names in place.
+func ValidateCatProfile(p *request.CatProfile) error {
+	seen := make(map[string]bool)
+	for i, skill := range p.Skills {
+		name := strings.TrimSpace(skill.Name)
+		if name == "" {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("skills[%d]: name is required", i))
+		}
+		if skill.Proficiency < config.MinSkillProficiency || skill.Proficiency > config.MaxSkillProficiency {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("skills[%d]: proficiency should be in range (%d|%d)",
+				i, config.MinSkillProficiency, config.MaxSkillProficiency))
+		}
+		if seen[strings.ToLower(name)] {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("skills[%d]: duplicate skill %q", i, name))
+		}
+		seen[strings.ToLower(name)] = true
+		p.Skills[i].Name = name
+	}
+
+	seen = make(map[string]bool)
+	for i, lang := range p.Languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if !govalidator.IsISO693Alpha2(lang) {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("languages[%d]: invalid ISO 639-1 code %q", i, p.Languages[i]))
+		}
+		if seen[lang] {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("languages[%d]: duplicate language %q", i, lang))
+		}
+		seen[lang] = true
+		p.Languages[i] = lang
+	}
+
+	if p.ClearanceLevel != nil && *p.ClearanceLevel > config.MaxClearanceLevel {
+		return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("clearance_level should be in range (0|%d)", config.MaxClearanceLevel))
+	}
+
+	if p.HomeCountry != nil && *p.HomeCountry != "" {
+		code, ok := country.Normalize(*p.HomeCountry)
+		if !ok {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("home_country: unknown country %q", *p.HomeCountry))
+		}
+		p.HomeCountry = &code
+	}
+
+	leaves, err := profileLeaves(*p, 0)
+	if err != nil {
+		return err
+	}
+	sort.Slice(leaves, func(i, j int) bool { return leaves[i].StartsOn.Before(leaves[j].StartsOn) })
+	for i := 1; i < len(leaves); i++ {
+		if !leaves[i].StartsOn.After(leaves[i-1].EndsOn) {
+			return config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("availability: leave periods %s and %s overlap",
+				leaves[i-1].StartsOn.Format(config.DateLayout), leaves[i].StartsOn.Format(config.DateLayout)))
+		}
+	}
+	return nil
+}
+
+// CatProfileToEntity applies the update to the cat. Lists omitted from the body are left as is.
+func CatProfileToEntity(p request.CatProfile, cat entity.Cat) entity.Cat {
+	if p.ClearanceLevel != nil {
+		cat.ClearanceLevel = *p.ClearanceLevel
+	}
+	if p.HomeCountry != nil {
+		cat.HomeCountry = *p.HomeCountry
+	}
+
+	if p.Skills != nil {
+		cat.Skills = make([]entity.CatSkill, 0, len(p.Skills))
+		for _, s := range p.Skills {
+			cat.Skills = append(cat.Skills, entity.CatSkill{CatID: cat.ID, Name: s.Name, Proficiency: s.Proficiency})
+		}
+	}
+	if p.Languages != nil {
+		cat.Languages = make([]entity.CatLanguage, 0, len(p.Languages))
+		for _, lang := range p.Languages {
+			cat.Languages = append(cat.Languages, entity.CatLanguage{CatID: cat.ID, Language: lang})
+		}
+	}
+	if p.Availability != nil {
+		// dates were checked by Validate
+		cat.Leaves, _ = profileLeaves(p, cat.ID)
+	}
+	return cat
+}
+
+func profileLeaves(p request.CatProfile, catID uint) ([]entity.CatLeave, error) {
+	leaves := make([]entity.CatLeave, 0, len(p.Availability))
+	for i, period := range p.Availability {
+		from, err := time.Parse(config.DateLayout, period.From)
+		if err != nil {
+			return nil, config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("availability[%d]: invalid from date", i))
+		}
+		to, err := time.Parse(config.DateLayout, period.To)
+		if err != nil {
+			return nil, config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("availability[%d]: invalid to date", i))
+		}
+		if to.Before(from) {
+			return nil, config.ErrInvalidCatProfile.WithDetail(fmt.Sprintf("availability[%d]: to is before from", i))
+		}
+		leaves = append(leaves, entity.CatLeave{CatID: catID, StartsOn: from, EndsOn: to, Reason: period.Reason})
+	}
+	return leaves, nil
+}
@@ -0,0 +1,167 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateCatProfile(t *testing.T) {
+	str := func(s string) *string { return &s }
+	level := func(l uint8) *uint8 { return &l }
+
+	tests := []struct {
+		name       string
+		profile    request.CatProfile
+		want       request.CatProfile // normalized profile, if valid
+		wantDetail string             // part of the error detail, empty if valid
+	}{
+		{
+			name:    "empty",
+			profile: request.CatProfile{},
+			want:    request.CatProfile{},
+		},
+		{
+			name: "normalized",
+			profile: request.CatProfile{
+				Skills:         []request.Skill{{Name: " Stealth ", Proficiency: 5}},
+				Languages:      []string{" EN", "uk"},
+				ClearanceLevel: level(5),
+				HomeCountry:    str("Ukraine"),
+			},
+			want: request.CatProfile{
+				Skills:         []request.Skill{{Name: "Stealth", Proficiency: 5}},
+				Languages:      []string{"en", "uk"},
+				ClearanceLevel: level(5),
+				HomeCountry:    str("UA"),
+			},
+		},
+		{
+			name:    "home country cleared",
+			profile: request.CatProfile{HomeCountry: str("")},
+			want:    request.CatProfile{HomeCountry: str("")},
+		},
+		{
+			name:       "skill without name",
+			profile:    request.CatProfile{Skills: []request.Skill{{Name: " ", Proficiency: 1}}},
+			wantDetail: "skills[0]: name is required",
+		},
+		{
+			name:       "skill proficiency out of range",
+			profile:    request.CatProfile{Skills: []request.Skill{{Name: "stealth", Proficiency: 6}}},
+			wantDetail: "skills[0]: proficiency",
+		},
+		{
+			name: "duplicate skills differ in case only",
+			profile: request.CatProfile{Skills: []request.Skill{
+				{Name: "Stealth", Proficiency: 3},
+				{Name: "stealth ", Proficiency: 4},
+			}},
+			wantDetail: `skills[1]: duplicate skill "stealth"`,
+		},
+		{
+			name:       "language is not ISO 639-1",
+			profile:    request.CatProfile{Languages: []string{"en", "eng"}},
+			wantDetail: `languages[1]: invalid ISO 639-1 code "eng"`,
+		},
+		{
+			name:       "duplicate languages",
+			profile:    request.CatProfile{Languages: []string{"en", "EN"}},
+			wantDetail: `languages[1]: duplicate language "en"`,
+		},
+		{
+			name:       "clearance level out of range",
+			profile:    request.CatProfile{ClearanceLevel: level(6)},
+			wantDetail: "clearance_level",
+		},
+		{
+			name:       "unknown home country",
+			profile:    request.CatProfile{HomeCountry: str("Atlantis")},
+			wantDetail: `home_country: unknown country "Atlantis"`,
+		},
+		{
+			name:       "invalid leave date",
+			profile:    request.CatProfile{Availability: []request.LeavePeriod{{From: "2026-03-01", To: "March 5"}}},
+			wantDetail: "availability[0]: invalid to date",
+		},
+		{
+			name:       "leave ends before it starts",
+			profile:    request.CatProfile{Availability: []request.LeavePeriod{{From: "2026-03-05", To: "2026-03-01"}}},
+			wantDetail: "availability[0]: to is before from",
+		},
+		{
+			name: "overlapping leaves in any order",
+			profile: request.CatProfile{Availability: []request.LeavePeriod{
+				{From: "2026-03-10", To: "2026-03-20"},
+				{From: "2026-03-01", To: "2026-03-10"},
+			}},
+			wantDetail: "leave periods 2026-03-01 and 2026-03-10 overlap",
+		},
+		{
+			name: "adjacent leaves",
+			profile: request.CatProfile{Availability: []request.LeavePeriod{
+				{From: "2026-03-01", To: "2026-03-09"},
+				{From: "2026-03-10", To: "2026-03-10"},
+			}},
+			want: request.CatProfile{Availability: []request.LeavePeriod{
+				{From: "2026-03-01", To: "2026-03-09"},
+				{From: "2026-03-10", To: "2026-03-10"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCatProfile(&tt.profile)
+			if tt.wantDetail == "" {
+				if err != nil {
+					t.Fatalf("err = %v", err)
+				}
+				if !reflect.DeepEqual(tt.profile, tt.want) {
+					t.Errorf("profile = %+v, want %+v", tt.profile, tt.want)
+				}
+				return
+			}
+
+			var e *config.Error
+			if !errors.Is(err, config.ErrInvalidCatProfile) || !errors.As(err, &e) || !strings.Contains(e.Detail, tt.wantDetail) {
+				t.Errorf("err = %v, want detail %q", err, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCatProfileToEntity(t *testing.T) {
+	level := uint8(2)
+	cat := entity.Cat{
+		ID:             7,
+		ClearanceLevel: 1,
+		HomeCountry:    "FR",
+		Skills:         []entity.CatSkill{{CatID: 7, Name: "stealth", Proficiency: 3}},
+		Languages:      []entity.CatLanguage{{CatID: 7, Language: "fr"}},
+	}
+
+	got := CatProfileToEntity(request.CatProfile{
+		ClearanceLevel: &level,
+		Languages:      []string{},
+		Availability:   []request.LeavePeriod{{From: "2026-03-01", To: "2026-03-05", Reason: "vet"}},
+	}, cat)
+
+	want := cat
+	want.ClearanceLevel = 2
+	want.Languages = []entity.CatLanguage{}
+	want.Leaves = []entity.CatLeave{{
+		CatID:    7,
+		StartsOn: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Reason:   "vet",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cat = %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,200 @@
+// Package cat validates the API request bodies and maps them to entities,
+// and entities to the API responses.
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/country"
+	"backend/pkg/money"
+	"time"
+)
+
+func CatToEntity(c request.Cat) entity.Cat {
+	return entity.Cat{
+		Name:            c.Name,
+		YearsExperience: c.YearsExperience,
+		Breed:           c.Breed,
+		Salary:          withDefaultCurrency(c.Salary),
+		Status:          config.CatStatusActive,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func UpdateCatToEntity(c request.UpdateCat, catID uint) entity.Cat {
+	return entity.Cat{
+		ID:     catID,
+		Salary: withDefaultCurrency(c.Salary),
+	}
+}
+
+// ValidateCat checks the salary, its currency is checked by the struct validator.
+func ValidateCat(c request.Cat) error {
+	if c.Salary.Amount < 0 {
+		return config.ErrNegativeSalary
+	}
+	return nil
+}
+
+// ValidateUpdateCat checks the salary, its currency is checked by the struct validator.
+func ValidateUpdateCat(c request.UpdateCat) error {
+	if c.Salary.Amount < 0 {
+		return config.ErrNegativeSalary
+	}
+	return nil
+}
+
+// SalaryChangeToEntity returns the salary history record of the update, its
+// previous salary is set once it is placed in the history.
+// The effective date can be backdated, but not set in the future.
+func SalaryChangeToEntity(c request.UpdateCat, catID uint) (entity.SalaryChange, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	effectiveFrom := today
+	if c.EffectiveFrom != "" {
+		date, err := time.Parse(config.DateLayout, c.EffectiveFrom)
+		if err != nil {
+			return entity.SalaryChange{}, config.BadRequest("invalid effective_from", err)
+		}
+		if date.After(today) {
+			return entity.SalaryChange{}, config.ErrSalaryDateInFuture
+		}
+		effectiveFrom = date
+	}
+
+	return entity.SalaryChange{
+		CatID:         catID,
+		Salary:        withDefaultCurrency(c.Salary),
+		EffectiveFrom: effectiveFrom,
+		Reason:        c.Reason,
+
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func MissionToEntity(m request.Mission) entity.Mission {
+	return entity.Mission{
+		CatID:    m.CatID,
+		TypeID:   m.TypeID,
+		Priority: m.Priority,
+		StartsAt: m.StartsAt,
+		DueAt:    m.DueAt,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TargetToEntity(t request.Target, missionID uint) entity.Target {
+	return entity.Target{
+		MissionID: missionID,
+		DossierID: t.DossierID,
+		Name:      t.Name,
+		Country:   t.Country,
+		Notes:     t.Notes,
+		Priority:  t.Priority,
+		StartsAt:  t.StartsAt,
+		DueAt:     t.DueAt,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TargetsToEntity(t request.Targets, missionID uint) []entity.Target {
+	res := make([]entity.Target, 0, len(t))
+	for _, v := range t {
+		res = append(res, TargetToEntity(v, missionID))
+	}
+	return res
+}
+
+func UpdateTargetToEntity(t request.UpdateTarget, targetID, missionID uint) entity.Target {
+	return entity.Target{
+		ID:        targetID,
+		MissionID: missionID,
+		Notes:     t.Notes,
+	}
+}
+
+// ValidateMissionTargetsLen checks the number of targets against the mission type.
+func ValidateMissionTargetsLen(m request.Mission, missionType response.MissionType) error {
+	return validateTargetsLen(missionType, len(m.Targets))
+}
+
+// ValidateMissionCountries checks the mission type allows the target countries,
+// normalized by ValidateMission.
+func ValidateMissionCountries(m request.Mission, missionType response.MissionType) error {
+	for _, t := range m.Targets {
+		if err := ValidateTargetCountry(t, missionType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateMission checks the schedule and normalizes the country of every target.
+func ValidateMission(m *request.Mission) error {
+	priority, err := validateSchedule(m.Priority, m.StartsAt, m.DueAt)
+	if err != nil {
+		return err
+	}
+	m.Priority = priority
+
+	for i := range m.Targets {
+		if err := ValidateTarget(&m.Targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTarget checks the schedule and normalizes the country to ISO 3166-1 alpha-2.
+func ValidateTarget(t *request.Target) error {
+	priority, err := validateSchedule(t.Priority, t.StartsAt, t.DueAt)
+	if err != nil {
+		return err
+	}
+	t.Priority = priority
+
+	code, err := normalizeCountry(t.Country)
+	if err != nil {
+		return err
+	}
+	t.Country = code
+	return nil
+}
+
+// ValidateTargetCountry checks the mission type allows the target country, normalized by Validate.
+func ValidateTargetCountry(t request.Target, missionType response.MissionType) error {
+	return validateCountry(missionType, t.Country)
+}
+
+func normalizeCountry(name string) (string, error) {
+	code, ok := country.Normalize(name)
+	if !ok {
+		return "", config.ErrUnknownCountry.WithDetail(name)
+	}
+	return code, nil
+}
+
+func withDefaultCurrency(m money.Money) money.Money {
+	if m.Currency == "" {
+		m.Currency = config.DefaultCurrency
+	}
+	return m
+}
+
+// ValidateCatStatus checks the status is known, transitions are checked by the service.
+func ValidateCatStatus(c request.CatStatus) error {
+	switch c.Status {
+	case config.CatStatusActive, config.CatStatusOnLeave, config.CatStatusRetired, config.CatStatusKIA:
+		return nil
+	default:
+		return config.ErrInvalidCatStatus.WithDetail(c.Status)
+	}
+}
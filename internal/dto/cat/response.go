@@ -0,0 +1,153 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/country"
+	"time"
+)
+
+func CatToResponse(c entity.Cat) response.Cat {
+	return response.Cat{
+		ID:              c.ID,
+		Name:            c.Name,
+		YearsExperience: c.YearsExperience,
+		Breed:           c.Breed,
+		Salary:          c.Salary,
+		ClearanceLevel:  c.ClearanceLevel,
+		HomeCountry:     c.HomeCountry,
+		Status:          c.Status,
+		StatusReason:    c.StatusReason,
+
+		Skills:       skillsToResponse(c.Skills),
+		Languages:    languagesToResponse(c.Languages),
+		Availability: leavesToResponse(c.Leaves),
+
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		DeletedAt: c.DeletedAt,
+	}
+}
+
+func CatStatusChangesToResponse(changes []entity.CatStatusChange) []response.CatStatusChange {
+	res := make([]response.CatStatusChange, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, response.CatStatusChange{
+			ID:     c.ID,
+			CatID:  c.CatID,
+			From:   c.From,
+			To:     c.To,
+			Reason: c.Reason,
+
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return res
+}
+
+func skillsToResponse(skills []entity.CatSkill) []response.Skill {
+	if skills == nil {
+		return nil
+	}
+	res := make([]response.Skill, 0, len(skills))
+	for _, s := range skills {
+		res = append(res, response.Skill{Name: s.Name, Proficiency: s.Proficiency})
+	}
+	return res
+}
+
+func languagesToResponse(languages []entity.CatLanguage) []string {
+	if languages == nil {
+		return nil
+	}
+	res := make([]string, 0, len(languages))
+	for _, l := range languages {
+		res = append(res, l.Language)
+	}
+	return res
+}
+
+func leavesToResponse(leaves []entity.CatLeave) []response.LeavePeriod {
+	if leaves == nil {
+		return nil
+	}
+	res := make([]response.LeavePeriod, 0, len(leaves))
+	for _, l := range leaves {
+		res = append(res, response.LeavePeriod{
+			From:   l.StartsOn.Format(config.DateLayout),
+			To:     l.EndsOn.Format(config.DateLayout),
+			Reason: l.Reason,
+		})
+	}
+	return res
+}
+
+func CatsToResponse(cats []entity.Cat) []response.Cat {
+	res := make([]response.Cat, 0, len(cats))
+	for _, c := range cats {
+		res = append(res, CatToResponse(c))
+	}
+	return res
+}
+
+func MissionToResponse(m entity.Mission) response.Mission {
+	return response.Mission{
+		ID:          m.ID,
+		CatID:       m.CatID,
+		TypeID:      m.TypeID,
+		IsCompleted: m.IsCompleted,
+
+		Priority:  m.Priority,
+		StartsAt:  m.StartsAt,
+		DueAt:     m.DueAt,
+		IsOverdue: !m.IsCompleted && m.DueAt != nil && m.DueAt.Before(time.Now()),
+		OverdueAt: m.OverdueAt,
+
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		DeletedAt: m.DeletedAt,
+
+		Cat:     CatToResponse(m.Cat),
+		Targets: TargetsToResponse(m.Targets),
+	}
+}
+
+func MissionsToResponse(missions []entity.Mission) []response.Mission {
+	res := make([]response.Mission, 0, len(missions))
+	for _, m := range missions {
+		res = append(res, MissionToResponse(m))
+	}
+	return res
+}
+
+func TargetToResponse(t entity.Target) response.Target {
+	return response.Target{
+		ID:          t.ID,
+		MissionID:   t.MissionID,
+		DossierID:   t.DossierID,
+		Name:        t.Name,
+		Country:     t.Country,
+		CountryName: country.Name(t.Country),
+		Notes:       t.Notes,
+		IsCompleted: t.IsCompleted,
+
+		Priority: t.Priority,
+		StartsAt: t.StartsAt,
+		DueAt:    t.DueAt,
+
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+		DeletedAt: t.DeletedAt,
+
+		DossierLink: DossierLinkToResponse(t.DossierLink),
+	}
+}
+
+func TargetsToResponse(targets []entity.Target) []response.Target {
+	res := make([]response.Target, 0, len(targets))
+	for _, t := range targets {
+		res = append(res, TargetToResponse(t))
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+)
+
+func SalaryChangeToResponse(c entity.SalaryChange) response.SalaryChange {
+	return response.SalaryChange{
+		ID:             c.ID,
+		CatID:          c.CatID,
+		Salary:         c.Salary,
+		PreviousSalary: c.PreviousSalary,
+		EffectiveFrom:  c.EffectiveFrom.Format(config.DateLayout),
+		Reason:         c.Reason,
+
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func SalaryChangesToResponse(changes []entity.SalaryChange) []response.SalaryChange {
+	res := make([]response.SalaryChange, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, SalaryChangeToResponse(c))
+	}
+	return res
+}
+
+func ExchangeRateToResponse(r entity.ExchangeRate) response.ExchangeRate {
+	return response.ExchangeRate{
+		BaseCurrency:  r.BaseCurrency,
+		QuoteCurrency: r.QuoteCurrency,
+		Rate:          r.Rate,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func ExchangeRatesToResponse(rates []entity.ExchangeRate) []response.ExchangeRate {
+	res := make([]response.ExchangeRate, 0, len(rates))
+	for _, r := range rates {
+		res = append(res, ExchangeRateToResponse(r))
+	}
+	return res
+}
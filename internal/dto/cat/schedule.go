@@ -0,0 +1,122 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidateSchedule defaults the priority and checks the start is not after the due date.
+func ValidateSchedule(s *request.Schedule) error {
+	priority, err := validateSchedule(s.Priority, s.StartsAt, s.DueAt)
+	if err != nil {
+		return err
+	}
+	s.Priority = priority
+	return nil
+}
+
+func ScheduleToMission(s request.Schedule, missionID uint) entity.Mission {
+	return entity.Mission{
+		ID:       missionID,
+		Priority: s.Priority,
+		StartsAt: s.StartsAt,
+		DueAt:    s.DueAt,
+	}
+}
+
+func ScheduleToTarget(s request.Schedule, targetID, missionID uint) entity.Target {
+	return entity.Target{
+		ID:        targetID,
+		MissionID: missionID,
+		Priority:  s.Priority,
+		StartsAt:  s.StartsAt,
+		DueAt:     s.DueAt,
+	}
+}
+
+func MissionFilterToEntity(f request.MissionFilter) (entity.MissionFilter, error) {
+	var (
+		filter entity.MissionFilter
+		err    error
+	)
+
+	if f.Priority != "" {
+		for _, p := range strings.Split(f.Priority, ",") {
+			p = strings.ToLower(strings.TrimSpace(p))
+			if !slices.Contains(config.Priorities, p) {
+				return filter, config.ErrInvalidPriority.WithDetail(p)
+			}
+			filter.Priorities = append(filter.Priorities, p)
+		}
+	}
+
+	if filter.DueAfter, err = parseFilterTime("due_after", f.DueAfter); err != nil {
+		return filter, err
+	}
+	if filter.DueBefore, err = parseFilterTime("due_before", f.DueBefore); err != nil {
+		return filter, err
+	}
+	if filter.Overdue, err = parseFilterBool("overdue", f.Overdue); err != nil {
+		return filter, err
+	}
+	if filter.Completed, err = parseFilterBool("completed", f.Completed); err != nil {
+		return filter, err
+	}
+
+	filter.Sort = strings.TrimPrefix(f.Sort, "-")
+	filter.Desc = strings.HasPrefix(f.Sort, "-")
+	switch filter.Sort {
+	case "":
+		filter.Sort, filter.Desc = config.MissionSortCreatedAt, true
+	case config.MissionSortCreatedAt, config.MissionSortPriority,
+		config.MissionSortStartsAt, config.MissionSortDueAt:
+	default:
+		return filter, config.ErrInvalidMissionFilter.WithDetail("unknown sort field " + filter.Sort)
+	}
+
+	return filter, nil
+}
+
+// validateSchedule returns the priority, normal if empty.
+func validateSchedule(priority string, startsAt, dueAt *time.Time) (string, error) {
+	priority = strings.ToLower(strings.TrimSpace(priority))
+	if priority == "" {
+		priority = config.PriorityNormal
+	}
+	if !slices.Contains(config.Priorities, priority) {
+		return "", config.ErrInvalidPriority.WithDetail(priority)
+	}
+	if startsAt != nil && dueAt != nil && startsAt.After(*dueAt) {
+		return "", config.ErrInvalidSchedule.WithDetail("starts_at is after due_at")
+	}
+	return priority, nil
+}
+
+func parseFilterTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(config.DateLayout, value); err != nil {
+			return nil, config.ErrInvalidMissionFilter.WithDetail("invalid " + name)
+		}
+	}
+	return &t, nil
+}
+
+func parseFilterBool(name, value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, config.ErrInvalidMissionFilter.WithDetail("invalid " + name)
+	}
+	return &b, nil
+}
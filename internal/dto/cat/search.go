@@ -0,0 +1,21 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+)
+
+func SearchResultsToResponse(hits []entity.SearchHit) []response.SearchResult {
+	res := make([]response.SearchResult, 0, len(hits))
+	for _, h := range hits {
+		res = append(res, response.SearchResult{
+			Type:      h.Type,
+			ID:        h.ID,
+			MissionID: h.MissionID,
+			Title:     h.Title,
+			Snippet:   h.Snippet,
+			Rank:      h.Rank,
+		})
+	}
+	return res
+}
@@ -0,0 +1,183 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidateTemplate normalizes the priorities and countries, the targets are checked
+// against the mission type with ValidateTemplateTargets.
+func ValidateTemplate(t *request.MissionTemplate) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return config.ErrInvalidTemplate.WithDetail("name can't be empty")
+	}
+
+	priority, err := validateSchedule(t.Priority, nil, nil)
+	if err != nil {
+		return err
+	}
+	t.Priority = priority
+
+	if len(t.Targets) == 0 {
+		return config.ErrInvalidTemplate.WithDetail("targets are required")
+	}
+	for i := range t.Targets {
+		target := &t.Targets[i]
+		target.Name = strings.TrimSpace(target.Name)
+		if target.Name == "" {
+			return config.ErrInvalidTemplate.WithDetail(fmt.Sprintf("target %d: name can't be empty", i+1))
+		}
+		if target.Country, err = normalizeCountry(target.Country); err != nil {
+			return err
+		}
+		if target.Priority, err = validateSchedule(target.Priority, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTemplateTargets checks the number of targets and their countries against
+// the mission type, missions created from the template are validated again.
+func ValidateTemplateTargets(t request.MissionTemplate, missionType response.MissionType) error {
+	if err := validateTargetsLen(missionType, len(t.Targets)); err != nil {
+		return err
+	}
+	for _, target := range t.Targets {
+		if err := validateCountry(missionType, target.Country); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TemplateToEntity(t request.MissionTemplate, templateID uint) entity.MissionTemplate {
+	targets := make([]entity.TemplateTarget, 0, len(t.Targets))
+	for _, target := range t.Targets {
+		targets = append(targets, entity.TemplateTarget{
+			TemplateID: templateID,
+			Name:       target.Name,
+			Country:    target.Country,
+			Notes:      target.Notes,
+			Priority:   target.Priority,
+		})
+	}
+
+	return entity.MissionTemplate{
+		ID:          templateID,
+		Name:        t.Name,
+		Description: t.Description,
+		TypeID:      t.TypeID,
+		Priority:    t.Priority,
+		Targets:     targets,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// TemplateToMission returns the mission to create from the template, it is
+// validated like any other created mission.
+func TemplateToMission(f request.MissionFromTemplate, template entity.MissionTemplate) (request.Mission, error) {
+	if len(f.Targets) > len(template.Targets) {
+		return request.Mission{}, config.ErrInvalidTemplate.WithDetail(
+			fmt.Sprintf("template has %d targets, %d given", len(template.Targets), len(f.Targets)))
+	}
+
+	mission := request.Mission{
+		CatID:    f.CatID,
+		TypeID:   template.TypeID,
+		Priority: f.Priority,
+		StartsAt: f.StartsAt,
+		DueAt:    f.DueAt,
+		Targets:  make(request.Targets, 0, len(template.Targets)),
+	}
+	if mission.Priority == "" {
+		mission.Priority = template.Priority
+	}
+
+	for i, t := range template.Targets {
+		target := request.Target{
+			Name:     t.Name,
+			Country:  t.Country,
+			Notes:    t.Notes,
+			Priority: t.Priority,
+		}
+		if i < len(f.Targets) {
+			fill := f.Targets[i]
+			if fill.Name != "" {
+				target.Name = fill.Name
+			}
+			if fill.Notes != "" {
+				target.Notes = fill.Notes
+			}
+			target.DossierID = fill.DossierID
+		}
+		mission.Targets = append(mission.Targets, target)
+	}
+	return mission, nil
+}
+
+// CloneToMission returns the mission to create as a copy of source, without its
+// cat, dates and completion state. Targets keep their dossier links.
+func CloneToMission(c request.CloneMission, source entity.Mission) request.Mission {
+	mission := request.Mission{
+		CatID:    c.CatID,
+		TypeID:   source.TypeID,
+		Priority: c.Priority,
+		StartsAt: c.StartsAt,
+		DueAt:    c.DueAt,
+		Targets:  make(request.Targets, 0, len(source.Targets)),
+	}
+	if mission.Priority == "" {
+		mission.Priority = source.Priority
+	}
+
+	for _, t := range source.Targets {
+		mission.Targets = append(mission.Targets, request.Target{
+			Name:      t.Name,
+			Country:   t.Country,
+			Notes:     t.Notes,
+			Priority:  t.Priority,
+			DossierID: t.DossierID,
+		})
+	}
+	return mission
+}
+
+func TemplateToResponse(t entity.MissionTemplate) response.MissionTemplate {
+	targets := make([]response.TemplateTarget, 0, len(t.Targets))
+	for _, target := range t.Targets {
+		targets = append(targets, response.TemplateTarget{
+			Name:     target.Name,
+			Country:  target.Country,
+			Notes:    target.Notes,
+			Priority: target.Priority,
+		})
+	}
+
+	return response.MissionTemplate{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		TypeID:      t.TypeID,
+		Priority:    t.Priority,
+		Targets:     targets,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func TemplatesToResponse(templates []entity.MissionTemplate) []response.MissionTemplate {
+	res := make([]response.MissionTemplate, 0, len(templates))
+	for _, t := range templates {
+		res = append(res, TemplateToResponse(t))
+	}
+	return res
+}
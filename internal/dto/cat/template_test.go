@@ -0,0 +1,139 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTemplateToMission(t *testing.T) {
+	catID, typeID, dossierID := uint(3), uint(2), uint(11)
+	template := entity.MissionTemplate{
+		ID:       1,
+		Name:     "extraction",
+		TypeID:   &typeID,
+		Priority: config.PriorityHigh,
+		Targets: []entity.TemplateTarget{
+			{TemplateID: 1, Name: "asset", Country: "UA", Notes: "pick up at dawn", Priority: config.PriorityCritical},
+			{TemplateID: 1, Name: "courier", Country: "PL", Notes: "watch only", Priority: config.PriorityNormal},
+		},
+	}
+	due := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		fill    request.MissionFromTemplate
+		want    request.Mission
+		wantErr error
+	}{
+		{
+			name: "template values",
+			fill: request.MissionFromTemplate{},
+			want: request.Mission{
+				TypeID:   &typeID,
+				Priority: config.PriorityHigh,
+				Targets: request.Targets{
+					{Name: "asset", Country: "UA", Notes: "pick up at dawn", Priority: config.PriorityCritical},
+					{Name: "courier", Country: "PL", Notes: "watch only", Priority: config.PriorityNormal},
+				},
+			},
+		},
+		{
+			name: "filled by position",
+			fill: request.MissionFromTemplate{
+				CatID:    &catID,
+				Priority: config.PriorityLow,
+				DueAt:    &due,
+				Targets: []request.TemplateFillTarget{
+					{Name: "Ivan", DossierID: &dossierID},
+					{Notes: "follow to the station"},
+				},
+			},
+			want: request.Mission{
+				CatID:    &catID,
+				TypeID:   &typeID,
+				Priority: config.PriorityLow,
+				DueAt:    &due,
+				Targets: request.Targets{
+					{Name: "Ivan", Country: "UA", Notes: "pick up at dawn", Priority: config.PriorityCritical, DossierID: &dossierID},
+					{Name: "courier", Country: "PL", Notes: "follow to the station", Priority: config.PriorityNormal},
+				},
+			},
+		},
+		{
+			name:    "more targets than the template",
+			fill:    request.MissionFromTemplate{Targets: make([]request.TemplateFillTarget, 3)},
+			wantErr: config.ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateToMission(tt.fill, template)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mission = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneToMission(t *testing.T) {
+	oldCatID, catID, typeID, dossierID := uint(3), uint(4), uint(2), uint(11)
+	started := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	source := entity.Mission{
+		ID:          5,
+		CatID:       &oldCatID,
+		TypeID:      &typeID,
+		IsCompleted: true,
+		Priority:    config.PriorityHigh,
+		StartsAt:    &started,
+		Targets: []entity.Target{
+			{ID: 8, MissionID: 5, DossierID: &dossierID, Name: "Ivan", Country: "UA", Notes: "met twice", IsCompleted: true, Priority: config.PriorityCritical},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		clone request.CloneMission
+		want  request.Mission
+	}{
+		{
+			name:  "cat and dates are not copied",
+			clone: request.CloneMission{},
+			want: request.Mission{
+				TypeID:   &typeID,
+				Priority: config.PriorityHigh,
+				Targets: request.Targets{
+					{Name: "Ivan", Country: "UA", Notes: "met twice", Priority: config.PriorityCritical, DossierID: &dossierID},
+				},
+			},
+		},
+		{
+			name:  "new cat and priority",
+			clone: request.CloneMission{CatID: &catID, Priority: config.PriorityLow},
+			want: request.Mission{
+				CatID:    &catID,
+				TypeID:   &typeID,
+				Priority: config.PriorityLow,
+				Targets: request.Targets{
+					{Name: "Ivan", Country: "UA", Notes: "met twice", Priority: config.PriorityCritical, DossierID: &dossierID},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CloneToMission(tt.clone, source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mission = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
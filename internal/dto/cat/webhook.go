@@ -0,0 +1,95 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ValidateWebhook checks the URL is absolute http(s) and the events are known,
+// dropping duplicates.
+func ValidateWebhook(w *request.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return config.ErrInvalidWebhook.WithDetail("url should be an absolute http(s) URL")
+	}
+
+	if len(w.Events) == 0 {
+		return config.ErrInvalidWebhook.WithDetail("at least one event is required")
+	}
+	events := make([]string, 0, len(w.Events))
+	for _, event := range w.Events {
+		if !slices.Contains(config.EventTypes, event) {
+			return config.ErrUnknownEventType.WithDetail(event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	w.Events = events
+
+	if w.Secret != "" && len(w.Secret) < config.MinWebhookSecretLen {
+		return config.ErrInvalidWebhook.WithDetail("secret is too short")
+	}
+	return nil
+}
+
+func WebhookToEntity(w request.Webhook) entity.Webhook {
+	return entity.Webhook{
+		URL:    w.URL,
+		Events: strings.Join(w.Events, ","),
+		Secret: w.Secret,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func WebhookToResponse(w entity.Webhook) response.Webhook {
+	return response.Webhook{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    strings.Split(w.Events, ","),
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func WebhooksToResponse(webhooks []entity.Webhook) []response.Webhook {
+	res := make([]response.Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		res = append(res, WebhookToResponse(w))
+	}
+	return res
+}
+
+func WebhookDeliveryToResponse(d entity.WebhookDelivery) response.WebhookDelivery {
+	return response.WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func WebhookDeliveriesToResponse(deliveries []entity.WebhookDelivery) []response.WebhookDelivery {
+	res := make([]response.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, WebhookDeliveryToResponse(d))
+	}
+	return res
+}
@@ -1,6 +1,7 @@
 package cat
 
 import (
+	"backend/pkg/money"
 	"time"
 )
 
@@ -16,7 +17,37 @@ type (
 		Name            string
 		YearsExperience uint8
 		Breed           string
-		Salary          uint64 // Salary in cents (e.g. 100 = 1$ in cents)
+		Salary          money.Money `gorm:"embedded;embeddedPrefix:salary_"` // Monthly, in minor units (e.g. 100 = 1$ in cents)
+		ClearanceLevel  uint8
+		HomeCountry     string
+		Status          string `gorm:"default:active;index"`
+		StatusReason    string
+
+		Skills    []CatSkill    `gorm:"-"`
+		Languages []CatLanguage `gorm:"-"`
+		Leaves    []CatLeave    `gorm:"-"`
+	}
+
+	CatSkill struct {
+		ID          uint
+		CatID       uint   `gorm:"uniqueIndex:idx_cat_skills_name"`
+		Name        string `gorm:"uniqueIndex:idx_cat_skills_name"`
+		Proficiency uint8
+	}
+
+	CatLanguage struct {
+		ID       uint
+		CatID    uint   `gorm:"uniqueIndex:idx_cat_languages_code"`
+		Language string `gorm:"size:2;uniqueIndex:idx_cat_languages_code"` // ISO 639-1
+	}
+
+	// CatLeave is a period the cat is unavailable, both dates inclusive.
+	CatLeave struct {
+		ID       uint
+		CatID    uint      `gorm:"index"`
+		StartsOn time.Time `gorm:"type:date"`
+		EndsOn   time.Time `gorm:"type:date"`
+		Reason   string
 	}
 
 	Mission struct {
@@ -26,7 +57,12 @@ type (
 		DeletedAt *time.Time `gorm:"index"`
 
 		CatID       *uint `gorm:"index"`
+		TypeID      *uint `gorm:"index"` // set to the default type when missing
 		IsCompleted bool
+		Priority    string `gorm:"default:normal;index"`
+		StartsAt    *time.Time
+		DueAt       *time.Time `gorm:"index"`
+		OverdueAt   *time.Time // set once the overdue notification is sent
 
 		Cat     Cat      `gorm:"-"`
 		Targets []Target `gorm:"-"`
@@ -38,11 +74,323 @@ type (
 		UpdatedAt time.Time
 		DeletedAt *time.Time `gorm:"index"`
 
-		MissionID   uint `gorm:"index"`
+		MissionID   uint  `gorm:"index"`
+		DossierID   *uint `gorm:"index"`
 		Name        string
 		Country     string
 		Notes       string
+		NotesKeyID  string // master key of the stored notes, empty if stored in plaintext
 		IsCompleted bool
+		Priority    string `gorm:"default:normal"`
+		StartsAt    *time.Time
+		DueAt       *time.Time
+
+		DossierLink *DossierLink `gorm:"-"`
+	}
+
+	// MissionType sets how many targets its missions have and where,
+	// any country is allowed without Countries.
+	MissionType struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		Name        string `gorm:"uniqueIndex"`
+		Description string
+		MinTargets  int
+		MaxTargets  int
+
+		Countries []MissionTypeCountry `gorm:"foreignKey:MissionTypeID"`
+	}
+
+	MissionTypeCountry struct {
+		ID uint
+
+		MissionTypeID uint   `gorm:"uniqueIndex:idx_mission_type_country"`
+		Country       string `gorm:"uniqueIndex:idx_mission_type_country"`
+	}
+
+	// MissionTemplate is a reusable mission shape, its targets are skeletons
+	// copied into the missions created from it.
+	MissionTemplate struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		Name        string `gorm:"uniqueIndex"`
+		Description string
+		TypeID      *uint  `gorm:"index"` // the default type if nil
+		Priority    string `gorm:"default:normal"`
+
+		Targets []TemplateTarget `gorm:"foreignKey:TemplateID"`
+	}
+
+	// TemplateTarget Name is a placeholder, usually replaced when the template is used.
+	TemplateTarget struct {
+		ID uint
+
+		TemplateID uint `gorm:"index"`
+		Name       string
+		Country    string
+		Notes      string
+		Priority   string `gorm:"default:normal"`
+	}
+
+	// MissionFilter selects and orders missions, not stored.
+	MissionFilter struct {
+		Priorities []string
+		DueAfter   *time.Time
+		DueBefore  *time.Time
+		Overdue    *bool
+		Completed  *bool
+		CatID      *uint  // missions of the cat only, set for cat callers
+		Sort       string // one of config.MissionSortFields
+		Desc       bool
+	}
+
+	// CatStatusChange records a cat status transition.
+	CatStatusChange struct {
+		ID        uint
+		CreatedAt time.Time
+
+		CatID  uint `gorm:"index"`
+		From   string
+		To     string
+		Reason string
+	}
+
+	// Dossier is a person pursued across missions, mission targets link to it.
+	Dossier struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		DeletedAt *time.Time `gorm:"index"`
+
+		Name    string
+		Country string // ISO 3166-1 alpha-2
+
+		Aliases    []DossierAlias     `gorm:"-"`
+		Attributes []DossierAttribute `gorm:"-"`
+	}
+
+	// DossierLink reports the dossier a target created without one was
+	// linked to, not stored.
+	DossierLink struct {
+		DossierID  uint
+		Name       string
+		Similarity float64 // of the names, for an existing dossier
+		Created    bool
+	}
+
+	DossierAlias struct {
+		ID        uint
+		DossierID uint `gorm:"index"`
+		Alias     string
+	}
+
+	DossierAttribute struct {
+		ID        uint
+		DossierID uint   `gorm:"uniqueIndex:idx_dossier_attributes_key"`
+		Key       string `gorm:"uniqueIndex:idx_dossier_attributes_key"`
+		Value     string
+	}
+
+	// SearchHit is a full-text search result, not stored.
+	SearchHit struct {
+		Type      string
+		ID        uint
+		MissionID *uint // targets only
+		Title     string
+		Snippet   string
+		Rank      float64
+	}
+
+	// SalaryChange records a salary set for the cat from the effective date on.
+	SalaryChange struct {
+		ID        uint
+		CreatedAt time.Time
+
+		CatID          uint        `gorm:"index"`
+		Salary         money.Money `gorm:"embedded;embeddedPrefix:salary_"`
+		PreviousSalary money.Money `gorm:"embedded;embeddedPrefix:previous_salary_"`
+		EffectiveFrom  time.Time   `gorm:"index"`
+		Reason         string
+	}
+
+	// ExchangeRate is the price of one unit of the base currency in the quote
+	// currency. Stored as numeric, as money must never go through floats.
+	ExchangeRate struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		BaseCurrency  string `gorm:"size:3;uniqueIndex:idx_exchange_rates_pair"`
+		QuoteCurrency string `gorm:"size:3;uniqueIndex:idx_exchange_rates_pair"`
+		Rate          string `gorm:"type:numeric(24,10)"`
+	}
+
+	// BonusRule is a configurable mission performance bonus. Depending on the kind,
+	// either Amount is paid or Multiplier (numeric) scales the other bonuses.
+	BonusRule struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		DeletedAt *time.Time `gorm:"index"`
+
+		Kind          string
+		Amount        money.Money `gorm:"embedded;embeddedPrefix:amount_"`
+		Multiplier    string      `gorm:"type:numeric(12,4)"`
+		Country       string      // country_multiplier only
+		MinExperience uint8       // experience_multiplier only
+		Description   string
+	}
+
+	// BonusEntry is a bonus ledger record paid to the cat for a completed mission,
+	// a rule pays each mission, or each of its targets, once.
+	BonusEntry struct {
+		ID        uint
+		CreatedAt time.Time
+
+		CatID       uint        `gorm:"index"`
+		MissionID   uint        `gorm:"index;uniqueIndex:idx_bonus_entry,option:NULLS NOT DISTINCT"`
+		TargetID    *uint       `gorm:"uniqueIndex:idx_bonus_entry,option:NULLS NOT DISTINCT"` // per_target bonuses only
+		RuleID      uint        `gorm:"uniqueIndex:idx_bonus_entry,option:NULLS NOT DISTINCT"`
+		Amount      money.Money `gorm:"embedded;embeddedPrefix:amount_"`
+		Description string
+	}
+
+	// Event is a domain event published to subscribers, not stored.
+	Event struct {
+		ID            string // unique, for subscribers to deduplicate
+		Type          string
+		AggregateType string // e.g. "mission"
+		AggregateID   uint
+		Payload       []byte // JSON
+		OccurredAt    time.Time
+	}
+
+	// OutboxEvent is an event written in the transaction of its change,
+	// published to the sink by the outbox relay. TxID is the writing
+	// transaction, events are relayed in its order rather than by ID, which
+	// is taken before the transaction commits.
+	OutboxEvent struct {
+		ID        uint
+		CreatedAt time.Time
+
+		TxID          uint64 `gorm:"type:xid8;not null;default:pg_current_xact_id()"`
+		EventID       string `gorm:"uniqueIndex"`
+		Type          string
+		AggregateType string `gorm:"index:idx_outbox_aggregate"`
+		AggregateID   uint   `gorm:"index:idx_outbox_aggregate"`
+		Payload       string `gorm:"type:jsonb"`
+		OccurredAt    time.Time
+		PublishedAt   *time.Time `gorm:"index"`
+		DeadAt        *time.Time // given up on after the max attempts
+		Attempts      int
+		NextAttemptAt *time.Time // retry backoff, or the lease of a claimed event
+		LastError     string
+	}
+
+	// Webhook subscribes a URL to event types, payloads are signed with the secret.
+	Webhook struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		DeletedAt *time.Time `gorm:"index"`
+
+		URL    string
+		Events string // comma separated event types
+		Secret string
+	}
+
+	// WebhookDelivery is an event sent, or to be sent, to a webhook.
+	WebhookDelivery struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		// an event has one first delivery per webhook, replays are redeliveries
+		WebhookID      uint   `gorm:"index;uniqueIndex:idx_webhook_event,where:redelivery_of IS NULL"`
+		EventID        string `gorm:"index;uniqueIndex:idx_webhook_event,where:redelivery_of IS NULL"`
+		Event          string
+		Payload        string
+		Status         string `gorm:"index"`
+		Attempts       int
+		NextAttemptAt  *time.Time `gorm:"index"`
+		LastAttemptAt  *time.Time
+		ResponseStatus int
+		LastError      string
+		DeliveredAt    *time.Time
+		RedeliveryOf   *uint // delivery replayed by this one
+	}
+
+	// Evidence is a file proving work on a target, its content is in blob
+	// storage under Key. Files are never deduplicated, SHA256 is informative.
+	Evidence struct {
+		ID        uint
+		CreatedAt time.Time
+
+		MissionID       uint   `gorm:"index"`
+		TargetID        uint   `gorm:"index"`
+		Key             string `gorm:"uniqueIndex"`
+		Filename        string
+		ContentType     string
+		Size            int64
+		SHA256          string `gorm:"column:sha256"`
+		UploadedByRole  string
+		UploadedByCatID *uint
+	}
+
+	// Message of a mission thread between handlers and the assigned cat.
+	// Handlers are not told apart, so receipts are kept per side.
+	// Debrief is the report on a completed mission: the handler writes the
+	// summary, rating and target outcomes, the assigned cat its self-assessment.
+	Debrief struct {
+		ID        uint
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		MissionID      uint `gorm:"uniqueIndex"`
+		Summary        string
+		Rating         *int
+		SelfAssessment string
+
+		Targets []DebriefTarget `gorm:"foreignKey:DebriefID"`
+	}
+
+	DebriefTarget struct {
+		ID uint
+
+		DebriefID uint `gorm:"uniqueIndex:idx_debrief_target"`
+		TargetID  uint `gorm:"uniqueIndex:idx_debrief_target"`
+		Outcome   string
+		Notes     string
+	}
+
+	Message struct {
+		ID        uint
+		CreatedAt time.Time
+
+		MissionID       uint `gorm:"index"`
+		AuthorRole      string
+		AuthorCatID     *uint
+		Body            string
+		ReadByCatAt     *time.Time
+		ReadByHandlerAt *time.Time
+
+		Attachments []MessageAttachment `gorm:"foreignKey:MessageID"`
+	}
+
+	// MessageAttachment is stored with its message, Data is not loaded with threads.
+	MessageAttachment struct {
+		ID        uint
+		CreatedAt time.Time
+
+		MessageID   uint `gorm:"index"`
+		Filename    string
+		ContentType string
+		Size        int
+		Data        []byte
 	}
 )
 
@@ -50,6 +398,22 @@ func (Cat) TableName() string {
 	return "cats"
 }
 
+func (CatSkill) TableName() string {
+	return "cat_skills"
+}
+
+func (CatLanguage) TableName() string {
+	return "cat_languages"
+}
+
+func (CatLeave) TableName() string {
+	return "cat_leaves"
+}
+
+func (CatStatusChange) TableName() string {
+	return "cat_status_changes"
+}
+
 func (Mission) TableName() string {
 	return "missions" // Could also be cat_missions, depending on the needed architecture
 }
@@ -57,3 +421,79 @@ func (Mission) TableName() string {
 func (Target) TableName() string {
 	return "targets" // Could also be (cat_)mission_targets, depending on the needed architecture
 }
+
+func (MissionType) TableName() string {
+	return "mission_types"
+}
+
+func (MissionTypeCountry) TableName() string {
+	return "mission_type_countries"
+}
+
+func (MissionTemplate) TableName() string {
+	return "mission_templates"
+}
+
+func (TemplateTarget) TableName() string {
+	return "template_targets"
+}
+
+func (Dossier) TableName() string {
+	return "dossiers"
+}
+
+func (DossierAlias) TableName() string {
+	return "dossier_aliases"
+}
+
+func (DossierAttribute) TableName() string {
+	return "dossier_attributes"
+}
+
+func (SalaryChange) TableName() string {
+	return "salary_changes"
+}
+
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+func (BonusRule) TableName() string {
+	return "bonus_rules"
+}
+
+func (BonusEntry) TableName() string {
+	return "bonus_ledger"
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (Evidence) TableName() string {
+	return "target_evidence"
+}
+
+func (Debrief) TableName() string {
+	return "debriefs"
+}
+
+func (DebriefTarget) TableName() string {
+	return "debrief_targets"
+}
+
+func (Message) TableName() string {
+	return "messages"
+}
+
+func (MessageAttachment) TableName() string {
+	return "message_attachments"
+}
@@ -0,0 +1,84 @@
+package bonus
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Calculate returns the ledger entries the mission pays to its cat: every
+// per_target rule once per completed target and every per_mission rule once.
+// Country multipliers scale per_target bonuses of targets in that country,
+// experience multipliers scale all bonuses of cats experienced enough.
+func Calculate(rules []entity.BonusRule, mission entity.Mission) ([]entity.BonusEntry, error) {
+	entries := make([]entity.BonusEntry, 0)
+	if mission.CatID == nil || mission.Cat.ID == 0 {
+		return entries, nil
+	}
+
+	experience := big.NewRat(1, 1)
+	countries := make(map[string]*big.Rat)
+	var perTarget, perMission []entity.BonusRule
+
+	for _, rule := range rules {
+		switch rule.Kind {
+		case config.BonusPerTarget:
+			perTarget = append(perTarget, rule)
+		case config.BonusPerMission:
+			perMission = append(perMission, rule)
+		case config.BonusCountryMultiplier:
+			m, err := money.ParseRate(rule.Multiplier)
+			if err != nil {
+				return nil, fmt.Errorf("bonus rule %d: %w", rule.ID, err)
+			}
+			country := strings.ToLower(rule.Country)
+			if countries[country] == nil {
+				countries[country] = big.NewRat(1, 1)
+			}
+			countries[country].Mul(countries[country], m)
+		case config.BonusExperienceMultiplier:
+			if mission.Cat.YearsExperience < rule.MinExperience {
+				continue
+			}
+			m, err := money.ParseRate(rule.Multiplier)
+			if err != nil {
+				return nil, fmt.Errorf("bonus rule %d: %w", rule.ID, err)
+			}
+			experience.Mul(experience, m)
+		}
+	}
+
+	newEntry := func(rule entity.BonusRule, targetID *uint, factor *big.Rat, desc string) entity.BonusEntry {
+		return entity.BonusEntry{
+			CatID:       mission.Cat.ID,
+			MissionID:   mission.ID,
+			TargetID:    targetID,
+			RuleID:      rule.ID,
+			Amount:      rule.Amount.Mul(factor),
+			Description: desc,
+		}
+	}
+
+	for _, target := range mission.Targets {
+		if !target.IsCompleted {
+			continue
+		}
+		factor := new(big.Rat).Set(experience)
+		if m, ok := countries[strings.ToLower(target.Country)]; ok {
+			factor.Mul(factor, m)
+		}
+		for _, rule := range perTarget {
+			targetID := target.ID
+			desc := fmt.Sprintf("target %q completed", target.Name)
+			entries = append(entries, newEntry(rule, &targetID, factor, desc))
+		}
+	}
+
+	for _, rule := range perMission {
+		entries = append(entries, newEntry(rule, nil, experience, "mission completed"))
+	}
+	return entries, nil
+}
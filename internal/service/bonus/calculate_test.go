@@ -0,0 +1,125 @@
+package bonus
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"reflect"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	usd := func(amount int64) money.Money { return money.New(amount, "USD") }
+	ptr := func(id uint) *uint { return &id }
+
+	perTarget := entity.BonusRule{ID: 1, Kind: config.BonusPerTarget, Amount: usd(10000)}
+	perMission := entity.BonusRule{ID: 2, Kind: config.BonusPerMission, Amount: usd(50000)}
+	britain := entity.BonusRule{ID: 3, Kind: config.BonusCountryMultiplier, Country: "GB", Multiplier: "1.5"}
+	britainAgain := entity.BonusRule{ID: 4, Kind: config.BonusCountryMultiplier, Country: "gb", Multiplier: "2"}
+	veteran := entity.BonusRule{ID: 5, Kind: config.BonusExperienceMultiplier, MinExperience: 5, Multiplier: "1.1"}
+	elite := entity.BonusRule{ID: 6, Kind: config.BonusExperienceMultiplier, MinExperience: 10, Multiplier: "3"}
+
+	mission := entity.Mission{
+		ID:    7,
+		CatID: ptr(9),
+		Cat:   entity.Cat{ID: 9, YearsExperience: 6},
+		Targets: []entity.Target{
+			{ID: 10, Name: "Ivan", Country: "GB", IsCompleted: true},
+			{ID: 11, Name: "Olga", Country: "UA", IsCompleted: true},
+			{ID: 12, Name: "Petro", Country: "GB"},
+		},
+	}
+	unassigned := mission
+	unassigned.CatID, unassigned.Cat = nil, entity.Cat{}
+
+	entry := func(rule entity.BonusRule, targetID *uint, amount int64, desc string) entity.BonusEntry {
+		return entity.BonusEntry{CatID: 9, MissionID: 7, TargetID: targetID, RuleID: rule.ID, Amount: usd(amount), Description: desc}
+	}
+
+	tests := []struct {
+		name    string
+		rules   []entity.BonusRule
+		mission entity.Mission
+		want    []entity.BonusEntry
+		wantErr bool
+	}{
+		{
+			name:    "no cat",
+			rules:   []entity.BonusRule{perTarget, perMission},
+			mission: unassigned,
+			want:    []entity.BonusEntry{},
+		},
+		{
+			name:    "no rules",
+			mission: mission,
+			want:    []entity.BonusEntry{},
+		},
+		{
+			name:    "flat bonuses per completed target and mission",
+			rules:   []entity.BonusRule{perTarget, perMission},
+			mission: mission,
+			want: []entity.BonusEntry{
+				entry(perTarget, ptr(10), 10000, `target "Ivan" completed`),
+				entry(perTarget, ptr(11), 10000, `target "Olga" completed`),
+				entry(perMission, nil, 50000, "mission completed"),
+			},
+		},
+		{
+			name:    "country multiplier scales targets in the country only",
+			rules:   []entity.BonusRule{britain, perTarget, perMission},
+			mission: mission,
+			want: []entity.BonusEntry{
+				entry(perTarget, ptr(10), 15000, `target "Ivan" completed`),
+				entry(perTarget, ptr(11), 10000, `target "Olga" completed`),
+				entry(perMission, nil, 50000, "mission completed"),
+			},
+		},
+		{
+			name:    "multipliers of a country compound, case-insensitively",
+			rules:   []entity.BonusRule{perTarget, britain, britainAgain},
+			mission: mission,
+			want: []entity.BonusEntry{
+				entry(perTarget, ptr(10), 30000, `target "Ivan" completed`),
+				entry(perTarget, ptr(11), 10000, `target "Olga" completed`),
+			},
+		},
+		{
+			name:    "experience multiplier scales every bonus of experienced cats",
+			rules:   []entity.BonusRule{perTarget, perMission, britain, veteran, elite},
+			mission: mission,
+			want: []entity.BonusEntry{
+				entry(perTarget, ptr(10), 16500, `target "Ivan" completed`),
+				entry(perTarget, ptr(11), 11000, `target "Olga" completed`),
+				entry(perMission, nil, 55000, "mission completed"),
+			},
+		},
+		{
+			name: "invalid multiplier",
+			rules: []entity.BonusRule{perTarget, {
+				ID: 8, Kind: config.BonusCountryMultiplier, Country: "GB", Multiplier: "0",
+			}},
+			mission: mission,
+			wantErr: true,
+		},
+		{
+			name: "invalid multiplier of an unmet experience rule is skipped",
+			rules: []entity.BonusRule{perMission, {
+				ID: 8, Kind: config.BonusExperienceMultiplier, MinExperience: 20, Multiplier: "x",
+			}},
+			mission: mission,
+			want:    []entity.BonusEntry{entry(perMission, nil, 50000, "mission completed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.rules, tt.mission)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entries = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package bonus
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"context"
+	"fmt"
+	"sort"
+)
+
+func (s service) GetBonusRules(ctx context.Context) ([]response.BonusRule, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	rules, err := s.repo.GetBonusRules(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get bonus rules: %w", err))
+	}
+	return dto.BonusRulesToResponse(rules), nil
+}
+
+func (s service) CreateBonusRule(ctx context.Context, body request.BonusRule) (response.BonusRule, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.BonusRule{}, err
+	}
+	rule, err := s.repo.CreateBonusRule(ctx, dto.BonusRuleToEntity(body))
+	if err != nil {
+		return response.BonusRule{}, config.DBError(fmt.Errorf("create bonus rule: %w", err))
+	}
+	return dto.BonusRuleToResponse(rule), nil
+}
+
+func (s service) DeleteBonusRule(ctx context.Context, ruleID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteBonusRule(ctx, ruleID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("delete bonus rule: %w", err))
+	}
+	if !deleted {
+		return config.ErrBonusRuleNotFound
+	}
+	return nil
+}
+
+// PreviewBonus calculates the bonus the mission would pay with its current
+// targets and the current rules, without writing to the ledger.
+func (s service) PreviewBonus(ctx context.Context, missionID uint) (response.BonusPreview, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.BonusPreview{}, err
+	}
+	mission, err := s.missionRepo.GetMission(ctx, missionID)
+	if err != nil {
+		return response.BonusPreview{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return response.BonusPreview{}, config.ErrMissionNotFound
+	}
+
+	rules, err := s.repo.GetBonusRules(ctx)
+	if err != nil {
+		return response.BonusPreview{}, config.DBError(fmt.Errorf("get bonus rules: %w", err))
+	}
+
+	entries, err := Calculate(rules, mission)
+	if err != nil {
+		return response.BonusPreview{}, config.Wrap(config.CodeInternal, "calculate bonus", err)
+	}
+
+	totals := make(map[string]int64)
+	for _, e := range entries {
+		totals[e.Amount.Currency] += e.Amount.Amount
+	}
+
+	preview := response.BonusPreview{
+		MissionID: mission.ID,
+		CatID:     mission.CatID,
+		Entries:   dto.BonusEntriesToResponse(entries),
+		Totals:    make([]money.Money, 0, len(totals)),
+	}
+	for currency, amount := range totals {
+		preview.Totals = append(preview.Totals, money.New(amount, currency))
+	}
+	sort.Slice(preview.Totals, func(i, j int) bool {
+		return preview.Totals[i].Currency < preview.Totals[j].Currency
+	})
+	return preview, nil
+}
+
+func (s service) GetCatBonuses(ctx context.Context, catID uint) ([]response.BonusEntry, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	cat, err := s.catRepo.GetCatByID(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	if cat.ID == 0 {
+		return nil, config.ErrCatNotFound
+	}
+
+	entries, err := s.repo.GetBonusEntriesByCat(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get bonus entries: %w", err))
+	}
+	return dto.BonusEntriesToResponse(entries), nil
+}
@@ -0,0 +1,40 @@
+package bonus
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		GetBonusRules(ctx context.Context) ([]entity.BonusRule, error)
+		CreateBonusRule(ctx context.Context, rule entity.BonusRule) (entity.BonusRule, error)
+		DeleteBonusRule(ctx context.Context, ruleID uint) (bool, error)
+		GetBonusEntriesByCat(ctx context.Context, catID uint) ([]entity.BonusEntry, error)
+	}
+
+	missionRepo interface {
+		GetMission(ctx context.Context, missionID uint) (entity.Mission, error)
+	}
+
+	catRepo interface {
+		GetCatByID(ctx context.Context, catID uint) (entity.Cat, error)
+	}
+
+	service struct {
+		repo        repo
+		missionRepo missionRepo
+		catRepo     catRepo
+		l           *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	missionRepo missionRepo,
+	catRepo catRepo,
+	l *slog.Logger,
+) service {
+	return service{repo, missionRepo, catRepo, l}
+}
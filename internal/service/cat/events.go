@@ -0,0 +1,29 @@
+package cat
+
+import (
+	"backend/config"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	"backend/internal/service/outbox"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// recordCreated writes cat.created events to the outbox in tx,
+// they are published once the cats are committed.
+func (s service) recordCreated(ctx context.Context, tx *gorm.DB, cats ...entity.Cat) error {
+	events := make([]entity.Event, 0, len(cats))
+	for _, cat := range cats {
+		event, err := outbox.NewEvent(config.EventCatCreated, "cat", cat.ID, dto.CatToResponse(cat))
+		if err != nil {
+			return config.Wrap(config.CodeInternal, "build event", err)
+		}
+		events = append(events, event)
+	}
+	if err := s.outboxRepo.CreateEvents(ctx, tx, events...); err != nil {
+		return config.DBError(fmt.Errorf("record cat.created events: %w", err))
+	}
+	return nil
+}
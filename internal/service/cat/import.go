@@ -0,0 +1,120 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ImportCats saves valid rows of the import. Breeds are validated once per
+// distinct breed. In atomic mode nothing is saved if any row is invalid.
+func (s service) ImportCats(ctx context.Context, rows dto.ImportRows, mode string) (response.ImportReport, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.ImportReport{}, err
+	}
+	report := response.ImportReport{
+		Mode:   mode,
+		Total:  len(rows),
+		Errors: make([]response.ImportRowError, 0),
+	}
+
+	breedErrs := s.validateBreeds(ctx, rows.Valid())
+
+	cats := make([]entity.Cat, 0, len(rows))
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = breedErrs[strings.ToLower(row.Cat.Breed)]
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, response.ImportRowError{
+				Row:   row.Row,
+				Name:  row.Cat.Name,
+				Error: publicErr(err),
+			})
+			continue
+		}
+		cats = append(cats, dto.CatToEntity(row.Cat))
+	}
+	report.Failed = len(report.Errors)
+
+	if len(cats) == 0 || (mode == dto.ImportModeAtomic && report.Failed > 0) {
+		return report, nil
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	createdCats, err := s.repo.CreateCats(ctx, tx, cats)
+	if err != nil {
+		return report, config.DBError(fmt.Errorf("create cats: %w", err))
+	}
+
+	changes := make([]entity.SalaryChange, 0, len(createdCats))
+	for _, cat := range createdCats {
+		changes = append(changes, initialSalary(cat))
+	}
+	if err := s.salaryRepo.CreateSalaryChanges(ctx, tx, changes); err != nil {
+		return report, config.DBError(fmt.Errorf("create salary changes: %w", err))
+	}
+
+	if err := s.recordCreated(ctx, tx, createdCats...); err != nil {
+		return report, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return report, config.DBError(fmt.Errorf("commit cats import: %w", err))
+	}
+
+	report.Imported = len(cats)
+	report.Committed = true
+	return report, nil
+}
+
+// ExportCats streams every cat to fn.
+func (s service) ExportCats(ctx context.Context, fn func(response.Cat) error) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	err := s.repo.StreamCats(ctx, func(cat entity.Cat) error {
+		return fn(dto.CatToResponse(cat))
+	})
+	return config.DBError(err)
+}
+
+// validateBreeds checks each distinct breed of the rows with TheCatAPI
+// and returns the error per lowercased breed, nil for valid ones.
+func (s service) validateBreeds(ctx context.Context, rows dto.ImportRows) map[string]error {
+	res := make(map[string]error)
+
+	for _, row := range rows {
+		key := strings.ToLower(row.Cat.Breed)
+		if _, ok := res[key]; ok {
+			continue
+		}
+
+		valid, err := s.breedValidator.IsValid(ctx, row.Cat.Breed)
+		switch {
+		case err != nil:
+			s.l.Error("error validating breed with TheCatAPI", "breed", row.Cat.Breed, "err", err)
+			res[key] = config.Wrap(config.CodeExternalRequestFail, "could not validate breed", err)
+		case !valid:
+			res[key] = config.ErrInvalidBreed.WithDetail(row.Cat.Breed)
+		default:
+			res[key] = nil
+		}
+	}
+
+	return res
+}
+
+// publicErr returns the client-safe text of a row error, a generic
+// message for errors which are not domain errors.
+func publicErr(err error) string {
+	return config.AsError(err).Public()
+}
@@ -0,0 +1,34 @@
+package cat
+
+import (
+	"backend/config"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPublicErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "domain error", err: config.ErrInvalidBreed.WithDetail("Dragon"), want: "invalid breed: Dragon"},
+		{name: "row error", err: config.BadRequest("invalid row", errors.New(`invalid salary: "-1"`)), want: `invalid row: invalid salary: "-1"`},
+		{name: "wrapped domain error", err: fmt.Errorf("row 3: %w", config.ErrInvalidBreed), want: "invalid breed"},
+		{
+			name: "cause is hidden",
+			err:  config.Wrap(config.CodeExternalRequestFail, "could not validate breed", errors.New("dial tcp 10.0.0.7:443: timeout")),
+			want: "could not validate breed",
+		},
+		{name: "unknown error", err: errors.New("pq: relation cats does not exist"), want: "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := publicErr(tt.err); got != tt.want {
+				t.Errorf("publicErr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
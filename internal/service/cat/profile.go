@@ -0,0 +1,46 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+// UpdateCatProfile applies the partial profile update and returns the updated cat.
+func (s service) UpdateCatProfile(ctx context.Context, body request.CatProfile, catID uint) (response.Cat, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Cat{}, err
+	}
+	cat, err := s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	if cat.ID == 0 {
+		return response.Cat{}, config.ErrCatNotFound
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	if err = s.repo.UpdateCatProfile(ctx, tx, dto.CatProfileToEntity(body, cat)); err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("update cat profile: %w", err))
+	}
+	if err = tx.Commit().Error; err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("commit cat profile: %w", err))
+	}
+
+	cat, err = s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	cats := []entity.Cat{cat}
+	if err = s.repo.GetCatProfiles(ctx, cats); err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat profile: %w", err))
+	}
+	return dto.CatToResponse(cats[0]), nil
+}
@@ -0,0 +1,51 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestPlaceSalaryChange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	usd := func(amount int64) money.Money { return money.New(amount, "USD") }
+
+	history := []entity.SalaryChange{
+		{ID: 1, Salary: usd(1000), EffectiveFrom: day(1)},
+		{ID: 2, Salary: usd(1500), EffectiveFrom: day(10)},
+		{ID: 3, Salary: usd(2000), EffectiveFrom: day(20)},
+	}
+
+	tests := []struct {
+		name         string
+		history      []entity.SalaryChange
+		date         time.Time
+		wantPrevious money.Money
+		wantNextID   uint // 0 if the change is the latest
+	}{
+		{"empty history", nil, day(5), money.Money{}, 0},
+		{"latest", history, day(25), usd(2000), 0},
+		{"backdated between changes", history, day(15), usd(1500), 3},
+		{"backdated before all changes", history[1:], day(5), money.Money{}, 2},
+		{"same day as a change goes after it", history, day(10), usd(1500), 3},
+		{"same day as the latest change", history, day(20), usd(2000), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, next := placeSalaryChange(tt.history, tt.date)
+			if previous != tt.wantPrevious {
+				t.Errorf("previous = %v, want %v", previous, tt.wantPrevious)
+			}
+
+			var nextID uint
+			if next != nil {
+				nextID = next.ID
+			}
+			if nextID != tt.wantNextID {
+				t.Errorf("next = %d, want %d", nextID, tt.wantNextID)
+			}
+		})
+	}
+}
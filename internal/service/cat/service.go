@@ -2,51 +2,178 @@ package cat
 
 import (
 	"backend/config"
-	request "backend/internal/controller/http/request/cat"
-	response "backend/internal/controller/http/response/cat"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
 	"context"
 	"fmt"
+	"time"
 )
 
-func (s service) GetCats(ctx context.Context, breed string) ([]response.Cat, config.ServiceCode, error) {
+func (s service) GetCats(ctx context.Context, breed string) ([]response.Cat, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
 	cats, err := s.repo.GetCats(ctx, breed)
-	return response.CatsToResponse(cats), config.DBErrToServiceCode(err), err
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get cats: %w", err))
+	}
+	return dto.CatsToResponse(cats), nil
 }
 
-func (s service) GetCatByID(ctx context.Context, catID uint) (response.Cat, config.ServiceCode, error) {
+func (s service) GetCatByID(ctx context.Context, catID uint) (response.Cat, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Cat{}, err
+	}
 	cat, err := s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
 	if cat.ID == 0 {
-		return response.Cat{}, config.CodeNotFound, config.ErrCatNotFound
+		return response.Cat{}, config.ErrCatNotFound
 	}
-	return response.CatToResponse(cat), config.DBErrToServiceCode(err), err
+
+	cats := []entity.Cat{cat}
+	if err = s.repo.GetCatProfiles(ctx, cats); err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat profile: %w", err))
+	}
+	return dto.CatToResponse(cats[0]), nil
 }
 
-func (s service) CreateCat(ctx context.Context, body request.Cat) (response.Cat, config.ServiceCode, error) {
+func (s service) CreateCat(ctx context.Context, body request.Cat) (response.Cat, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Cat{}, err
+	}
 	valid, err := s.breedValidator.IsValid(ctx, body.Breed)
 	if err != nil {
 		s.l.Error("error validating breed with TheCatAPI", "breed", body.Breed, "err", err)
-		return response.Cat{}, config.CodeExternalRequestFail, fmt.Errorf("could not validate breed: %v", err)
+		return response.Cat{}, config.Wrap(config.CodeExternalRequestFail, "could not validate breed", err)
 	}
 	if !valid {
-		return response.Cat{}, config.CodeBadRequest, fmt.Errorf("invalid breed: %s", body.Breed)
+		return response.Cat{}, config.ErrInvalidBreed.WithDetail(body.Breed)
 	}
 
-	catEntity := body.ToEntity()
+	catEntity := dto.CatToEntity(body)
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	createdCat, err := s.repo.CreateCat(ctx, tx, catEntity)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("create cat: %w", err))
+	}
 
-	createdCat, err := s.repo.CreateCat(ctx, catEntity)
+	err = s.salaryRepo.CreateSalaryChange(ctx, tx, initialSalary(createdCat))
 	if err != nil {
-		return response.Cat{}, config.DBErrToServiceCode(err), err
+		return response.Cat{}, config.DBError(fmt.Errorf("create salary change: %w", err))
 	}
 
-	return response.CatToResponse(createdCat), config.CodeOK, nil
+	if err = s.recordCreated(ctx, tx, createdCat); err != nil {
+		return response.Cat{}, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("commit cat: %w", err))
+	}
+	return dto.CatToResponse(createdCat), nil
 }
 
-func (s service) UpdateCat(ctx context.Context, body request.UpdateCat, catID uint) (config.ServiceCode, error) {
-	err := s.repo.UpdateCat(ctx, body.ToEntity(catID))
-	return config.DBErrToServiceCode(err), err
+// UpdateCat sets the new salary and records it in the salary history.
+// UpdateCat records the salary change at its place in the history, a backdated
+// change only updates the current salary if no later change is effective.
+func (s service) UpdateCat(ctx context.Context, body request.UpdateCat, catID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	change, err := dto.SalaryChangeToEntity(body, catID)
+	if err != nil {
+		return err
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	found, err := s.repo.LockCat(ctx, tx, catID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("lock cat: %w", err))
+	}
+	if !found {
+		return config.ErrCatNotFound
+	}
+
+	history, err := s.salaryRepo.GetCatSalaryChanges(ctx, tx, catID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get salary history: %w", err))
+	}
+
+	previous, next := placeSalaryChange(history, change.EffectiveFrom)
+	change.PreviousSalary = previous
+
+	if next == nil {
+		if err = s.repo.UpdateCat(ctx, tx, dto.UpdateCatToEntity(body, catID)); err != nil {
+			return config.DBError(fmt.Errorf("update cat: %w", err))
+		}
+	} else if err = s.salaryRepo.UpdatePreviousSalary(ctx, tx, next.ID, change.Salary); err != nil {
+		return config.DBError(fmt.Errorf("update next salary change: %w", err))
+	}
+	if err = s.salaryRepo.CreateSalaryChange(ctx, tx, change); err != nil {
+		return config.DBError(fmt.Errorf("create salary change: %w", err))
+	}
+
+	return config.DBError(tx.Commit().Error)
 }
 
-func (s service) DeleteCat(ctx context.Context, catID uint) (config.ServiceCode, error) {
+func (s service) GetSalaryHistory(ctx context.Context, catID uint) ([]response.SalaryChange, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	cat, err := s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	if cat.ID == 0 {
+		return nil, config.ErrCatNotFound
+	}
+
+	changes, err := s.salaryRepo.GetSalaryHistory(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get salary history: %w", err))
+	}
+	return dto.SalaryChangesToResponse(changes), nil
+}
+
+func (s service) DeleteCat(ctx context.Context, catID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
 	err := s.repo.DeleteCat(ctx, catID)
-	return config.DBErrToServiceCode(err), err
+	return config.DBError(err)
+}
+
+// placeSalaryChange finds where a change effective from the given date goes in
+// the chronological history, after the changes effective the same day. It
+// returns the salary in effect before it, and the next change if any.
+func placeSalaryChange(history []entity.SalaryChange, effectiveFrom time.Time) (money.Money, *entity.SalaryChange) {
+	var previous money.Money
+	for i, h := range history {
+		if h.EffectiveFrom.After(effectiveFrom) {
+			return previous, &history[i]
+		}
+		previous = h.Salary
+	}
+	return previous, nil
+}
+
+func initialSalary(cat entity.Cat) entity.SalaryChange {
+	return entity.SalaryChange{
+		CatID:         cat.ID,
+		Salary:        cat.Salary,
+		EffectiveFrom: cat.CreatedAt.UTC().Truncate(24 * time.Hour),
+		Reason:        config.InitialSalaryReason,
+
+		CreatedAt: time.Now(),
+	}
 }
@@ -0,0 +1,95 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"time"
+)
+
+// statusTransitions lists statuses reachable from each status, KIA is final.
+var statusTransitions = map[string][]string{
+	config.CatStatusActive:  {config.CatStatusOnLeave, config.CatStatusRetired, config.CatStatusKIA},
+	config.CatStatusOnLeave: {config.CatStatusActive, config.CatStatusRetired, config.CatStatusKIA},
+	config.CatStatusRetired: {config.CatStatusActive},
+	config.CatStatusKIA:     {},
+}
+
+// UpdateCatStatus moves the cat to the new status and records the transition.
+func (s service) UpdateCatStatus(ctx context.Context, body request.CatStatus, catID uint) (response.Cat, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Cat{}, err
+	}
+	cat, err := s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	if cat.ID == 0 {
+		return response.Cat{}, config.ErrCatNotFound
+	}
+
+	if !canTransition(cat.Status, body.Status) {
+		return response.Cat{}, config.ErrCatStatusTransitionInvalid.WithDetail(
+			fmt.Sprintf("%s -> %s", cat.Status, body.Status))
+	}
+
+	change := entity.CatStatusChange{
+		CatID:  catID,
+		From:   cat.Status,
+		To:     body.Status,
+		Reason: body.Reason,
+
+		CreatedAt: time.Now(),
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	updated, err := s.repo.UpdateCatStatus(ctx, tx, change)
+	if err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("update cat status: %w", err))
+	}
+	if !updated {
+		// changed concurrently since the check above
+		return response.Cat{}, config.ErrCatStatusChanged
+	}
+	if err = tx.Commit().Error; err != nil {
+		return response.Cat{}, config.DBError(fmt.Errorf("commit cat status: %w", err))
+	}
+
+	cat.Status, cat.StatusReason = change.To, change.Reason
+	return dto.CatToResponse(cat), nil
+}
+
+func (s service) GetStatusHistory(ctx context.Context, catID uint) ([]response.CatStatusChange, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	cat, err := s.repo.GetCatByID(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get cat: %w", err))
+	}
+	if cat.ID == 0 {
+		return nil, config.ErrCatNotFound
+	}
+
+	changes, err := s.repo.GetStatusHistory(ctx, catID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get status history: %w", err))
+	}
+	return dto.CatStatusChangesToResponse(changes), nil
+}
+
+func canTransition(from, to string) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package cat
+
+import (
+	"backend/config"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	const (
+		active  = config.CatStatusActive
+		onLeave = config.CatStatusOnLeave
+		retired = config.CatStatusRetired
+		kia     = config.CatStatusKIA
+	)
+
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{active, active, false},
+		{active, onLeave, true},
+		{active, retired, true},
+		{active, kia, true},
+
+		{onLeave, active, true},
+		{onLeave, onLeave, false},
+		{onLeave, retired, true},
+		{onLeave, kia, true},
+
+		{retired, active, true},
+		{retired, onLeave, false},
+		{retired, retired, false},
+		{retired, kia, false},
+
+		{kia, active, false},
+		{kia, onLeave, false},
+		{kia, retired, false},
+		{kia, kia, false},
+
+		{active, "deserted", false},
+		{"", active, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" -> "+tt.to, func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("canTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cat
 
 import (
 	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
 	"context"
 	"log/slog"
 
@@ -15,30 +16,58 @@ type (
 
 		GetCats(ctx context.Context, breed string) (cats []entity.Cat, err error)
 		GetCatByID(ctx context.Context, catID uint) (cat entity.Cat, err error)
+		StreamCats(ctx context.Context, fn func(entity.Cat) error) error
 
-		CreateCat(ctx context.Context, cat entity.Cat) (entity.Cat, error)
-		UpdateCat(ctx context.Context, cat entity.Cat) error
+		CreateCat(ctx context.Context, tx *gorm.DB, cat entity.Cat) (entity.Cat, error)
+		CreateCats(ctx context.Context, tx *gorm.DB, cats []entity.Cat) ([]entity.Cat, error)
+		LockCat(ctx context.Context, tx *gorm.DB, catID uint) (bool, error)
+		UpdateCat(ctx context.Context, tx *gorm.DB, cat entity.Cat) error
 		DeleteCat(ctx context.Context, catID uint) error
+
+		GetCatProfiles(ctx context.Context, cats []entity.Cat) error
+		UpdateCatProfile(ctx context.Context, tx *gorm.DB, cat entity.Cat) error
+
+		GetStatusHistory(ctx context.Context, catID uint) ([]entity.CatStatusChange, error)
+		UpdateCatStatus(ctx context.Context, tx *gorm.DB, change entity.CatStatusChange) (bool, error)
+	}
+
+	salaryRepo interface {
+		GetSalaryHistory(ctx context.Context, catID uint) ([]entity.SalaryChange, error)
+		GetCatSalaryChanges(ctx context.Context, tx *gorm.DB, catID uint) ([]entity.SalaryChange, error)
+
+		CreateSalaryChange(ctx context.Context, tx *gorm.DB, change entity.SalaryChange) error
+		UpdatePreviousSalary(ctx context.Context, tx *gorm.DB, changeID uint, previous money.Money) error
+		CreateSalaryChanges(ctx context.Context, tx *gorm.DB, changes []entity.SalaryChange) error
 	}
 
 	breedValidator interface {
 		IsValid(ctx context.Context, breedName string) (bool, error)
 	}
 
+	outboxRepo interface {
+		CreateEvents(ctx context.Context, tx *gorm.DB, events ...entity.Event) error
+	}
+
 	service struct {
 		repo           repo
+		salaryRepo     salaryRepo
 		breedValidator breedValidator
+		outboxRepo     outboxRepo
 		l              *slog.Logger
 	}
 )
 
 func NewService(
 	repo repo,
+	salaryRepo salaryRepo,
 	breedValidator breedValidator,
+	outboxRepo outboxRepo,
 	l *slog.Logger,
 ) service {
 	return service{
 		repo,
+		salaryRepo,
 		breedValidator,
+		outboxRepo,
 		l}
 }
@@ -0,0 +1,83 @@
+package debrief
+
+import (
+	"backend/config"
+	response "backend/pkg/api/response/cat"
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var funcs = map[string]any{
+	"rating": func(rating *int) string {
+		if rating == nil {
+			return "not rated"
+		}
+		return fmt.Sprintf("%d/%d", *rating, config.MaxDebriefRating)
+	},
+	"outcome": func(outcome string) string {
+		if outcome == "" {
+			return "not recorded"
+		}
+		return outcome
+	},
+	"date": func(t time.Time) string {
+		return t.UTC().Format(config.DateLayout)
+	},
+	// paragraphs splits a text on blank lines
+	"paragraphs": func(text string) []string {
+		var paragraphs []string
+		for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+			if p = strings.TrimSpace(p); p != "" {
+				paragraphs = append(paragraphs, p)
+			}
+		}
+		return paragraphs
+	},
+}
+
+// Markdown renderers pass inline HTML through, so texts are escaped.
+var markdownTemplate = texttemplate.Must(
+	texttemplate.New("debrief.md.tmpl").
+		Funcs(funcs).
+		Funcs(map[string]any{"escape": html.EscapeString}).
+		ParseFS(templates, "templates/debrief.md.tmpl"))
+
+var htmlTemplate = htmltemplate.Must(
+	htmltemplate.New("debrief.html.tmpl").
+		Funcs(funcs).
+		ParseFS(templates, "templates/debrief.html.tmpl"))
+
+func render(debrief response.Debrief, format string) (response.DebriefDocument, error) {
+	var (
+		buf bytes.Buffer
+		doc = response.DebriefDocument{Filename: fmt.Sprintf("mission-%d-debrief", debrief.MissionID)}
+		err error
+	)
+	switch format {
+	case config.DebriefFormatMarkdown:
+		doc.Filename += ".md"
+		doc.ContentType = "text/markdown; charset=utf-8"
+		err = markdownTemplate.Execute(&buf, debrief)
+	case config.DebriefFormatHTML:
+		doc.Filename += ".html"
+		doc.ContentType = "text/html; charset=utf-8"
+		err = htmlTemplate.Execute(&buf, debrief)
+	default:
+		return response.DebriefDocument{}, config.ErrUnknownFormat.WithDetail(format)
+	}
+	if err != nil {
+		return response.DebriefDocument{}, config.Wrap(config.CodeInternal, "render debrief", err)
+	}
+
+	doc.Content = buf.Bytes()
+	return doc, nil
+}
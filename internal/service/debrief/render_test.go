@@ -0,0 +1,99 @@
+package debrief
+
+import (
+	"backend/config"
+	response "backend/pkg/api/response/cat"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	rating := 4
+	written := time.Date(2026, time.March, 1, 22, 0, 0, 0, time.FixedZone("", 3*60*60))
+	debrief := response.Debrief{
+		MissionID: 7,
+		CatName:   "Tom & <b>Jerry</b>",
+		Priority:  config.PriorityHigh,
+		Summary:   "Went <script>alert(1)</script> well.\n\nSecond paragraph.",
+		Rating:    &rating,
+		Targets: []response.DebriefTarget{
+			{TargetID: 1, Name: "<i>Ivan</i>", Country: "UA", Outcome: config.OutcomeSuccess, Notes: "a < b"},
+			{TargetID: 2, Name: "Olga", Country: "FR"},
+		},
+		CreatedAt: written,
+		UpdatedAt: written,
+	}
+
+	tests := []struct {
+		format          string
+		wantFilename    string
+		wantContentType string
+		want            []string
+		wantNot         []string
+	}{
+		{
+			format:          config.DebriefFormatMarkdown,
+			wantFilename:    "mission-7-debrief.md",
+			wantContentType: "text/markdown; charset=utf-8",
+			want: []string{
+				"# Mission 7 debrief",
+				"- **Cat:** Tom &amp; &lt;b&gt;Jerry&lt;/b&gt;",
+				"- **Handler rating:** 4/5",
+				"- **Written:** 2026-03-01", // in UTC
+				"Went &lt;script&gt;alert(1)&lt;/script&gt; well.",
+				"### &lt;i&gt;Ivan&lt;/i&gt; (UA)",
+				"**Outcome:** success\n\na &lt; b",
+				"**Outcome:** not recorded",
+				"## Cat self-assessment\n\n_Not written yet._",
+			},
+			wantNot: []string{"<script>", "<b>", "<i>"},
+		},
+		{
+			format:          config.DebriefFormatHTML,
+			wantFilename:    "mission-7-debrief.html",
+			wantContentType: "text/html; charset=utf-8",
+			want: []string{
+				"<title>Mission 7 debrief</title>",
+				"<dd>Tom &amp; &lt;b&gt;Jerry&lt;/b&gt;</dd>",
+				"<p>Went &lt;script&gt;alert(1)&lt;/script&gt; well.</p>\n<p>Second paragraph.</p>",
+				`<section class="target success">`,
+				"<h3>&lt;i&gt;Ivan&lt;/i&gt; (UA)</h3>",
+				"<p>a &lt; b</p>",
+				`<p class="missing">Not written yet.</p>`,
+			},
+			wantNot: []string{"<script>", "<b>", "<i>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			doc, err := render(debrief, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.Filename != tt.wantFilename || doc.ContentType != tt.wantContentType {
+				t.Errorf("document = %s %s, want %s %s", doc.Filename, doc.ContentType, tt.wantFilename, tt.wantContentType)
+			}
+
+			content := string(doc.Content)
+			for _, s := range tt.want {
+				if !strings.Contains(content, s) {
+					t.Errorf("missing %q in\n%s", s, content)
+				}
+			}
+			for _, s := range tt.wantNot {
+				if strings.Contains(content, s) {
+					t.Errorf("unescaped %q in\n%s", s, content)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := render(response.Debrief{}, "pdf"); !errors.Is(err, config.ErrUnknownFormat) {
+		t.Errorf("err = %v, want %v", err, config.ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,115 @@
+package debrief
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+func (s service) GetDebrief(ctx context.Context, missionID uint) (response.Debrief, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return response.Debrief{}, err
+	}
+	return s.getDebrief(ctx, mission)
+}
+
+// RenderDebrief renders the debrief as a Markdown or a self-contained HTML document.
+func (s service) RenderDebrief(ctx context.Context, missionID uint, format string) (response.DebriefDocument, error) {
+	if format != config.DebriefFormatMarkdown && format != config.DebriefFormatHTML {
+		return response.DebriefDocument{}, config.ErrUnknownFormat.WithDetail(format)
+	}
+
+	debrief, err := s.GetDebrief(ctx, missionID)
+	if err != nil {
+		return response.DebriefDocument{}, err
+	}
+	return render(debrief, format)
+}
+
+// SaveDebrief writes the handler part of the debrief of a completed mission.
+func (s service) SaveDebrief(ctx context.Context, body request.Debrief, missionID uint) (response.Debrief, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Debrief{}, err
+	}
+	mission, err := s.getCompletedMission(ctx, missionID)
+	if err != nil {
+		return response.Debrief{}, err
+	}
+
+	targets := make(map[uint]bool, len(mission.Targets))
+	for _, t := range mission.Targets {
+		targets[t.ID] = true
+	}
+	for _, t := range body.Targets {
+		if !targets[t.TargetID] {
+			return response.Debrief{}, config.ErrInvalidDebrief.WithDetail(
+				fmt.Sprintf("target %d is not a target of the mission", t.TargetID))
+		}
+	}
+
+	if err = s.repo.SaveDebrief(ctx, dto.DebriefToEntity(body, missionID)); err != nil {
+		return response.Debrief{}, config.DBError(fmt.Errorf("save debrief: %w", err))
+	}
+	return s.getDebrief(ctx, mission)
+}
+
+// SaveSelfAssessment writes the assigned cat's part of the debrief of a completed mission.
+func (s service) SaveSelfAssessment(ctx context.Context, body request.SelfAssessment, missionID uint) (response.Debrief, error) {
+	mission, err := s.getCompletedMission(ctx, missionID)
+	if err != nil {
+		return response.Debrief{}, err
+	}
+	if auth.FromContext(ctx).IsHandler() {
+		return response.Debrief{}, config.ErrSelfAssessmentCatOnly
+	}
+
+	if err = s.repo.SaveSelfAssessment(ctx, missionID, body.SelfAssessment); err != nil {
+		return response.Debrief{}, config.DBError(fmt.Errorf("save self-assessment: %w", err))
+	}
+	return s.getDebrief(ctx, mission)
+}
+
+// getMission returns the mission if the caller may access it, handlers
+// any mission and cats their own missions only.
+func (s service) getMission(ctx context.Context, missionID uint) (entity.Mission, error) {
+	mission, err := s.missionRepo.GetMission(ctx, missionID)
+	if err != nil {
+		return entity.Mission{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return entity.Mission{}, config.ErrMissionNotFound
+	}
+	if err = auth.RequireMission(ctx, mission.CatID); err != nil {
+		return entity.Mission{}, err
+	}
+	return mission, nil
+}
+
+// getCompletedMission is getMission for debrief writes, only allowed once the mission is completed.
+func (s service) getCompletedMission(ctx context.Context, missionID uint) (entity.Mission, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return entity.Mission{}, err
+	}
+	if !mission.IsCompleted {
+		return entity.Mission{}, config.ErrMissionNotCompleted
+	}
+	return mission, nil
+}
+
+func (s service) getDebrief(ctx context.Context, mission entity.Mission) (response.Debrief, error) {
+	debrief, err := s.repo.GetDebrief(ctx, mission.ID)
+	if err != nil {
+		return response.Debrief{}, config.DBError(fmt.Errorf("get debrief: %w", err))
+	}
+	if debrief.ID == 0 {
+		return response.Debrief{}, config.ErrDebriefNotFound
+	}
+	return dto.DebriefToResponse(debrief, mission), nil
+}
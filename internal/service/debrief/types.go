@@ -0,0 +1,33 @@
+package debrief
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		GetDebrief(ctx context.Context, missionID uint) (entity.Debrief, error)
+		SaveDebrief(ctx context.Context, debrief entity.Debrief) error
+		SaveSelfAssessment(ctx context.Context, missionID uint, selfAssessment string) error
+	}
+
+	missionRepo interface {
+		GetMission(ctx context.Context, missionID uint) (entity.Mission, error)
+	}
+
+	service struct {
+		repo        repo
+		missionRepo missionRepo
+		l           *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	missionRepo missionRepo,
+	l *slog.Logger,
+) service {
+	return service{repo, missionRepo, l}
+}
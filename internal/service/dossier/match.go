@@ -0,0 +1,72 @@
+package dossier
+
+import (
+	entity "backend/internal/entity/cat"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match returns the dossier whose name or alias is the most similar to name,
+// and the similarity from 0 (nothing in common) to 1 (same name).
+func Match(dossiers []entity.Dossier, name string) (entity.Dossier, float64) {
+	var (
+		best  entity.Dossier
+		score float64
+	)
+
+	key := nameKey(name)
+	for _, d := range dossiers {
+		names := []string{d.Name}
+		for _, a := range d.Aliases {
+			names = append(names, a.Alias)
+		}
+		for _, n := range names {
+			if s := similarity(key, nameKey(n)); s > score {
+				best, score = d, s
+			}
+		}
+	}
+	return best, score
+}
+
+// nameKey lowercases the name, drops punctuation and sorts its words,
+// so "Petrov, Ivan" and "ivan petrov" have the same key.
+func nameKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// similarity is 1 minus the Levenshtein distance relative to the longer key.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,55 @@
+package dossier
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"math"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	dossiers := []entity.Dossier{
+		// key "ivan petrovich sidor", 20 runes
+		{ID: 1, Name: "Ivan Petrovich Sidor"},
+		{ID: 2, Name: "Olga Shevchenko", Aliases: []entity.DossierAlias{{Alias: "The Raven"}}},
+		// key "anna kovalska maria", 19 runes
+		{ID: 3, Name: "Anna Maria Kovalska"},
+	}
+
+	tests := []struct {
+		name       string
+		dossiers   []entity.Dossier
+		target     string
+		wantID     uint
+		wantScore  float64
+		wantLinked bool
+	}{
+		{name: "same name", dossiers: dossiers, target: "Ivan Petrovich Sidor", wantID: 1, wantScore: 1, wantLinked: true},
+		{name: "word order and punctuation", dossiers: dossiers, target: "Sidor, Ivan Petrovich", wantID: 1, wantScore: 1, wantLinked: true},
+		{name: "case", dossiers: dossiers, target: "IVAN petrovich SIDOR", wantID: 1, wantScore: 1, wantLinked: true},
+		{name: "alias", dossiers: dossiers, target: "the raven", wantID: 2, wantScore: 1, wantLinked: true},
+		// 3 edits of 20 runes
+		{name: "at the threshold", dossiers: dossiers, target: "Ivan Petrovich Sixxx", wantID: 1, wantScore: 0.85, wantLinked: true},
+		// 4 edits of 20 runes
+		{name: "below the threshold", dossiers: dossiers, target: "Ivan Petrovich Sxxxx", wantID: 1, wantScore: 0.8},
+		// 3 edits of 19 runes
+		{name: "just below the threshold", dossiers: dossiers, target: "Anna Maria Kovalxxx", wantID: 3, wantScore: 1 - 3.0/19},
+		{name: "no dossiers", target: "Ivan Petrovich Sidor"},
+		{name: "empty name", dossiers: dossiers, target: " , "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, score := Match(tt.dossiers, tt.target)
+			if d.ID != tt.wantID {
+				t.Errorf("dossier = %d, want %d", d.ID, tt.wantID)
+			}
+			if math.Abs(score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %v, want %v", score, tt.wantScore)
+			}
+			if linked := score >= config.DossierMatchThreshold; linked != tt.wantLinked {
+				t.Errorf("linked = %v at %v, want %v", linked, score, tt.wantLinked)
+			}
+		})
+	}
+}
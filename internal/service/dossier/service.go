@@ -0,0 +1,104 @@
+package dossier
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+func (s service) GetDossiers(ctx context.Context, q string) ([]response.Dossier, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	dossiers, err := s.repo.GetDossiers(ctx, q)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get dossiers: %w", err))
+	}
+	return dto.DossiersToResponse(dossiers), nil
+}
+
+// GetDossier returns the dossier with every target ever linked to it.
+func (s service) GetDossier(ctx context.Context, dossierID uint) (response.DossierDetail, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.DossierDetail{}, err
+	}
+	dossier, err := s.repo.GetDossierByID(ctx, dossierID)
+	if err != nil {
+		return response.DossierDetail{}, config.DBError(fmt.Errorf("get dossier: %w", err))
+	}
+	if dossier.ID == 0 {
+		return response.DossierDetail{}, config.ErrDossierNotFound
+	}
+
+	targets, missions, err := s.repo.GetDossierHistory(ctx, dossierID)
+	if err != nil {
+		return response.DossierDetail{}, config.DBError(fmt.Errorf("get dossier history: %w", err))
+	}
+	return dto.DossierDetailToResponse(dossier, targets, missions), nil
+}
+
+// CreateDossier creates the dossier unless a similar one exists in the country
+// and force is not set.
+func (s service) CreateDossier(ctx context.Context, body request.Dossier, force bool) (response.Dossier, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Dossier{}, err
+	}
+	if !force {
+		candidates, err := s.repo.GetDossiersByCountry(ctx, body.Country)
+		if err != nil {
+			return response.Dossier{}, config.DBError(fmt.Errorf("get dossiers: %w", err))
+		}
+		for _, name := range append([]string{body.Name}, body.Aliases...) {
+			if match, score := Match(candidates, name); score >= config.DossierMatchThreshold {
+				return response.Dossier{}, config.ErrDossierDuplicate.WithDetail(
+					fmt.Sprintf("dossier %d %q, %.0f%% similar to %q, pass force=true to create anyway",
+						match.ID, match.Name, score*100, name))
+			}
+		}
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	dossier, err := s.repo.CreateDossier(ctx, tx, dto.DossierToEntity(body))
+	if err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("create dossier: %w", err))
+	}
+	if err = tx.Commit().Error; err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("commit dossier: %w", err))
+	}
+	return dto.DossierToResponse(dossier), nil
+}
+
+func (s service) UpdateDossier(ctx context.Context, body request.UpdateDossier, dossierID uint) (response.Dossier, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Dossier{}, err
+	}
+	dossier, err := s.repo.GetDossierByID(ctx, dossierID)
+	if err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("get dossier: %w", err))
+	}
+	if dossier.ID == 0 {
+		return response.Dossier{}, config.ErrDossierNotFound
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	if err = s.repo.UpdateDossier(ctx, tx, dto.UpdateDossierToEntity(body, dossier)); err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("update dossier: %w", err))
+	}
+	if err = tx.Commit().Error; err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("commit dossier: %w", err))
+	}
+
+	dossier, err = s.repo.GetDossierByID(ctx, dossierID)
+	if err != nil {
+		return response.Dossier{}, config.DBError(fmt.Errorf("get dossier: %w", err))
+	}
+	return dto.DossierToResponse(dossier), nil
+}
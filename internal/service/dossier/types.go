@@ -0,0 +1,36 @@
+package dossier
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+type (
+	repo interface {
+		GetDB(ctx context.Context) *gorm.DB
+		NewTransaction(ctx context.Context) *gorm.DB
+
+		GetDossiers(ctx context.Context, q string) ([]entity.Dossier, error)
+		GetDossiersByCountry(ctx context.Context, country string) ([]entity.Dossier, error)
+		GetDossierByID(ctx context.Context, dossierID uint) (entity.Dossier, error)
+		GetDossierHistory(ctx context.Context, dossierID uint) ([]entity.Target, []entity.Mission, error)
+
+		CreateDossier(ctx context.Context, tx *gorm.DB, dossier entity.Dossier) (entity.Dossier, error)
+		UpdateDossier(ctx context.Context, tx *gorm.DB, dossier entity.Dossier) error
+	}
+
+	service struct {
+		repo repo
+		l    *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	l *slog.Logger,
+) service {
+	return service{repo, l}
+}
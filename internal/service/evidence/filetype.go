@@ -0,0 +1,67 @@
+package evidence
+
+import (
+	"backend/config"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// evidenceTypes are the accepted content types: images, documents and audio.
+var evidenceTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+	"image/bmp":  true,
+
+	"application/pdf":    true,
+	"text/plain":         true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.oasis.opendocument.text":                                 true,
+
+	"audio/mpeg":      true,
+	"audio/wave":      true,
+	"audio/aiff":      true,
+	"audio/basic":     true,
+	"audio/midi":      true,
+	"audio/mp4":       true,
+	"application/ogg": true,
+}
+
+// containerTypes are sniffed for formats built on generic containers,
+// which are told apart by the file extension.
+var containerTypes = map[string]map[string]string{
+	"application/zip": {
+		".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		".odt":  "application/vnd.oasis.opendocument.text",
+	},
+	"application/octet-stream": {
+		".doc": "application/msword",
+		".mp3": "audio/mpeg", // without ID3 tag
+	},
+	"video/mp4": {
+		".m4a": "audio/mp4",
+	},
+}
+
+// detectType sniffs the content type from the first bytes of the file,
+// the declared type of the upload is not trusted.
+func detectType(head []byte, filename string) (string, error) {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "", config.ErrEvidenceType
+	}
+	if byExt, ok := containerTypes[contentType]; ok {
+		if t, ok := byExt[strings.ToLower(filepath.Ext(filename))]; ok {
+			contentType = t
+		}
+	}
+
+	if !evidenceTypes[contentType] {
+		return "", config.ErrEvidenceType.WithDetail(contentType)
+	}
+	return contentType, nil
+}
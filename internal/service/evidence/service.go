@@ -0,0 +1,246 @@
+package evidence
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/blob"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (s service) GetEvidence(ctx context.Context, missionID, targetID uint) ([]response.Evidence, error) {
+	if _, _, err := s.getTarget(ctx, missionID, targetID); err != nil {
+		return nil, err
+	}
+
+	evidence, err := s.repo.GetEvidence(ctx, missionID, targetID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get evidence: %w", err))
+	}
+
+	res := make([]response.Evidence, 0, len(evidence))
+	for _, e := range evidence {
+		item, err := s.toResponse(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// UploadEvidence stores the file read from r as evidence of the target. The file
+// is spooled to disk first to enforce the size limit, hash and sniff it before
+// anything is stored.
+func (s service) UploadEvidence(ctx context.Context, missionID, targetID uint, filename string, r io.Reader) (response.Evidence, error) {
+	mission, target, err := s.getTarget(ctx, missionID, targetID)
+	if err != nil {
+		return response.Evidence{}, err
+	}
+	if mission.IsCompleted {
+		return response.Evidence{}, config.ErrMissionAlreadyComplete
+	}
+	if target.IsCompleted {
+		return response.Evidence{}, config.ErrTargetAlreadyComplete
+	}
+
+	filename = strings.TrimSpace(filepath.Base(filepath.FromSlash(filename)))
+	if filename == "" || filename == "." || filename == string(filepath.Separator) || len(filename) > 255 {
+		return response.Evidence{}, config.BadRequest("invalid filename", nil)
+	}
+
+	spool, err := os.CreateTemp("", "evidence-*")
+	if err != nil {
+		return response.Evidence{}, config.Wrap(config.CodeInternal, "spool evidence", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(r, s.cfg.MaxSize+1))
+	if err != nil {
+		return response.Evidence{}, config.BadRequest("read evidence file", err)
+	}
+	if size > s.cfg.MaxSize {
+		return response.Evidence{}, config.ErrEvidenceTooLarge.WithDetail(fmt.Sprintf("limit is %d bytes", s.cfg.MaxSize))
+	}
+	if size == 0 {
+		return response.Evidence{}, config.ErrEvidenceType.WithDetail("file is empty")
+	}
+
+	head := make([]byte, 512)
+	n, err := spool.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return response.Evidence{}, config.Wrap(config.CodeInternal, "read spooled evidence", err)
+	}
+	contentType, err := detectType(head[:n], filename)
+	if err != nil {
+		return response.Evidence{}, err
+	}
+
+	key, err := newKey(missionID, targetID)
+	if err != nil {
+		return response.Evidence{}, config.Wrap(config.CodeInternal, "generate evidence key", err)
+	}
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return response.Evidence{}, config.Wrap(config.CodeInternal, "rewind spooled evidence", err)
+	}
+	if err = s.store.Put(ctx, key, spool, size, contentType); err != nil {
+		return response.Evidence{}, config.Wrap(config.CodeExternalRequestFail, "store evidence", err)
+	}
+
+	caller := auth.FromContext(ctx)
+	evidence := entity.Evidence{
+		MissionID:      missionID,
+		TargetID:       targetID,
+		Key:            key,
+		Filename:       filename,
+		ContentType:    contentType,
+		Size:           size,
+		SHA256:         hex.EncodeToString(hash.Sum(nil)),
+		UploadedByRole: caller.Role,
+	}
+	if !caller.IsHandler() {
+		evidence.UploadedByCatID = &caller.CatID
+	}
+
+	evidence, err = s.repo.CreateEvidence(ctx, evidence)
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return response.Evidence{}, config.DBError(fmt.Errorf("create evidence: %w", err))
+	}
+	return s.toResponse(ctx, evidence)
+}
+
+// DeleteEvidence removes evidence of a target which is not completed yet.
+func (s service) DeleteEvidence(ctx context.Context, missionID, targetID, evidenceID uint) error {
+	mission, target, err := s.getTarget(ctx, missionID, targetID)
+	if err != nil {
+		return err
+	}
+	if mission.IsCompleted {
+		return config.ErrMissionAlreadyComplete
+	}
+	if target.IsCompleted {
+		return config.ErrTargetAlreadyComplete
+	}
+
+	evidence, err := s.repo.GetEvidenceByID(ctx, evidenceID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get evidence: %w", err))
+	}
+	if evidence.ID == 0 || evidence.MissionID != missionID || evidence.TargetID != targetID {
+		return config.ErrEvidenceNotFound
+	}
+
+	deleted, err := s.repo.DeleteEvidence(ctx, evidenceID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("delete evidence: %w", err))
+	}
+	if !deleted {
+		// target completed concurrently since the check above
+		return config.ErrTargetAlreadyComplete
+	}
+	s.deleteBlob(ctx, evidence.Key)
+	return nil
+}
+
+// OpenDownload returns the evidence content for a URL signed by toResponse.
+// The signature is the authorization, no caller is required.
+func (s service) OpenDownload(ctx context.Context, evidenceID uint, expires, signature string) (response.EvidenceFile, error) {
+	if err := s.signer.Verify(downloadPath(evidenceID), expires, signature, time.Now()); err != nil {
+		return response.EvidenceFile{}, config.ErrInvalidDownloadURL
+	}
+
+	evidence, err := s.repo.GetEvidenceByID(ctx, evidenceID)
+	if err != nil {
+		return response.EvidenceFile{}, config.DBError(fmt.Errorf("get evidence: %w", err))
+	}
+	if evidence.ID == 0 {
+		return response.EvidenceFile{}, config.ErrEvidenceNotFound
+	}
+
+	content, err := s.store.Open(ctx, evidence.Key)
+	if errors.Is(err, blob.ErrNotFound) {
+		return response.EvidenceFile{}, config.ErrEvidenceNotFound
+	}
+	if err != nil {
+		return response.EvidenceFile{}, config.Wrap(config.CodeExternalRequestFail, "open evidence", err)
+	}
+	return response.EvidenceFile{
+		Filename:    evidence.Filename,
+		ContentType: evidence.ContentType,
+		Size:        evidence.Size,
+		Content:     content,
+	}, nil
+}
+
+// getTarget returns the mission and its target, if the caller may access the mission.
+func (s service) getTarget(ctx context.Context, missionID, targetID uint) (entity.Mission, entity.Target, error) {
+	mission, err := s.missionRepo.GetMission(ctx, missionID)
+	if err != nil {
+		return entity.Mission{}, entity.Target{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return entity.Mission{}, entity.Target{}, config.ErrMissionNotFound
+	}
+	if err = auth.RequireMission(ctx, mission.CatID); err != nil {
+		return entity.Mission{}, entity.Target{}, err
+	}
+
+	for _, t := range mission.Targets {
+		if t.ID == targetID {
+			return mission, t, nil
+		}
+	}
+	return entity.Mission{}, entity.Target{}, config.ErrTargetNotFound
+}
+
+// toResponse adds a download URL valid for the configured expiry, presigned
+// by the store when it can, otherwise served by the API.
+func (s service) toResponse(ctx context.Context, e entity.Evidence) (response.Evidence, error) {
+	expiresAt := time.Now().Add(s.cfg.URLExpiry).Truncate(time.Second)
+
+	if presigner, ok := s.store.(blob.Presigner); ok {
+		url, err := presigner.PresignGet(ctx, e.Key, e.Filename, s.cfg.URLExpiry)
+		if err != nil {
+			return response.Evidence{}, config.Wrap(config.CodeExternalRequestFail, "presign evidence url", err)
+		}
+		return dto.EvidenceToResponse(e, url, expiresAt), nil
+	}
+
+	return dto.EvidenceToResponse(e, s.signer.Sign(downloadPath(e.ID), expiresAt), expiresAt), nil
+}
+
+func (s service) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		s.l.Error("delete evidence blob failed", "key", key, "err", err)
+	}
+}
+
+func downloadPath(evidenceID uint) string {
+	return fmt.Sprintf("/evidence/%d/download", evidenceID)
+}
+
+// newKey returns a random blob key grouped by mission and target.
+func newKey(missionID, targetID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("evidence/%d/%d/%s", missionID, targetID, hex.EncodeToString(b)), nil
+}
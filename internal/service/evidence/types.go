@@ -0,0 +1,43 @@
+package evidence
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/blob"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		GetEvidence(ctx context.Context, missionID, targetID uint) ([]entity.Evidence, error)
+		GetEvidenceByID(ctx context.Context, evidenceID uint) (entity.Evidence, error)
+
+		CreateEvidence(ctx context.Context, evidence entity.Evidence) (entity.Evidence, error)
+		DeleteEvidence(ctx context.Context, evidenceID uint) (bool, error)
+	}
+
+	missionRepo interface {
+		GetMission(ctx context.Context, missionID uint) (entity.Mission, error)
+	}
+
+	service struct {
+		repo        repo
+		missionRepo missionRepo
+		store       blob.Store
+		signer      blob.URLSigner
+		cfg         config.Evidence
+		l           *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	missionRepo missionRepo,
+	store blob.Store,
+	signer blob.URLSigner,
+	cfg config.Evidence,
+	l *slog.Logger,
+) service {
+	return service{repo, missionRepo, store, signer, cfg, l}
+}
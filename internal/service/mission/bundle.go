@@ -0,0 +1,118 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+func (s service) ExportMissionBundle(ctx context.Context, missionID uint) (response.MissionBundle, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionBundle{}, err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return response.MissionBundle{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return response.MissionBundle{}, config.ErrMissionNotFound
+	}
+
+	bundle := dto.MissionToBundle(mission)
+	if mission.TypeID != nil {
+		missionType, err := s.GetMissionType(ctx, mission.TypeID)
+		if err != nil {
+			return response.MissionBundle{}, err
+		}
+		bundle.Mission.Type = missionType.Name
+	}
+	return bundle, nil
+}
+
+// ImportMissionBundle creates the mission and its targets from the bundle
+// in one transaction. If attachCat is set, the bundle cat is matched
+// to a local cat by name and assigned like in AssignCat.
+func (s service) ImportMissionBundle(ctx context.Context, bundle request.MissionBundle, attachCat bool) (response.BundleImport, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.BundleImport{}, err
+	}
+	if err := dto.ValidateBundleVersion(bundle); err != nil {
+		return response.BundleImport{}, err
+	}
+	missionType, err := s.getMissionTypeByName(ctx, bundle.Mission.Type)
+	if err != nil {
+		return response.BundleImport{}, err
+	}
+	if err = dto.ValidateBundle(&bundle, missionType); err != nil {
+		return response.BundleImport{}, err
+	}
+
+	missionEntity := dto.BundleToEntity(bundle)
+	missionEntity.TypeID = &missionType.ID
+
+	var cat entity.Cat
+	if attachCat {
+		if bundle.Mission.Cat == nil {
+			return response.BundleImport{}, config.ErrBundleHasNoCat
+		}
+
+		cat, err = s.catRepo.GetCatByName(ctx, bundle.Mission.Cat.Name)
+		if err != nil {
+			return response.BundleImport{}, config.DBError(fmt.Errorf("get cat: %w", err))
+		}
+		if cat.ID == 0 {
+			return response.BundleImport{}, config.ErrCatNotFound.WithDetail(bundle.Mission.Cat.Name)
+		}
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	targets := missionEntity.Targets
+	createdMission, err := s.repo.CreateMission(ctx, tx, missionEntity)
+	if err != nil {
+		return response.BundleImport{}, config.DBError(fmt.Errorf("create mission: %w", err))
+	}
+
+	for i := range targets {
+		targets[i].MissionID = createdMission.ID
+	}
+	if err = s.linkDossiers(ctx, tx, targets); err != nil {
+		return response.BundleImport{}, err
+	}
+	createdTargets, err := s.targetRepo.CreateTargets(ctx, tx, targets)
+	if err != nil {
+		return response.BundleImport{}, config.DBError(fmt.Errorf("create mission targets: %w", err))
+	}
+
+	if cat.ID != 0 {
+		if err = s.assignCat(ctx, tx, createdMission.ID, cat); err != nil {
+			return response.BundleImport{}, err
+		}
+		createdMission.CatID = &cat.ID
+		createdMission.Cat = cat
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return response.BundleImport{}, config.DBError(fmt.Errorf("commit mission bundle: %w", err))
+	}
+	if cat.ID != 0 {
+		s.notify(ctx, response.MissionUpdate{Type: config.EventMissionAssigned, MissionID: createdMission.ID, CatID: &cat.ID})
+	}
+
+	createdMission.Targets = createdTargets
+	targetIDs := make(map[uint]uint, len(createdTargets))
+	for i, t := range createdTargets {
+		targetIDs[bundle.Mission.Targets[i].SourceID] = t.ID
+	}
+
+	return response.BundleImport{
+		Mission:   dto.MissionToResponse(createdMission),
+		TargetIDs: targetIDs,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/internal/service/dossier"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// linkDossiers links every target without a dossier to the most similar
+// dossier of its country, creating a new dossier if none is close enough,
+// and reports the link in the target DossierLink. Explicit dossier IDs are
+// checked to exist.
+func (s service) linkDossiers(ctx context.Context, tx *gorm.DB, targets []entity.Target) error {
+	// dossiers created in tx are not visible to the repo reads yet
+	created := make(map[string][]entity.Dossier)
+
+	for i := range targets {
+		t := &targets[i]
+
+		if t.DossierID != nil {
+			d, err := s.dossierRepo.GetDossierByID(ctx, *t.DossierID)
+			if err != nil {
+				return config.DBError(fmt.Errorf("get dossier: %w", err))
+			}
+			if d.ID == 0 {
+				return config.ErrDossierNotFound.WithDetail(fmt.Sprintf("dossier_id %d", *t.DossierID))
+			}
+			continue
+		}
+
+		candidates, err := s.dossierRepo.GetDossiersByCountry(ctx, t.Country)
+		if err != nil {
+			return config.DBError(fmt.Errorf("get dossiers: %w", err))
+		}
+		candidates = append(candidates, created[t.Country]...)
+
+		if match, score := dossier.Match(candidates, t.Name); score >= config.DossierMatchThreshold {
+			t.DossierID = &match.ID
+			t.DossierLink = &entity.DossierLink{DossierID: match.ID, Name: match.Name, Similarity: score}
+			continue
+		}
+
+		d, err := s.dossierRepo.CreateDossier(ctx, tx, entity.Dossier{
+			Name:    t.Name,
+			Country: t.Country,
+
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			return config.DBError(fmt.Errorf("create dossier: %w", err))
+		}
+		created[t.Country] = append(created[t.Country], d)
+		t.DossierID = &d.ID
+		t.DossierLink = &entity.DossierLink{DossierID: d.ID, Name: d.Name, Created: true}
+	}
+
+	return nil
+}
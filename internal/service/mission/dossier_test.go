@@ -0,0 +1,113 @@
+package cat
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type fakeDossiers struct {
+	dossierRepo
+	dossiers []entity.Dossier
+}
+
+func (r *fakeDossiers) GetDossierByID(_ context.Context, dossierID uint) (entity.Dossier, error) {
+	for _, d := range r.dossiers {
+		if d.ID == dossierID {
+			return d, nil
+		}
+	}
+	return entity.Dossier{}, nil
+}
+
+func (r *fakeDossiers) GetDossiersByCountry(_ context.Context, country string) (res []entity.Dossier, _ error) {
+	for _, d := range r.dossiers {
+		if d.Country == country && d.ID < 100 {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+// CreateDossier numbers created dossiers from 100, they are not read
+// back by GetDossiersByCountry like in a transaction.
+func (r *fakeDossiers) CreateDossier(_ context.Context, _ *gorm.DB, dossier entity.Dossier) (entity.Dossier, error) {
+	dossier.ID = uint(100 + len(r.dossiers))
+	r.dossiers = append(r.dossiers, dossier)
+	return dossier, nil
+}
+
+func TestLinkDossiers(t *testing.T) {
+	existingID, missingID := uint(1), uint(7)
+
+	tests := []struct {
+		name      string
+		targets   []entity.Target
+		wantLinks []*entity.DossierLink
+		wantErr   error
+	}{
+		{
+			name:    "similar name is linked and reported",
+			targets: []entity.Target{{Name: "Petrov, Ivan", Country: "UA"}},
+			wantLinks: []*entity.DossierLink{
+				{DossierID: 1, Name: "Ivan Petrov", Similarity: 1},
+			},
+		},
+		{
+			name:      "same name in another country gets a new dossier",
+			targets:   []entity.Target{{Name: "Ivan Petrov", Country: "PL"}},
+			wantLinks: []*entity.DossierLink{{DossierID: 101, Name: "Ivan Petrov", Created: true}},
+		},
+		{
+			name: "new dossier is reused by the next targets",
+			targets: []entity.Target{
+				{Name: "Olga Shevchenko", Country: "UA"},
+				{Name: "Shevchenko Olga", Country: "UA"},
+			},
+			wantLinks: []*entity.DossierLink{
+				{DossierID: 101, Name: "Olga Shevchenko", Created: true},
+				{DossierID: 101, Name: "Olga Shevchenko", Similarity: 1},
+			},
+		},
+		{
+			name:      "explicit dossier is not reported",
+			targets:   []entity.Target{{Name: "Someone Else", Country: "UA", DossierID: &existingID}},
+			wantLinks: []*entity.DossierLink{nil},
+		},
+		{
+			name:    "missing explicit dossier",
+			targets: []entity.Target{{Name: "Someone Else", Country: "UA", DossierID: &missingID}},
+			wantErr: config.ErrDossierNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := service{dossierRepo: &fakeDossiers{dossiers: []entity.Dossier{
+				{ID: 1, Name: "Ivan Petrov", Country: "UA"},
+			}}}
+
+			err := s.linkDossiers(context.Background(), nil, tt.targets)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for i, target := range tt.targets {
+				if !reflect.DeepEqual(target.DossierLink, tt.wantLinks[i]) {
+					t.Errorf("target %d link = %+v, want %+v", i, target.DossierLink, tt.wantLinks[i])
+				}
+				if target.DossierID == nil {
+					t.Errorf("target %d has no dossier", i)
+				}
+			}
+		})
+	}
+}
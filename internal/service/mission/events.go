@@ -0,0 +1,29 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/service/outbox"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// record writes the mission event to the outbox in tx, so it is published
+// if and only if the change it describes is committed.
+func (s service) record(ctx context.Context, tx *gorm.DB, eventType string, missionID uint, data any) error {
+	event, err := outbox.NewEvent(eventType, "mission", missionID, data)
+	if err != nil {
+		return config.Wrap(config.CodeInternal, "build event", err)
+	}
+	if err = s.outboxRepo.CreateEvents(ctx, tx, event); err != nil {
+		return config.DBError(fmt.Errorf("record %s event: %w", eventType, err))
+	}
+	return nil
+}
+
+func (s service) recordAssigned(ctx context.Context, tx *gorm.DB, missionID uint, catID *uint) error {
+	return s.record(ctx, tx, config.EventMissionAssigned, missionID,
+		response.MissionEvent{MissionID: missionID, CatID: catID})
+}
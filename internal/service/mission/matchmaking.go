@@ -0,0 +1,203 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/country"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Candidate score weights.
+const (
+	scorePerExperienceYear = 2
+	maxExperienceScore     = 20
+	scorePerLanguage       = 10
+	scoreHomeCountry       = 8
+	scorePerCountryTarget  = 4
+	maxCountryTargetsScore = 12 // per country
+	scorePerActiveMission  = -15
+	scorePerClearanceLevel = 3
+)
+
+// GetCandidates ranks active cats available today for the mission, best first.
+func (s service) GetCandidates(ctx context.Context, missionID uint) ([]response.Candidate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return nil, config.ErrMissionNotFound
+	}
+	return s.rankCandidates(ctx, mission)
+}
+
+// AutoAssign assigns the best candidate to the mission and returns it.
+func (s service) AutoAssign(ctx context.Context, missionID uint) (response.Candidate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Candidate{}, err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return response.Candidate{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return response.Candidate{}, config.ErrMissionNotFound
+	}
+	if mission.CatID != nil {
+		return response.Candidate{}, config.ErrMissionAlreadyAssigned
+	}
+
+	candidates, err := s.rankCandidates(ctx, mission)
+	if err != nil {
+		return response.Candidate{}, err
+	}
+	if len(candidates) == 0 {
+		return response.Candidate{}, config.ErrNoCandidates
+	}
+
+	best := candidates[0]
+	if err = s.AssignCat(ctx, missionID, best.Cat.ID); err != nil {
+		return response.Candidate{}, err
+	}
+	return best, nil
+}
+
+func (s service) rankCandidates(ctx context.Context, mission entity.Mission) ([]response.Candidate, error) {
+	cats, err := s.catRepo.GetCats(ctx, "")
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get cats: %w", err))
+	}
+	if err = s.catRepo.GetCatProfiles(ctx, cats); err != nil {
+		return nil, config.DBError(fmt.Errorf("get cat profiles: %w", err))
+	}
+
+	missions, err := s.repo.GetMissions(ctx, entity.MissionFilter{})
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get missions: %w", err))
+	}
+
+	// completed targets per cat and country, and missions in progress per cat
+	history := make(map[uint]map[string]int)
+	workload := make(map[uint]int)
+	for _, m := range missions {
+		if m.CatID == nil || m.ID == mission.ID {
+			continue
+		}
+		if !m.IsCompleted {
+			workload[*m.CatID]++
+		}
+		for _, t := range m.Targets {
+			if !t.IsCompleted {
+				continue
+			}
+			if history[*m.CatID] == nil {
+				history[*m.CatID] = make(map[string]int)
+			}
+			history[*m.CatID][countryCode(t.Country)]++
+		}
+	}
+
+	countries := make([]string, 0, len(mission.Targets))
+	seen := make(map[string]bool)
+	for _, t := range mission.Targets {
+		code := countryCode(t.Country)
+		if !seen[code] {
+			seen[code] = true
+			countries = append(countries, code)
+		}
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	candidates := make([]response.Candidate, 0, len(cats))
+	for _, cat := range cats {
+		if cat.Status != config.CatStatusActive || onLeave(cat, today) {
+			continue
+		}
+
+		b := score(cat, countries, history[cat.ID], workload[cat.ID])
+		candidates = append(candidates, response.Candidate{
+			Cat:       dto.CatToResponse(cat),
+			Score:     b.Total(),
+			Breakdown: b,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Cat.ID < candidates[j].Cat.ID
+	})
+	return candidates, nil
+}
+
+func score(cat entity.Cat, countries []string, history map[string]int, workload int) response.ScoreBreakdown {
+	b := response.ScoreBreakdown{
+		Experience: min(int(cat.YearsExperience)*scorePerExperienceYear, maxExperienceScore),
+		Workload:   workload * scorePerActiveMission,
+		Clearance:  int(cat.ClearanceLevel) * scorePerClearanceLevel,
+	}
+
+	speaks := make(map[string]bool, len(cat.Languages))
+	for _, l := range cat.Languages {
+		speaks[l.Language] = true
+	}
+
+	for _, country := range countries {
+		if lang := countryLanguage(country); lang != "" && speaks[lang] {
+			b.Languages += scorePerLanguage
+		}
+		if country == cat.HomeCountry {
+			b.Countries += scoreHomeCountry
+		}
+		b.Countries += min(history[country]*scorePerCountryTarget, maxCountryTargetsScore)
+	}
+	return b
+}
+
+func onLeave(cat entity.Cat, day time.Time) bool {
+	for _, l := range cat.Leaves {
+		if !day.Before(l.StartsOn) && !day.After(l.EndsOn) {
+			return true
+		}
+	}
+	return false
+}
+
+// countryCode resolves the country to the alpha-2 code, unknown
+// countries (not normalized legacy rows) are returned uppercased.
+func countryCode(name string) string {
+	if code, ok := country.Normalize(name); ok {
+		return code
+	}
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// countryLanguage returns the most likely ISO 639-1 language spoken in the country.
+func countryLanguage(code string) string {
+	region, err := language.ParseRegion(code)
+	if err != nil {
+		return ""
+	}
+	tag, err := language.Compose(language.Und, region)
+	if err != nil {
+		return ""
+	}
+	base, confidence := tag.Base()
+	if confidence < language.High {
+		return ""
+	}
+	return base.String()
+}
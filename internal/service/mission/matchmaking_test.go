@@ -0,0 +1,154 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	speaks := func(langs ...string) []entity.CatLanguage {
+		res := make([]entity.CatLanguage, 0, len(langs))
+		for _, l := range langs {
+			res = append(res, entity.CatLanguage{Language: l})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name      string
+		cat       entity.Cat
+		countries []string
+		history   map[string]int
+		workload  int
+		want      response.ScoreBreakdown
+	}{
+		{
+			name: "rookie without profile",
+			cat:  entity.Cat{},
+			want: response.ScoreBreakdown{},
+		},
+		{
+			name: "experience and clearance",
+			cat:  entity.Cat{YearsExperience: 4, ClearanceLevel: 3},
+			want: response.ScoreBreakdown{Experience: 8, Clearance: 9},
+		},
+		{
+			name: "experience is capped",
+			cat:  entity.Cat{YearsExperience: 15},
+			want: response.ScoreBreakdown{Experience: 20},
+		},
+		{
+			name:      "languages of the target countries",
+			cat:       entity.Cat{Languages: speaks("uk", "fr", "ja")},
+			countries: []string{"UA", "FR", "DE"},
+			want:      response.ScoreBreakdown{Languages: 20},
+		},
+		{
+			name:      "home country",
+			cat:       entity.Cat{HomeCountry: "FR"},
+			countries: []string{"UA", "FR"},
+			want:      response.ScoreBreakdown{Countries: 8},
+		},
+		{
+			name:      "past targets are capped per country",
+			cat:       entity.Cat{},
+			countries: []string{"UA", "FR", "DE"},
+			history:   map[string]int{"UA": 2, "FR": 5, "GB": 3},
+			want:      response.ScoreBreakdown{Countries: 8 + 12},
+		},
+		{
+			name:     "missions in progress are penalized",
+			cat:      entity.Cat{YearsExperience: 10},
+			workload: 2,
+			want:     response.ScoreBreakdown{Experience: 20, Workload: -30},
+		},
+		{
+			name: "everything",
+			cat: entity.Cat{
+				YearsExperience: 5,
+				ClearanceLevel:  2,
+				HomeCountry:     "UA",
+				Languages:       speaks("uk"),
+			},
+			countries: []string{"UA"},
+			history:   map[string]int{"UA": 1},
+			workload:  1,
+			want:      response.ScoreBreakdown{Experience: 10, Languages: 10, Countries: 8 + 4, Workload: -15, Clearance: 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := score(tt.cat, tt.countries, tt.history, tt.workload); got != tt.want {
+				t.Errorf("score = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnLeave(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	cat := entity.Cat{Leaves: []entity.CatLeave{
+		{StartsOn: day(5), EndsOn: day(7)},
+		{StartsOn: day(20), EndsOn: day(20)},
+	}}
+
+	tests := []struct {
+		day  int
+		want bool
+	}{
+		{4, false},
+		{5, true},
+		{6, true},
+		{7, true},
+		{8, false},
+		{20, true},
+		{21, false},
+	}
+
+	for _, tt := range tests {
+		if got := onLeave(cat, day(tt.day)); got != tt.want {
+			t.Errorf("onLeave(March %d) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestCountryCode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"GB", "GB"},
+		{"United Kingdom", "GB"},
+		{"ukr", "UA"},
+		{" atlantis ", "ATLANTIS"},
+	}
+
+	for _, tt := range tests {
+		if got := countryCode(tt.in); got != tt.want {
+			t.Errorf("countryCode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCountryLanguage(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"UA", "uk"},
+		{"FR", "fr"},
+		{"JP", "ja"},
+		{"BR", "pt"},
+		{"ATLANTIS", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := countryLanguage(tt.code); got != tt.want {
+			t.Errorf("countryLanguage(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
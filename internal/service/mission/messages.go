@@ -0,0 +1,94 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetMessages returns the mission thread after afterID, oldest first.
+func (s service) GetMessages(ctx context.Context, missionID, afterID uint) (response.Thread, error) {
+	mission, err := s.getAuthorizedMission(ctx, missionID)
+	if err != nil {
+		return response.Thread{}, err
+	}
+
+	messages, err := s.messageRepo.GetMessages(ctx, missionID, afterID)
+	if err != nil {
+		return response.Thread{}, config.DBError(fmt.Errorf("get messages: %w", err))
+	}
+	return response.Thread{
+		Archived: mission.IsCompleted,
+		Messages: dto.MessagesToResponse(messages),
+	}, nil
+}
+
+// PostMessage adds a message by the caller to the thread, which is
+// archived read-only once the mission is completed.
+func (s service) PostMessage(ctx context.Context, body request.Message, missionID uint) (response.Message, error) {
+	mission, err := s.getAuthorizedMission(ctx, missionID)
+	if err != nil {
+		return response.Message{}, err
+	}
+	if mission.IsCompleted {
+		return response.Message{}, config.ErrThreadArchived
+	}
+
+	caller := auth.FromContext(ctx)
+	message := dto.MessageToEntity(body, missionID)
+	message.AuthorRole = caller.Role
+	if !caller.IsHandler() {
+		message.AuthorCatID = &caller.CatID
+	}
+
+	message, err = s.messageRepo.CreateMessage(ctx, message)
+	if err != nil {
+		return response.Message{}, config.DBError(fmt.Errorf("create message: %w", err))
+	}
+
+	s.notify(ctx, response.MissionUpdate{
+		Type:      config.UpdateMessagePosted,
+		MissionID: missionID,
+		MessageID: &message.ID,
+		CatID:     mission.CatID,
+	})
+	return dto.MessageToResponse(message), nil
+}
+
+// MarkMessagesRead records the caller side read the messages of the other
+// side up to the given one, returning how many were newly marked.
+func (s service) MarkMessagesRead(ctx context.Context, body request.MarkRead, missionID uint) (int64, error) {
+	if _, err := s.getAuthorizedMission(ctx, missionID); err != nil {
+		return 0, err
+	}
+
+	marked, err := s.messageRepo.MarkRead(ctx, missionID, body.UpToID, auth.FromContext(ctx).Role, time.Now())
+	if err != nil {
+		return 0, config.DBError(fmt.Errorf("mark messages read: %w", err))
+	}
+	return marked, nil
+}
+
+func (s service) GetMessageAttachment(ctx context.Context, missionID, messageID, attachmentID uint) (response.AttachmentFile, error) {
+	if _, err := s.getAuthorizedMission(ctx, missionID); err != nil {
+		return response.AttachmentFile{}, err
+	}
+
+	attachment, err := s.messageRepo.GetAttachment(ctx, missionID, messageID, attachmentID)
+	if err != nil {
+		return response.AttachmentFile{}, config.DBError(fmt.Errorf("get attachment: %w", err))
+	}
+	if attachment.ID == 0 {
+		return response.AttachmentFile{}, config.ErrAttachmentNotFound
+	}
+	return response.AttachmentFile{
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Data:        attachment.Data,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/api/caller"
+	request "backend/pkg/api/request/cat"
+	"backend/pkg/eventbus"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMissions serves the missions by ID, the other repo methods are not implemented.
+type fakeMissions struct {
+	repo
+	missions map[uint]entity.Mission
+}
+
+func (r fakeMissions) GetMission(_ context.Context, missionID uint) (entity.Mission, error) {
+	return r.missions[missionID], nil
+}
+
+type markRead struct {
+	missionID, upToID uint
+	readerRole        string
+}
+
+type fakeMessages struct {
+	messageRepo
+	created []entity.Message
+	marked  []markRead
+}
+
+func (r *fakeMessages) CreateMessage(_ context.Context, message entity.Message) (entity.Message, error) {
+	message.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, message)
+	return message, nil
+}
+
+func (r *fakeMessages) MarkRead(_ context.Context, missionID, upToID uint, readerRole string, _ time.Time) (int64, error) {
+	r.marked = append(r.marked, markRead{missionID, upToID, readerRole})
+	return 1, nil
+}
+
+func newMessageService(messages *fakeMessages) (service, *eventbus.Bus) {
+	catID := uint(9)
+	bus := eventbus.New(4)
+	return service{
+		repo: fakeMissions{missions: map[uint]entity.Mission{
+			1: {ID: 1, CatID: &catID},
+			2: {ID: 2, CatID: &catID, IsCompleted: true},
+			3: {ID: 3},
+		}},
+		messageRepo: messages,
+		bus:         bus,
+		l:           slog.New(slog.DiscardHandler),
+	}, bus
+}
+
+func TestPostMessage(t *testing.T) {
+	handler := auth.Caller{Role: caller.RoleHandler}
+	cat := auth.Caller{Role: caller.RoleCat, CatID: 9}
+	otherCat := auth.Caller{Role: caller.RoleCat, CatID: 5}
+	catID := uint(9)
+
+	tests := []struct {
+		name       string
+		caller     auth.Caller
+		missionID  uint
+		wantErr    error
+		wantAuthor *uint
+	}{
+		{name: "handler", caller: handler, missionID: 1},
+		{name: "assigned cat", caller: cat, missionID: 1, wantAuthor: &catID},
+		{name: "other cat", caller: otherCat, missionID: 1, wantErr: config.ErrMissionAccessDenied},
+		{name: "cat on an unassigned mission", caller: cat, missionID: 3, wantErr: config.ErrMissionAccessDenied},
+		{name: "archived thread", caller: handler, missionID: 2, wantErr: config.ErrThreadArchived},
+		{name: "missing mission", caller: handler, missionID: 4, wantErr: config.ErrMissionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := &fakeMessages{}
+			s, bus := newMessageService(messages)
+			defer bus.Close()
+			updates := bus.Subscribe(missionTopic(tt.missionID))
+
+			ctx := auth.WithCaller(context.Background(), tt.caller)
+			res, err := s.PostMessage(ctx, request.Message{
+				Body:        "on my way",
+				Attachments: []request.Attachment{{Filename: "map.png", ContentType: "image/png", Data: []byte("png")}},
+			}, tt.missionID)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(messages.created) != 0 {
+					t.Errorf("created %+v", messages.created)
+				}
+				return
+			}
+
+			if len(messages.created) != 1 {
+				t.Fatalf("created %d messages", len(messages.created))
+			}
+			created := messages.created[0]
+			if created.AuthorRole != tt.caller.Role || !equalID(created.AuthorCatID, tt.wantAuthor) {
+				t.Errorf("author = %s %v, want %s %v", created.AuthorRole, created.AuthorCatID, tt.caller.Role, tt.wantAuthor)
+			}
+			if len(res.Attachments) != 1 || res.Attachments[0].Filename != "map.png" || res.Attachments[0].Size != 3 {
+				t.Errorf("attachments = %+v", res.Attachments)
+			}
+
+			select {
+			case update := <-updates.C:
+				if !strings.Contains(string(update), `"type":"`+config.UpdateMessagePosted+`"`) {
+					t.Errorf("update = %s", update)
+				}
+			default:
+				t.Error("no update published")
+			}
+		})
+	}
+}
+
+func TestMarkMessagesRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		caller   auth.Caller
+		wantErr  error
+		wantRole string
+	}{
+		{name: "handler receipt", caller: auth.Caller{Role: caller.RoleHandler}, wantRole: caller.RoleHandler},
+		{name: "cat receipt", caller: auth.Caller{Role: caller.RoleCat, CatID: 9}, wantRole: caller.RoleCat},
+		{name: "other cat", caller: auth.Caller{Role: caller.RoleCat, CatID: 5}, wantErr: config.ErrMissionAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := &fakeMessages{}
+			s, bus := newMessageService(messages)
+			defer bus.Close()
+
+			ctx := auth.WithCaller(context.Background(), tt.caller)
+			_, err := s.MarkMessagesRead(ctx, request.MarkRead{UpToID: 4}, 1)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			var want []markRead
+			if tt.wantErr == nil {
+				want = []markRead{{missionID: 1, upToID: 4, readerRole: tt.wantRole}}
+			}
+			if len(messages.marked) != len(want) || (len(want) == 1 && messages.marked[0] != want[0]) {
+				t.Errorf("marked = %+v, want %+v", messages.marked, want)
+			}
+		})
+	}
+}
+
+func equalID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,50 @@
+package cat
+
+import (
+	"backend/config"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+// GetMissionType returns the type missions are validated against, the
+// default type if typeID is nil.
+func (s service) GetMissionType(ctx context.Context, typeID *uint) (response.MissionType, error) {
+	var (
+		missionType entity.MissionType
+		err         error
+	)
+	if typeID == nil {
+		missionType, err = s.typeRepo.GetMissionTypeByName(ctx, config.DefaultMissionType)
+	} else {
+		missionType, err = s.typeRepo.GetMissionType(ctx, *typeID)
+	}
+	if err != nil {
+		return response.MissionType{}, config.DBError(fmt.Errorf("get mission type: %w", err))
+	}
+	if missionType.ID == 0 {
+		if typeID == nil {
+			return response.MissionType{}, config.ErrMissionTypeNotFound.WithDetail(config.DefaultMissionType)
+		}
+		return response.MissionType{}, config.ErrMissionTypeNotFound
+	}
+	return dto.MissionTypeToResponse(missionType), nil
+}
+
+// getMissionTypeByName returns the type of a bundle, the default type if name is empty.
+func (s service) getMissionTypeByName(ctx context.Context, name string) (response.MissionType, error) {
+	if name == "" {
+		return s.GetMissionType(ctx, nil)
+	}
+
+	missionType, err := s.typeRepo.GetMissionTypeByName(ctx, name)
+	if err != nil {
+		return response.MissionType{}, config.DBError(fmt.Errorf("get mission type: %w", err))
+	}
+	if missionType.ID == 0 {
+		return response.MissionType{}, config.ErrMissionTypeNotFound.WithDetail(name)
+	}
+	return dto.MissionTypeToResponse(missionType), nil
+}
@@ -0,0 +1,64 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+// UpdateMissionSchedule replaces the priority and dates of an incomplete mission.
+func (s service) UpdateMissionSchedule(ctx context.Context, body request.Schedule, missionID uint) (response.Mission, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Mission{}, err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return response.Mission{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return response.Mission{}, config.ErrMissionNotFound
+	}
+	if mission.IsCompleted {
+		return response.Mission{}, config.ErrMissionAlreadyComplete
+	}
+
+	if err = s.repo.UpdateMissionSchedule(ctx, dto.ScheduleToMission(body, missionID)); err != nil {
+		return response.Mission{}, config.DBError(fmt.Errorf("update mission schedule: %w", err))
+	}
+	return s.GetMission(ctx, missionID)
+}
+
+// UpdateTargetSchedule replaces the priority and dates of an incomplete target.
+func (s service) UpdateTargetSchedule(ctx context.Context, body request.Schedule, targetID, missionID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return config.ErrMissionNotFound
+	}
+	if mission.IsCompleted {
+		return config.ErrMissionAlreadyComplete
+	}
+
+	target, err := s.targetRepo.GetTargetByID(ctx, targetID, missionID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get target: %w", err))
+	}
+	if target.ID == 0 {
+		return config.ErrTargetNotFound
+	}
+	if target.IsCompleted {
+		return config.ErrTargetAlreadyComplete
+	}
+
+	err = s.targetRepo.UpdateTargetSchedule(ctx, dto.ScheduleToTarget(body, targetID, missionID))
+	return config.DBError(err)
+}
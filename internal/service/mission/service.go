@@ -2,166 +2,388 @@ package cat
 
 import (
 	"backend/config"
-	request "backend/internal/controller/http/request/cat"
-	response "backend/internal/controller/http/response/cat"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	"backend/internal/service/bonus"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
 	"context"
 	"fmt"
+
+	"gorm.io/gorm"
 )
 
-func (s service) GetMissions(ctx context.Context) ([]response.Mission, config.ServiceCode, error) {
-	missions, err := s.repo.GetMissions(ctx)
-	return response.MissionsToResponse(missions), config.DBErrToServiceCode(err), err
+// GetMissions returns the filtered missions, cats get their own missions only.
+func (s service) GetMissions(ctx context.Context, query request.MissionFilter) ([]response.Mission, error) {
+	if err := auth.RequireCaller(ctx); err != nil {
+		return nil, err
+	}
+	filter, err := dto.MissionFilterToEntity(query)
+	if err != nil {
+		return nil, err
+	}
+	if caller := auth.FromContext(ctx); !caller.IsHandler() {
+		filter.CatID = &caller.CatID
+	}
+
+	missions, err := s.repo.GetMissions(ctx, filter)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get missions: %w", err))
+	}
+	return dto.MissionsToResponse(missions), nil
+}
+
+func (s service) GetMission(ctx context.Context, missionID uint) (response.Mission, error) {
+	mission, err := s.getAuthorizedMission(ctx, missionID)
+	if err != nil {
+		return response.Mission{}, err
+	}
+	return dto.MissionToResponse(mission), nil
 }
 
-func (s service) GetMission(ctx context.Context, missionID uint) (response.Mission, config.ServiceCode, error) {
+// getAuthorizedMission returns the mission if the caller may access it,
+// handlers any mission and cats their own missions only.
+func (s service) getAuthorizedMission(ctx context.Context, missionID uint) (entity.Mission, error) {
 	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return entity.Mission{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
 	if mission.ID == 0 {
-		return response.Mission{}, config.CodeNotFound, config.ErrMissionNotFound
+		return entity.Mission{}, config.ErrMissionNotFound
 	}
-	return response.MissionToResponse(mission), config.DBErrToServiceCode(err), err
+	return mission, auth.RequireMission(ctx, mission.CatID)
 }
 
-func (s service) CreateMission(ctx context.Context, mission request.Mission) (response.Mission, config.ServiceCode, error) {
+func (s service) CreateMission(ctx context.Context, mission request.Mission) (response.Mission, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Mission{}, err
+	}
 	tx := s.repo.NewTransaction(ctx)
 	defer tx.Rollback()
 
-	missionEntity := mission.ToEntity()
+	missionEntity := dto.MissionToEntity(mission)
 	createdMission, err := s.repo.CreateMission(ctx, tx, missionEntity)
 	if err != nil {
-		return response.Mission{}, config.DBErrToServiceCode(err), fmt.Errorf("create mission err: %v", err)
+		return response.Mission{}, config.DBError(fmt.Errorf("create mission: %w", err))
 	}
 
 	if mission.CatID != nil {
 		cat, err := s.catRepo.GetCatByID(ctx, *mission.CatID)
 		if err != nil {
-			return response.Mission{}, config.DBErrToServiceCode(err), fmt.Errorf("get cat err: %v", err)
+			return response.Mission{}, config.DBError(fmt.Errorf("get cat: %w", err))
 		}
 		if cat.ID == 0 {
-			return response.Mission{}, config.CodeNotFound, config.ErrCatNotFound
+			return response.Mission{}, config.ErrCatNotFound
+		}
+		if cat.Status != config.CatStatusActive {
+			return response.Mission{}, config.ErrCatNotActive.WithDetail(cat.Status)
 		}
 	}
 
-	targets := mission.Targets.ToEntity(createdMission.ID)
+	targets := dto.TargetsToEntity(mission.Targets, createdMission.ID)
+	if err = s.linkDossiers(ctx, tx, targets); err != nil {
+		return response.Mission{}, err
+	}
 	createdTargets, err := s.targetRepo.CreateTargets(ctx, tx, targets)
 	if err != nil {
-		return response.Mission{}, config.DBErrToServiceCode(err), fmt.Errorf("create mission targets err: %v", err)
+		return response.Mission{}, config.DBError(fmt.Errorf("create mission targets: %w", err))
 	}
 
 	createdMission.Targets = createdTargets
 
-	err = tx.Commit().Error
-	return response.MissionToResponse(createdMission), config.DBErrToServiceCode(err), err
+	if createdMission.CatID != nil {
+		if err = s.recordAssigned(ctx, tx, createdMission.ID, createdMission.CatID); err != nil {
+			return response.Mission{}, err
+		}
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return response.Mission{}, config.DBError(fmt.Errorf("commit mission: %w", err))
+	}
+	return dto.MissionToResponse(createdMission), nil
 }
 
-func (s service) AssignCat(ctx context.Context, missionID, catID uint) (config.ServiceCode, error) {
+func (s service) AssignCat(ctx context.Context, missionID, catID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
 	mission, err := s.repo.GetMission(ctx, missionID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get mission err: %v", err)
+		return config.DBError(fmt.Errorf("get mission: %w", err))
 	}
 	if mission.ID == 0 {
-		return config.CodeNotFound, config.ErrMissionNotFound
+		return config.ErrMissionNotFound
 	}
 	if mission.CatID != nil {
-		return config.CodeForbidden, config.ErrMissionAlreadyAssigned
+		return config.ErrMissionAlreadyAssigned
 	}
 
 	cat, err := s.catRepo.GetCatByID(ctx, catID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get cat err: %v", err)
+		return config.DBError(fmt.Errorf("get cat: %w", err))
 	}
 	if cat.ID == 0 {
-		return config.CodeNotFound, config.ErrCatNotFound
+		return config.ErrCatNotFound
 	}
 
-	err = s.repo.AssignCat(ctx, missionID, catID)
-	return config.DBErrToServiceCode(err), err
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	if err = s.assignCat(ctx, tx, missionID, cat); err != nil {
+		return err
+	}
+	if err = tx.Commit().Error; err != nil {
+		return config.DBError(err)
+	}
+
+	s.notify(ctx, response.MissionUpdate{Type: config.EventMissionAssigned, MissionID: missionID, CatID: &catID})
+	return nil
 }
 
-func (s service) CompleteMission(ctx context.Context, missionID uint) (config.ServiceCode, error) {
-	err := s.repo.CompleteMission(ctx, missionID)
-	return config.DBErrToServiceCode(err), err
+// assignCat assigns the cat to the mission in tx if the cat is active and the
+// mission still unassigned, and records the assignment event.
+func (s service) assignCat(ctx context.Context, tx *gorm.DB, missionID uint, cat entity.Cat) error {
+	if cat.Status != config.CatStatusActive {
+		return config.ErrCatNotActive.WithDetail(cat.Status)
+	}
+
+	assigned, err := s.repo.AssignCat(ctx, tx, missionID, cat.ID)
+	if err != nil {
+		return config.DBError(err)
+	}
+	if !assigned {
+		// assigned concurrently since the check above
+		return config.ErrMissionAlreadyAssigned
+	}
+	return s.recordAssigned(ctx, tx, missionID, &cat.ID)
+}
+
+// CompleteMission completes the mission and writes the bonuses earned by its
+// cat to the ledger in the same transaction. The mission, its targets and the
+// bonus rules are locked while the bonuses are calculated.
+func (s service) CompleteMission(ctx context.Context, missionID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	mission, err := s.repo.LockMission(ctx, tx, missionID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("lock mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return config.ErrMissionNotFound
+	}
+	if mission.IsCompleted {
+		return config.ErrMissionAlreadyComplete
+	}
+
+	rules, err := s.bonusRepo.LockBonusRules(ctx, tx)
+	if err != nil {
+		return config.DBError(fmt.Errorf("lock bonus rules: %w", err))
+	}
+	entries, err := bonus.Calculate(rules, mission)
+	if err != nil {
+		return config.Wrap(config.CodeInternal, "calculate bonus", err)
+	}
+
+	completed, err := s.repo.CompleteMission(ctx, tx, missionID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("complete mission: %w", err))
+	}
+	if !completed {
+		// completed concurrently
+		return config.ErrMissionAlreadyComplete
+	}
+	if len(entries) > 0 {
+		if err = s.bonusRepo.CreateBonusEntries(ctx, tx, entries); err != nil {
+			return config.DBError(fmt.Errorf("create bonus entries: %w", err))
+		}
+	}
+
+	err = s.record(ctx, tx, config.EventMissionCompleted, missionID,
+		response.MissionEvent{MissionID: missionID, CatID: mission.CatID})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return config.DBError(fmt.Errorf("commit mission completion: %w", err))
+	}
+
+	s.notify(ctx, response.MissionUpdate{Type: config.EventMissionCompleted, MissionID: missionID, CatID: mission.CatID})
+	return nil
 }
 
-func (s service) DeleteMission(ctx context.Context, missionID uint) (config.ServiceCode, error) {
+func (s service) DeleteMission(ctx context.Context, missionID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
 	mission, err := s.repo.GetMission(ctx, missionID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get mission err: %v", err)
+		return config.DBError(fmt.Errorf("get mission: %w", err))
 	}
 	if mission.ID == 0 {
-		return config.CodeNotFound, config.ErrMissionNotFound
+		return config.ErrMissionNotFound
 	}
 	if mission.CatID != nil {
-		return config.CodeForbidden, config.ErrMissionAlreadyAssigned
+		return config.ErrMissionAlreadyAssigned
 	}
 
 	err = s.repo.DeleteMission(ctx, missionID)
-	return config.DBErrToServiceCode(err), err
+	return config.DBError(err)
 }
 
-func (s service) CreateTarget(ctx context.Context, body request.Target, missionID uint) (config.ServiceCode, error) {
+func (s service) CreateTarget(ctx context.Context, body request.Target, missionID uint) (response.Target, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Target{}, err
+	}
 	mission, err := s.repo.GetMission(ctx, missionID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get mission err: %v", err)
+		return response.Target{}, config.DBError(fmt.Errorf("get mission: %w", err))
 	}
 	if mission.ID == 0 {
-		return config.CodeNotFound, config.ErrMissionNotFound
+		return response.Target{}, config.ErrMissionNotFound
 	}
 	if mission.IsCompleted {
-		return config.CodeForbidden, config.ErrMissionAlreadyAssigned
+		return response.Target{}, config.ErrMissionAlreadyComplete
 	}
-	if len(mission.Targets) == config.MaxMissionTargets {
-		return config.CodeForbidden, config.ErrMissionHasMaxTargets
+
+	missionType, err := s.GetMissionType(ctx, mission.TypeID)
+	if err != nil {
+		return response.Target{}, err
+	}
+	if len(mission.Targets) >= missionType.MaxTargets {
+		return response.Target{}, config.ErrMissionHasMaxTargets.WithDetail(
+			fmt.Sprintf("%s missions have at most %d targets", missionType.Name, missionType.MaxTargets))
+	}
+	if err = dto.ValidateTargetCountry(body, missionType); err != nil {
+		return response.Target{}, err
 	}
 
-	target := body.ToEntity(missionID)
-	err = s.targetRepo.CreateTarget(ctx, target)
-	return config.DBErrToServiceCode(err), err
-}
+	targets := []entity.Target{dto.TargetToEntity(body, missionID)}
 
-func (s service) UpdateTarget(ctx context.Context, body request.UpdateTarget, targetID, missionID uint) (config.ServiceCode, error) {
-	mission, err := s.repo.GetMission(ctx, missionID)
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	if err = s.linkDossiers(ctx, tx, targets); err != nil {
+		return response.Target{}, err
+	}
+	created, err := s.targetRepo.CreateTargets(ctx, tx, targets)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get mission err: %v", err)
+		return response.Target{}, config.DBError(fmt.Errorf("create target: %w", err))
 	}
-	if mission.ID == 0 {
-		return config.CodeNotFound, config.ErrMissionNotFound
+	if err = tx.Commit().Error; err != nil {
+		return response.Target{}, config.DBError(fmt.Errorf("commit target: %w", err))
+	}
+	return dto.TargetToResponse(created[0]), nil
+}
+
+func (s service) UpdateTarget(ctx context.Context, body request.UpdateTarget, targetID, missionID uint) error {
+	mission, err := s.getAuthorizedMission(ctx, missionID)
+	if err != nil {
+		return err
 	}
 	if mission.IsCompleted {
-		return config.CodeForbidden, config.ErrMissionAlreadyComplete
+		return config.ErrMissionAlreadyComplete
 	}
 
 	target, err := s.targetRepo.GetTargetByID(ctx, targetID, missionID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get target err: %v", err)
+		return config.DBError(fmt.Errorf("get target: %w", err))
 	}
 	if target.ID == 0 {
-		return config.CodeNotFound, config.ErrTargetNotFound
+		return config.ErrTargetNotFound
 	}
 	if target.IsCompleted {
-		return config.CodeForbidden, config.ErrTargetAlreadyComplete
+		return config.ErrTargetAlreadyComplete
 	}
 
-	err = s.targetRepo.UpdateTarget(ctx, body.ToEntity(targetID, missionID))
-	return config.DBErrToServiceCode(err), err
+	if err = s.targetRepo.UpdateTarget(ctx, dto.UpdateTargetToEntity(body, targetID, missionID)); err != nil {
+		return config.DBError(err)
+	}
+
+	s.notify(ctx, response.MissionUpdate{
+		Type:      config.UpdateTargetNotes,
+		MissionID: missionID,
+		TargetID:  &targetID,
+		CatID:     mission.CatID,
+		Notes:     &body.Notes,
+	})
+	return nil
 }
 
-func (s service) CompleteTarget(ctx context.Context, targetID, missionID uint) (config.ServiceCode, error) {
-	err := s.targetRepo.CompleteTarget(ctx, targetID, missionID)
-	return config.DBErrToServiceCode(err), err
+// CompleteTarget completes the target, checking its evidence if required
+// with the target locked.
+func (s service) CompleteTarget(ctx context.Context, targetID, missionID uint) error {
+	mission, err := s.getAuthorizedMission(ctx, missionID)
+	if err != nil {
+		return err
+	}
+
+	tx := s.repo.NewTransaction(ctx)
+	defer tx.Rollback()
+
+	target, err := s.targetRepo.LockTarget(ctx, tx, targetID, missionID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("lock target: %w", err))
+	}
+	if target.ID == 0 {
+		return config.ErrTargetNotFound
+	}
+	if target.IsCompleted {
+		return nil
+	}
+
+	if s.requireEvidence {
+		count, err := s.evidenceRepo.CountEvidence(ctx, tx, targetID)
+		if err != nil {
+			return config.DBError(fmt.Errorf("count evidence: %w", err))
+		}
+		if count == 0 {
+			return config.ErrEvidenceRequired
+		}
+	}
+
+	if err = s.targetRepo.CompleteTarget(ctx, tx, targetID, missionID); err != nil {
+		return config.DBError(err)
+	}
+	err = s.record(ctx, tx, config.EventTargetCompleted, missionID,
+		response.TargetEvent{MissionID: missionID, TargetID: targetID, CatID: mission.CatID})
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit().Error; err != nil {
+		return config.DBError(err)
+	}
+
+	s.notify(ctx, response.MissionUpdate{
+		Type:      config.EventTargetCompleted,
+		MissionID: missionID,
+		TargetID:  &targetID,
+		CatID:     mission.CatID,
+	})
+	return nil
 }
 
-func (s service) DeleteTarget(ctx context.Context, targetID, missionID uint) (config.ServiceCode, error) {
+func (s service) DeleteTarget(ctx context.Context, targetID, missionID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
 	target, err := s.targetRepo.GetTargetByID(ctx, targetID, missionID)
 	if err != nil {
-		return config.DBErrToServiceCode(err), fmt.Errorf("get target err: %v", err)
+		return config.DBError(fmt.Errorf("get target: %w", err))
 	}
 	if target.ID == 0 {
-		return config.CodeNotFound, config.ErrTargetNotFound
+		return config.ErrTargetNotFound
 	}
 	if target.IsCompleted {
-		return config.CodeForbidden, config.ErrTargetAlreadyComplete
+		return config.ErrTargetAlreadyComplete
 	}
 
 	err = s.targetRepo.DeleteTarget(ctx, targetID, missionID)
-	return config.DBErrToServiceCode(err), err
+	return config.DBError(err)
 }
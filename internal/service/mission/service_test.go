@@ -0,0 +1,323 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/api/caller"
+	request "backend/pkg/api/request/cat"
+	"backend/pkg/eventbus"
+	"backend/pkg/money"
+	"backend/pkg/postgres/postgrestest"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// filteredMissions records the filter of GetMissions.
+type filteredMissions struct {
+	fakeMissions
+	filter *entity.MissionFilter
+}
+
+func (r filteredMissions) GetMissions(_ context.Context, filter entity.MissionFilter) ([]entity.Mission, error) {
+	*r.filter = filter
+	return nil, nil
+}
+
+func TestMissionAccess(t *testing.T) {
+	catID, otherCatID := uint(9), uint(5)
+
+	tests := []struct {
+		name        string
+		caller      *auth.Caller // nil for a request without caller
+		wantGet     error        // of mission 1, assigned to cat 9
+		wantList    error
+		wantCatID   *uint // of the list filter
+		wantHandler error // of a handler-only method, not called for handlers
+	}{
+		{
+			name:        "no caller",
+			wantGet:     config.ErrInvalidCaller,
+			wantList:    config.ErrInvalidCaller,
+			wantHandler: config.ErrInvalidCaller,
+		},
+		{
+			name:   "handler",
+			caller: &auth.Caller{Role: caller.RoleHandler},
+		},
+		{
+			name:        "assigned cat",
+			caller:      &auth.Caller{Role: caller.RoleCat, CatID: 9},
+			wantCatID:   &catID,
+			wantHandler: config.ErrHandlerOnly,
+		},
+		{
+			name:        "other cat",
+			caller:      &auth.Caller{Role: caller.RoleCat, CatID: 5},
+			wantGet:     config.ErrMissionAccessDenied,
+			wantCatID:   &otherCatID,
+			wantHandler: config.ErrHandlerOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := &fakeMessages{}
+			s, bus := newMessageService(messages)
+			defer bus.Close()
+			var filter entity.MissionFilter
+			s.repo = filteredMissions{fakeMissions: s.repo.(fakeMissions), filter: &filter}
+
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = auth.WithCaller(ctx, *tt.caller)
+			}
+
+			if _, err := s.GetMission(ctx, 1); !errors.Is(err, tt.wantGet) || (err != nil) != (tt.wantGet != nil) {
+				t.Errorf("get: err = %v, want %v", err, tt.wantGet)
+			}
+
+			_, err := s.GetMissions(ctx, request.MissionFilter{})
+			if !errors.Is(err, tt.wantList) || (err != nil) != (tt.wantList != nil) {
+				t.Errorf("list: err = %v, want %v", err, tt.wantList)
+			}
+			if !equalID(filter.CatID, tt.wantCatID) {
+				t.Errorf("list: cat filter = %v, want %v", filter.CatID, tt.wantCatID)
+			}
+
+			if tt.wantHandler != nil {
+				if err := s.DeleteMission(ctx, 1); !errors.Is(err, tt.wantHandler) {
+					t.Errorf("delete: err = %v, want %v", err, tt.wantHandler)
+				}
+			}
+		})
+	}
+}
+
+// lockedMissions serves the missions only under lock, reading one outside a
+// transaction panics.
+type lockedMissions struct {
+	repo
+	db        *postgrestest.DB
+	missions  map[uint]entity.Mission
+	completed []uint
+}
+
+func (r *lockedMissions) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r *lockedMissions) LockMission(_ context.Context, tx *gorm.DB, missionID uint) (entity.Mission, error) {
+	if tx == nil {
+		return entity.Mission{}, errors.New("lock outside a transaction")
+	}
+	return r.missions[missionID], nil
+}
+
+func (r *lockedMissions) CompleteMission(_ context.Context, _ *gorm.DB, missionID uint) (bool, error) {
+	r.completed = append(r.completed, missionID)
+	return true, nil
+}
+
+type lockedRules struct {
+	bonusRepo
+	rules   []entity.BonusRule
+	entries []entity.BonusEntry
+}
+
+func (r *lockedRules) LockBonusRules(_ context.Context, tx *gorm.DB) ([]entity.BonusRule, error) {
+	if tx == nil {
+		return nil, errors.New("lock outside a transaction")
+	}
+	return r.rules, nil
+}
+
+func (r *lockedRules) CreateBonusEntries(_ context.Context, _ *gorm.DB, entries []entity.BonusEntry) error {
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+type fakeEvents struct {
+	outboxRepo
+	types []string
+}
+
+func (r *fakeEvents) CreateEvents(_ context.Context, _ *gorm.DB, events ...entity.Event) error {
+	for _, e := range events {
+		r.types = append(r.types, e.Type)
+	}
+	return nil
+}
+
+func TestCompleteMission(t *testing.T) {
+	catID := uint(9)
+	cat := entity.Cat{ID: catID, Name: "Tom", YearsExperience: 2}
+	rules := []entity.BonusRule{
+		{ID: 1, Kind: config.BonusPerTarget, Amount: money.New(1000, "USD")},
+		{ID: 2, Kind: config.BonusPerMission, Amount: money.New(5000, "USD")},
+	}
+
+	tests := []struct {
+		name        string
+		mission     entity.Mission // as locked
+		wantErr     error
+		wantEntries []uint // rule IDs
+		wantTxs     []string
+	}{
+		{
+			name: "bonuses of the locked targets",
+			mission: entity.Mission{ID: 1, CatID: &catID, Cat: cat, Targets: []entity.Target{
+				{ID: 4, IsCompleted: true},
+				{ID: 5},
+			}},
+			wantEntries: []uint{1, 2},
+			wantTxs:     []string{"commit"},
+		},
+		{name: "not found", wantErr: config.ErrMissionNotFound, wantTxs: []string{"rollback"}},
+		{
+			name:    "completed already",
+			mission: entity.Mission{ID: 1, CatID: &catID, Cat: cat, IsCompleted: true},
+			wantErr: config.ErrMissionAlreadyComplete,
+			wantTxs: []string{"rollback"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := postgrestest.New(t, func(postgrestest.Query) postgrestest.Result { return postgrestest.Result{} })
+			missions := &lockedMissions{db: db, missions: map[uint]entity.Mission{1: tt.mission}}
+			bonuses := &lockedRules{rules: rules}
+			events := &fakeEvents{}
+			bus := eventbus.New(4)
+			defer bus.Close()
+			s := service{repo: missions, bonusRepo: bonuses, outboxRepo: events, bus: bus, l: slog.New(slog.DiscardHandler)}
+
+			ctx := auth.WithCaller(context.Background(), auth.Caller{Role: caller.RoleHandler})
+			err := s.CompleteMission(ctx, 1)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := db.Transactions(); !reflect.DeepEqual(got, tt.wantTxs) {
+				t.Errorf("transactions = %v, want %v", got, tt.wantTxs)
+			}
+
+			var got []uint
+			for _, e := range bonuses.entries {
+				got = append(got, e.RuleID)
+			}
+			if !reflect.DeepEqual(got, tt.wantEntries) {
+				t.Errorf("entries of rules %v, want %v", got, tt.wantEntries)
+			}
+			if err == nil && (len(missions.completed) != 1 || !reflect.DeepEqual(events.types, []string{config.EventMissionCompleted})) {
+				t.Errorf("completed %v with events %v, want mission 1 once", missions.completed, events.types)
+			}
+		})
+	}
+}
+
+// checkedMissions serves the mission checked for access before the
+// transactions of lockedMissions.
+type checkedMissions struct {
+	*lockedMissions
+	mission entity.Mission
+}
+
+func (r checkedMissions) GetMission(_ context.Context, missionID uint) (entity.Mission, error) {
+	if missionID != r.mission.ID {
+		return entity.Mission{}, nil
+	}
+	return r.mission, nil
+}
+
+// lockedTargets serves the targets only under lock.
+type lockedTargets struct {
+	targetRepo
+	targets   map[uint]entity.Target
+	completed []uint
+}
+
+func (r *lockedTargets) LockTarget(_ context.Context, tx *gorm.DB, targetID, _ uint) (entity.Target, error) {
+	if tx == nil {
+		return entity.Target{}, errors.New("lock outside a transaction")
+	}
+	return r.targets[targetID], nil
+}
+
+func (r *lockedTargets) CompleteTarget(_ context.Context, _ *gorm.DB, targetID, _ uint) error {
+	r.completed = append(r.completed, targetID)
+	return nil
+}
+
+type countedEvidence struct {
+	evidenceRepo
+	counts map[uint]int64
+}
+
+func (r countedEvidence) CountEvidence(_ context.Context, tx *gorm.DB, targetID uint) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("count outside a transaction")
+	}
+	return r.counts[targetID], nil
+}
+
+func TestCompleteTarget(t *testing.T) {
+	catID := uint(9)
+	targets := map[uint]entity.Target{
+		4: {ID: 4, MissionID: 1},
+		5: {ID: 5, MissionID: 1},
+		6: {ID: 6, MissionID: 1, IsCompleted: true},
+	}
+	evidence := countedEvidence{counts: map[uint]int64{4: 2}}
+
+	tests := []struct {
+		name            string
+		targetID        uint
+		requireEvidence bool
+		wantErr         error
+		wantCompleted   bool
+		wantTxs         []string
+	}{
+		{name: "with evidence", targetID: 4, requireEvidence: true, wantCompleted: true, wantTxs: []string{"commit"}},
+		{name: "without evidence", targetID: 5, requireEvidence: true, wantErr: config.ErrEvidenceRequired, wantTxs: []string{"rollback"}},
+		{name: "evidence not required", targetID: 5, wantCompleted: true, wantTxs: []string{"commit"}},
+		{name: "completed already", targetID: 6, requireEvidence: true, wantTxs: []string{"rollback"}},
+		{name: "not found", targetID: 8, wantErr: config.ErrTargetNotFound, wantTxs: []string{"rollback"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := postgrestest.New(t, func(postgrestest.Query) postgrestest.Result { return postgrestest.Result{} })
+			locked := &lockedTargets{targets: targets}
+			events := &fakeEvents{}
+			bus := eventbus.New(4)
+			defer bus.Close()
+			s := service{
+				repo:            checkedMissions{lockedMissions: &lockedMissions{db: db}, mission: entity.Mission{ID: 1, CatID: &catID}},
+				targetRepo:      locked,
+				evidenceRepo:    evidence,
+				outboxRepo:      events,
+				bus:             bus,
+				l:               slog.New(slog.DiscardHandler),
+				requireEvidence: tt.requireEvidence,
+			}
+
+			ctx := auth.WithCaller(context.Background(), auth.Caller{Role: caller.RoleCat, CatID: catID})
+			err := s.CompleteTarget(ctx, tt.targetID, 1)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := db.Transactions(); !reflect.DeepEqual(got, tt.wantTxs) {
+				t.Errorf("transactions = %v, want %v", got, tt.wantTxs)
+			}
+			completed := len(locked.completed) == 1 && len(events.types) == 1
+			if completed != tt.wantCompleted || len(locked.completed) > 1 {
+				t.Errorf("completed %v with events %v, want completed %v", locked.completed, events.types, tt.wantCompleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package cat
+
+import (
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/eventbus"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+func missionTopic(missionID uint) string {
+	return "mission:" + strconv.FormatUint(uint64(missionID), 10)
+}
+
+// SubscribeMission returns a subscription to the live updates of the mission,
+// the caller closes it. Cats can only follow their own missions.
+func (s service) SubscribeMission(ctx context.Context, missionID uint) (*eventbus.Subscription, error) {
+	if _, err := s.getAuthorizedMission(ctx, missionID); err != nil {
+		return nil, err
+	}
+	return s.bus.Subscribe(missionTopic(missionID)), nil
+}
+
+// notify pushes the update to the mission streams after its change is
+// committed. Updates are best effort, failures are logged only.
+func (s service) notify(ctx context.Context, update response.MissionUpdate) {
+	update.OccurredAt = time.Now().UTC()
+
+	err := s.pushUpdate(ctx, update)
+	if errors.Is(err, eventbus.ErrTooLarge) && update.Notes != nil {
+		update.Notes, update.Truncated = nil, true
+		err = s.pushUpdate(ctx, update)
+	}
+	if err != nil {
+		s.l.Error("push mission update failed", "type", update.Type, "mission_id", update.MissionID, "err", err)
+	}
+}
+
+func (s service) pushUpdate(ctx context.Context, update response.MissionUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	return s.bus.Publish(ctx, missionTopic(update.MissionID), payload)
+}
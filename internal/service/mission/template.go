@@ -0,0 +1,42 @@
+package cat
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	"context"
+	"fmt"
+)
+
+// MissionFromTemplate returns the mission to create from a template, the
+// caller validates and creates it like any other mission.
+func (s service) MissionFromTemplate(ctx context.Context, body request.MissionFromTemplate, templateID uint) (request.Mission, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return request.Mission{}, err
+	}
+	template, err := s.templateRepo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return request.Mission{}, config.DBError(fmt.Errorf("get mission template: %w", err))
+	}
+	if template.ID == 0 {
+		return request.Mission{}, config.ErrTemplateNotFound
+	}
+	return dto.TemplateToMission(body, template)
+}
+
+// MissionFromClone returns the mission to create as a copy of a mission,
+// the caller validates and creates it like any other mission.
+func (s service) MissionFromClone(ctx context.Context, body request.CloneMission, missionID uint) (request.Mission, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return request.Mission{}, err
+	}
+	mission, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return request.Mission{}, config.DBError(fmt.Errorf("get mission: %w", err))
+	}
+	if mission.ID == 0 {
+		return request.Mission{}, config.ErrMissionNotFound
+	}
+	return dto.CloneToMission(body, mission), nil
+}
@@ -2,8 +2,10 @@ package cat
 
 import (
 	entity "backend/internal/entity/cat"
+	"backend/pkg/eventbus"
 	"context"
 	"log/slog"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,35 +15,92 @@ type (
 		GetDB(ctx context.Context) *gorm.DB
 		NewTransaction(ctx context.Context) *gorm.DB
 
-		GetMissions(ctx context.Context) ([]entity.Mission, error)
+		GetMissions(ctx context.Context, filter entity.MissionFilter) ([]entity.Mission, error)
 		GetMission(ctx context.Context, missionID uint) (entity.Mission, error)
+		LockMission(ctx context.Context, tx *gorm.DB, missionID uint) (entity.Mission, error)
 
 		CreateMission(ctx context.Context, tx *gorm.DB, mission entity.Mission) (entity.Mission, error)
-		AssignCat(ctx context.Context, missionID, catID uint) error
-		CompleteMission(ctx context.Context, missionID uint) error
+		AssignCat(ctx context.Context, tx *gorm.DB, missionID, catID uint) (bool, error)
+		UpdateMissionSchedule(ctx context.Context, mission entity.Mission) error
+		CompleteMission(ctx context.Context, tx *gorm.DB, missionID uint) (bool, error)
 		DeleteMission(ctx context.Context, missionID uint) error
 	}
 
 	targetRepo interface {
 		GetTargetByID(ctx context.Context, targetID, missionID uint) (entity.Target, error)
 		GetTargetsByMissionID(ctx context.Context, missionID uint) ([]entity.Target, error)
+		LockTarget(ctx context.Context, tx *gorm.DB, targetID, missionID uint) (entity.Target, error)
 
-		CreateTarget(ctx context.Context, target entity.Target) error
 		CreateTargets(ctx context.Context, tx *gorm.DB, targets []entity.Target) ([]entity.Target, error)
 		UpdateTarget(ctx context.Context, target entity.Target) error
-		CompleteTarget(ctx context.Context, targetID, missionID uint) error
+		UpdateTargetSchedule(ctx context.Context, target entity.Target) error
+		CompleteTarget(ctx context.Context, tx *gorm.DB, targetID, missionID uint) error
 		DeleteTarget(ctx context.Context, targetID, missionID uint) error
 	}
 
 	catRepo interface {
+		GetCats(ctx context.Context, breed string) ([]entity.Cat, error)
 		GetCatByID(ctx context.Context, catID uint) (entity.Cat, error)
+		GetCatByName(ctx context.Context, name string) (entity.Cat, error)
+		GetCatProfiles(ctx context.Context, cats []entity.Cat) error
+	}
+
+	bonusRepo interface {
+		LockBonusRules(ctx context.Context, tx *gorm.DB) ([]entity.BonusRule, error)
+		CreateBonusEntries(ctx context.Context, tx *gorm.DB, entries []entity.BonusEntry) error
+	}
+
+	dossierRepo interface {
+		GetDossierByID(ctx context.Context, dossierID uint) (entity.Dossier, error)
+		GetDossiersByCountry(ctx context.Context, country string) ([]entity.Dossier, error)
+		CreateDossier(ctx context.Context, tx *gorm.DB, dossier entity.Dossier) (entity.Dossier, error)
+	}
+
+	messageRepo interface {
+		GetMessages(ctx context.Context, missionID, afterID uint) ([]entity.Message, error)
+		GetAttachment(ctx context.Context, missionID, messageID, attachmentID uint) (entity.MessageAttachment, error)
+		CreateMessage(ctx context.Context, message entity.Message) (entity.Message, error)
+		MarkRead(ctx context.Context, missionID, upToID uint, readerRole string, at time.Time) (int64, error)
+	}
+
+	evidenceRepo interface {
+		CountEvidence(ctx context.Context, tx *gorm.DB, targetID uint) (int64, error)
+	}
+
+	templateRepo interface {
+		GetTemplate(ctx context.Context, templateID uint) (entity.MissionTemplate, error)
+	}
+
+	missionTypeRepo interface {
+		GetMissionType(ctx context.Context, typeID uint) (entity.MissionType, error)
+		GetMissionTypeByName(ctx context.Context, name string) (entity.MissionType, error)
+	}
+
+	outboxRepo interface {
+		CreateEvents(ctx context.Context, tx *gorm.DB, events ...entity.Event) error
+	}
+
+	bus interface {
+		Publish(ctx context.Context, topic string, payload []byte) error
+		Subscribe(topic string) *eventbus.Subscription
 	}
 
 	service struct {
-		repo       repo
-		targetRepo targetRepo
-		catRepo    catRepo
-		l          *slog.Logger
+		repo         repo
+		targetRepo   targetRepo
+		catRepo      catRepo
+		bonusRepo    bonusRepo
+		dossierRepo  dossierRepo
+		messageRepo  messageRepo
+		evidenceRepo evidenceRepo
+		templateRepo templateRepo
+		typeRepo     missionTypeRepo
+		outboxRepo   outboxRepo
+		bus          bus
+		l            *slog.Logger
+
+		// requireEvidence refuses to complete targets without evidence
+		requireEvidence bool
 	}
 )
 
@@ -49,7 +108,18 @@ func NewService(
 	repo repo,
 	targetRepo targetRepo,
 	catRepo catRepo,
+	bonusRepo bonusRepo,
+	dossierRepo dossierRepo,
+	messageRepo messageRepo,
+	evidenceRepo evidenceRepo,
+	templateRepo templateRepo,
+	typeRepo missionTypeRepo,
+	outboxRepo outboxRepo,
+	bus bus,
 	l *slog.Logger,
+	requireEvidence bool,
 ) service {
-	return service{repo, targetRepo, catRepo, l}
+	return service{
+		repo, targetRepo, catRepo, bonusRepo, dossierRepo, messageRepo,
+		evidenceRepo, templateRepo, typeRepo, outboxRepo, bus, l, requireEvidence}
 }
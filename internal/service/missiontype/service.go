@@ -0,0 +1,122 @@
+package missiontype
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (s service) GetMissionTypes(ctx context.Context) ([]response.MissionType, error) {
+	if err := auth.RequireCaller(ctx); err != nil {
+		return nil, err
+	}
+	types, err := s.repo.GetMissionTypes(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get mission types: %w", err))
+	}
+	return dto.MissionTypesToResponse(types), nil
+}
+
+func (s service) GetMissionType(ctx context.Context, typeID uint) (response.MissionType, error) {
+	if err := auth.RequireCaller(ctx); err != nil {
+		return response.MissionType{}, err
+	}
+	missionType, err := s.getMissionType(ctx, typeID)
+	if err != nil {
+		return response.MissionType{}, err
+	}
+	return dto.MissionTypeToResponse(missionType), nil
+}
+
+func (s service) CreateMissionType(ctx context.Context, body request.MissionType) (response.MissionType, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionType{}, err
+	}
+	if err := s.checkName(ctx, body.Name, 0); err != nil {
+		return response.MissionType{}, err
+	}
+
+	missionType, err := s.repo.CreateMissionType(ctx, dto.MissionTypeToEntity(body, 0))
+	if err != nil {
+		return response.MissionType{}, config.DBError(fmt.Errorf("create mission type: %w", err))
+	}
+	return dto.MissionTypeToResponse(missionType), nil
+}
+
+// UpdateMissionType replaces the type, existing missions are not validated again.
+func (s service) UpdateMissionType(ctx context.Context, body request.MissionType, typeID uint) (response.MissionType, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionType{}, err
+	}
+	missionType, err := s.getMissionType(ctx, typeID)
+	if err != nil {
+		return response.MissionType{}, err
+	}
+	if missionType.Name == config.DefaultMissionType && !strings.EqualFold(body.Name, missionType.Name) {
+		return response.MissionType{}, config.ErrInvalidMissionType.WithDetail("the default type can't be renamed")
+	}
+	if err = s.checkName(ctx, body.Name, typeID); err != nil {
+		return response.MissionType{}, err
+	}
+
+	if err = s.repo.UpdateMissionType(ctx, dto.MissionTypeToEntity(body, typeID)); err != nil {
+		return response.MissionType{}, config.DBError(fmt.Errorf("update mission type: %w", err))
+	}
+	return s.GetMissionType(ctx, typeID)
+}
+
+// DeleteMissionType deletes a type no mission or template has.
+func (s service) DeleteMissionType(ctx context.Context, typeID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	missionType, err := s.getMissionType(ctx, typeID)
+	if err != nil {
+		return err
+	}
+	if missionType.Name == config.DefaultMissionType {
+		return config.ErrMissionTypeInUse.WithDetail("the default type can't be deleted")
+	}
+
+	count, err := s.repo.CountUsage(ctx, typeID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("count mission type usage: %w", err))
+	}
+	if count > 0 {
+		return config.ErrMissionTypeInUse.WithDetail(fmt.Sprintf("%d missions and templates", count))
+	}
+
+	if err = s.repo.DeleteMissionType(ctx, typeID); err != nil {
+		return config.DBError(fmt.Errorf("delete mission type: %w", err))
+	}
+	return nil
+}
+
+func (s service) getMissionType(ctx context.Context, typeID uint) (entity.MissionType, error) {
+	missionType, err := s.repo.GetMissionType(ctx, typeID)
+	if err != nil {
+		return entity.MissionType{}, config.DBError(fmt.Errorf("get mission type: %w", err))
+	}
+	if missionType.ID == 0 {
+		return entity.MissionType{}, config.ErrMissionTypeNotFound
+	}
+	return missionType, nil
+}
+
+// checkName fails if another type than typeID has the name.
+func (s service) checkName(ctx context.Context, name string, typeID uint) error {
+	existing, err := s.repo.GetMissionTypeByName(ctx, name)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get mission type: %w", err))
+	}
+	if existing.ID != 0 && existing.ID != typeID {
+		return config.ErrMissionTypeDuplicate.WithDetail(existing.Name)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package missiontype
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		GetMissionTypes(ctx context.Context) ([]entity.MissionType, error)
+		GetMissionType(ctx context.Context, typeID uint) (entity.MissionType, error)
+		GetMissionTypeByName(ctx context.Context, name string) (entity.MissionType, error)
+		CountUsage(ctx context.Context, typeID uint) (int64, error)
+
+		CreateMissionType(ctx context.Context, missionType entity.MissionType) (entity.MissionType, error)
+		UpdateMissionType(ctx context.Context, missionType entity.MissionType) error
+		DeleteMissionType(ctx context.Context, typeID uint) error
+	}
+
+	service struct {
+		repo repo
+		l    *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	l *slog.Logger,
+) service {
+	return service{repo, l}
+}
@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	entity "backend/internal/entity/cat"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// NewEvent returns a domain event of the aggregate with data as JSON payload.
+func NewEvent(eventType, aggregateType string, aggregateID uint, data any) (entity.Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return entity.Event{}, err
+	}
+
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		return entity.Event{}, err
+	}
+
+	return entity.Event{
+		ID:            "evt_" + hex.EncodeToString(id),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
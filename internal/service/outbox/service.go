@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/backoff"
+	"context"
+	"fmt"
+	"time"
+)
+
+// claimLease outlives a publish, so the claims of a crashed relay are retried.
+const claimLease = time.Minute
+
+// Run relays outbox events every poll interval until ctx is done, pruning
+// published events older than the retention once per hour.
+func (s service) Run(ctx context.Context) {
+	if s.cfg.PollInterval <= 0 {
+		s.l.Warn("outbox relay disabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+	for {
+		for {
+			n, failed, err := s.Relay(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.l.Error("outbox relay failed", "err", err)
+			}
+			// publishing an event makes the next one of its aggregate due,
+			// failed events wait for their backoff
+			if err != nil || n == 0 || failed > 0 {
+				break
+			}
+		}
+
+		if s.cfg.Retention > 0 && time.Since(pruned) > time.Hour {
+			if _, err := s.repo.DeletePublishedBefore(ctx, time.Now().Add(-s.cfg.Retention)); err != nil && ctx.Err() == nil {
+				s.l.Error("outbox prune failed", "err", err)
+			}
+			pruned = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay claims a batch of due events, publishes them to the sink and records
+// the attempts. Events are marked published only after the sink accepted
+// them, so a crash in between publishes them again. An event the sink fails
+// on is retried with exponential backoff, holding back the later events of its
+// aggregate, until it is dead-lettered after the max attempts.
+// It returns the number of events attempted and how many of them failed.
+func (s service) Relay(ctx context.Context) (int, int, error) {
+	events, err := s.repo.ClaimDueEvents(ctx, time.Now(), claimLease, config.OutboxBatch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("claim outbox events: %w", err)
+	}
+
+	failed := 0
+	for _, e := range events {
+		e = s.attempt(ctx, e)
+		if e.PublishedAt == nil {
+			failed++
+		}
+		if err = s.repo.UpdateEventAttempt(ctx, e); err != nil {
+			return 0, 0, fmt.Errorf("update outbox event: %w", err)
+		}
+	}
+
+	return len(events), failed, nil
+}
+
+// attempt publishes the event and returns it updated with the outcome.
+func (s service) attempt(ctx context.Context, e entity.OutboxEvent) entity.OutboxEvent {
+	now := time.Now()
+	e.Attempts++
+	e.NextAttemptAt = nil
+	e.LastError = ""
+
+	err := s.sink.Publish(ctx, entity.Event{
+		ID:            e.EventID,
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Payload:       []byte(e.Payload),
+		OccurredAt:    e.OccurredAt,
+	})
+	if err == nil {
+		e.PublishedAt = &now
+		return e
+	}
+
+	e.LastError = err.Error()
+	if e.Attempts >= s.cfg.MaxAttempts {
+		e.DeadAt = &now
+		s.l.Error("outbox event dead-lettered", "event_id", e.EventID, "type", e.Type, "attempts", e.Attempts, "err", err)
+		return e
+	}
+
+	next := now.Add(backoff.Exponential(s.cfg.BackoffBase, s.cfg.BackoffMax, e.Attempts))
+	e.NextAttemptAt = &next
+	s.l.Error("outbox event publish failed", "event_id", e.EventID, "type", e.Type, "attempts", e.Attempts, "err", err)
+	return e
+}
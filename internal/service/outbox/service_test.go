@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeOutbox struct {
+	repo
+	due     []entity.OutboxEvent
+	updated []entity.OutboxEvent
+}
+
+func (r *fakeOutbox) ClaimDueEvents(_ context.Context, _ time.Time, _ time.Duration, limit int) ([]entity.OutboxEvent, error) {
+	return r.due[:min(limit, len(r.due))], nil
+}
+
+func (r *fakeOutbox) UpdateEventAttempt(_ context.Context, event entity.OutboxEvent) error {
+	r.updated = append(r.updated, event)
+	return nil
+}
+
+// fakeSink fails on the events in fail.
+type fakeSink struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (s *fakeSink) Publish(_ context.Context, event entity.Event) error {
+	if s.fail[event.ID] {
+		return errors.New("sink down")
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	cfg := config.Outbox{MaxAttempts: 3, BackoffBase: time.Second, BackoffMax: time.Minute}
+
+	tests := []struct {
+		name          string
+		attempts      int // before this one
+		fail          bool
+		wantPublished bool
+		wantRetryIn   time.Duration // 0 if no retry is scheduled
+		wantDead      bool
+	}{
+		{name: "published", wantPublished: true},
+		{name: "published on retry", attempts: 2, wantPublished: true},
+		{name: "retried with backoff", fail: true, wantRetryIn: time.Second},
+		{name: "backoff doubles", attempts: 1, fail: true, wantRetryIn: 2 * time.Second},
+		{name: "dead-lettered after the last attempt", attempts: 2, fail: true, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOutbox{due: []entity.OutboxEvent{{
+				ID:            1,
+				EventID:       "evt-1",
+				Type:          config.EventMissionCompleted,
+				AggregateType: "mission",
+				AggregateID:   4,
+				Payload:       `{"mission_id":4}`,
+				Attempts:      tt.attempts,
+			}}}
+			sink := &fakeSink{fail: map[string]bool{"evt-1": tt.fail}}
+			s := NewService(repo, sink, cfg, slog.New(slog.DiscardHandler))
+
+			before := time.Now()
+			n, failed, err := s.Relay(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 1 || (failed == 1) == tt.wantPublished {
+				t.Errorf("relayed %d, failed %d", n, failed)
+			}
+			if len(repo.updated) != 1 {
+				t.Fatalf("updated %d events, want 1", len(repo.updated))
+			}
+
+			e := repo.updated[0]
+			if e.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", e.Attempts, tt.attempts+1)
+			}
+			if (e.PublishedAt != nil) != tt.wantPublished || (e.DeadAt != nil) != tt.wantDead {
+				t.Errorf("published at %v, dead at %v", e.PublishedAt, e.DeadAt)
+			}
+			if tt.fail != (e.LastError != "") {
+				t.Errorf("last error = %q", e.LastError)
+			}
+
+			switch {
+			case tt.wantRetryIn == 0 && e.NextAttemptAt != nil:
+				t.Errorf("retry scheduled at %v, want none", e.NextAttemptAt)
+			case tt.wantRetryIn > 0 && e.NextAttemptAt == nil:
+				t.Errorf("no retry scheduled, want one in %v", tt.wantRetryIn)
+			case tt.wantRetryIn > 0 && e.NextAttemptAt.Sub(before) < tt.wantRetryIn:
+				t.Errorf("retry in %v, want %v", e.NextAttemptAt.Sub(before), tt.wantRetryIn)
+			}
+		})
+	}
+}
+
+func TestRelayInOrder(t *testing.T) {
+	repo := &fakeOutbox{due: []entity.OutboxEvent{
+		{ID: 2, TxID: 10, EventID: "evt-2"},
+		{ID: 1, TxID: 11, EventID: "evt-1"},
+		{ID: 3, TxID: 11, EventID: "evt-3"},
+	}}
+	sink := &fakeSink{fail: map[string]bool{"evt-1": true}}
+	s := NewService(repo, sink, config.Outbox{MaxAttempts: 5, BackoffBase: time.Second, BackoffMax: time.Minute}, slog.New(slog.DiscardHandler))
+
+	n, failed, err := s.Relay(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || failed != 1 {
+		t.Errorf("relayed %d, failed %d, want 3 and 1", n, failed)
+	}
+	// claimed events are published in the order of the claim
+	if got := sink.published; len(got) != 2 || got[0] != "evt-2" || got[1] != "evt-3" {
+		t.Errorf("published %v, want [evt-2 evt-3]", got)
+	}
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type (
+	repo interface {
+		ClaimDueEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.OutboxEvent, error)
+		UpdateEventAttempt(ctx context.Context, event entity.OutboxEvent) error
+		DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	// Sink receives relayed events, at least once each and in order per aggregate.
+	Sink interface {
+		Publish(ctx context.Context, event entity.Event) error
+	}
+
+	// LogSink writes relayed events to the logger.
+	LogSink struct {
+		l *slog.Logger
+	}
+
+	service struct {
+		repo repo
+		sink Sink
+		cfg  config.Outbox
+		l    *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	sink Sink,
+	cfg config.Outbox,
+	l *slog.Logger,
+) service {
+	return service{repo, sink, cfg, l}
+}
+
+func NewLogSink(l *slog.Logger) LogSink {
+	return LogSink{l}
+}
+
+func (s LogSink) Publish(ctx context.Context, event entity.Event) error {
+	s.l.InfoContext(ctx, "event",
+		"id", event.ID,
+		"type", event.Type,
+		"aggregate", event.AggregateType,
+		"aggregate_id", event.AggregateID,
+		"payload", string(event.Payload))
+	return nil
+}
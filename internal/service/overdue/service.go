@@ -0,0 +1,81 @@
+package overdue
+
+import (
+	"backend/config"
+	"backend/pkg/notifier"
+	"context"
+	"fmt"
+	"time"
+)
+
+type missionOverdue struct {
+	MissionID uint      `json:"mission_id"`
+	CatID     *uint     `json:"cat_id"`
+	Priority  string    `json:"priority"`
+	DueAt     time.Time `json:"due_at"`
+}
+
+// Run checks for overdue missions every interval until ctx is done,
+// a non positive interval disables the checks.
+func (s service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		s.l.Warn("overdue missions check disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CheckOverdue(ctx); err != nil && ctx.Err() == nil {
+			s.l.Error("overdue missions check failed", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOverdue claims missions gone overdue by flagging them, then notifies
+// about them, so each mission is notified once per due date even with several
+// replicas checking. Missions the notifier failed on are released and retried
+// on the next check. It returns the number of missions notified.
+func (s service) CheckOverdue(ctx context.Context) (int, error) {
+	// stored with the microsecond precision of Postgres, to be released by it
+	now := time.Now().Truncate(time.Microsecond)
+
+	missions, err := s.repo.ClaimOverdueMissions(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("claim overdue missions: %w", err)
+	}
+
+	notified := 0
+	for _, m := range missions {
+		err = s.notifier.Notify(ctx, notifier.Notification{
+			Event:   config.NotificationMissionOverdue,
+			Message: fmt.Sprintf("mission %d is overdue", m.ID),
+			Data: missionOverdue{
+				MissionID: m.ID,
+				CatID:     m.CatID,
+				Priority:  m.Priority,
+				DueAt:     *m.DueAt,
+			},
+			At: now,
+		})
+		if err == nil {
+			notified++
+			continue
+		}
+
+		s.l.Error("overdue mission notification failed", "mission_id", m.ID, "err", err)
+		// released on shutdown too, the claim would never be notified otherwise
+		if err = s.repo.ReleaseOverdue(context.WithoutCancel(ctx), m.ID, now); err != nil {
+			s.l.Error("overdue mission release failed", "mission_id", m.ID, "err", err)
+		}
+	}
+
+	return notified, nil
+}
@@ -0,0 +1,137 @@
+package overdue
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/notifier"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeMissions claims each overdue mission once, like the flag guard.
+type fakeMissions struct {
+	due      map[uint]time.Time
+	flagged  map[uint]time.Time
+	released []uint
+	claimErr error
+}
+
+func (r *fakeMissions) ClaimOverdueMissions(_ context.Context, now time.Time) (res []entity.Mission, _ error) {
+	if r.claimErr != nil {
+		return nil, r.claimErr
+	}
+	for id := uint(1); id <= uint(len(r.due)); id++ {
+		due := r.due[id]
+		if _, ok := r.flagged[id]; ok || !due.Before(now) {
+			continue
+		}
+		r.flagged[id] = now
+		res = append(res, entity.Mission{ID: id, DueAt: &due})
+	}
+	return res, nil
+}
+
+func (r *fakeMissions) ReleaseOverdue(_ context.Context, missionID uint, at time.Time) error {
+	if r.flagged[missionID].Equal(at) {
+		delete(r.flagged, missionID)
+		r.released = append(r.released, missionID)
+	}
+	return nil
+}
+
+// fakeNotifier fails on the missions in fail.
+type fakeNotifier struct {
+	fail     map[uint]bool
+	notified []uint
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, msg notifier.Notification) error {
+	id := msg.Data.(missionOverdue).MissionID
+	if n.fail[id] {
+		return errors.New("notifier down")
+	}
+	n.notified = append(n.notified, id)
+	return nil
+}
+
+func TestCheckOverdue(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	errDB := errors.New("db down")
+
+	tests := []struct {
+		name         string
+		claimErr     error
+		fail         map[uint]bool
+		wantNotified []uint
+		wantReleased []uint
+		wantErr      error
+	}{
+		{name: "overdue missions are notified", wantNotified: []uint{1, 3}},
+		{
+			name:         "failed notification is released",
+			fail:         map[uint]bool{1: true},
+			wantNotified: []uint{3},
+			wantReleased: []uint{1},
+		},
+		{name: "claim failure", claimErr: errDB, wantErr: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeMissions{
+				due:      map[uint]time.Time{1: past, 2: future, 3: past},
+				flagged:  map[uint]time.Time{},
+				claimErr: tt.claimErr,
+			}
+			n := &fakeNotifier{fail: tt.fail}
+			s := NewService(repo, n, slog.New(slog.DiscardHandler))
+
+			count, err := s.CheckOverdue(context.Background())
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if count != len(tt.wantNotified) || !reflect.DeepEqual(n.notified, tt.wantNotified) {
+				t.Errorf("notified %d: %v, want %v", count, n.notified, tt.wantNotified)
+			}
+			if !reflect.DeepEqual(repo.released, tt.wantReleased) {
+				t.Errorf("released = %v, want %v", repo.released, tt.wantReleased)
+			}
+			if err != nil {
+				return
+			}
+
+			// the next check notifies the released missions only
+			n.fail = nil
+			n.notified = nil
+			if _, err = s.CheckOverdue(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(n.notified, tt.wantReleased) {
+				t.Errorf("next check notified %v, want %v", n.notified, tt.wantReleased)
+			}
+		})
+	}
+}
+
+func TestCheckOverdueConcurrent(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	repo := &fakeMissions{due: map[uint]time.Time{1: past, 2: past}, flagged: map[uint]time.Time{}}
+	n := &fakeNotifier{}
+
+	// two replicas sharing the missions notify each mission once
+	replicas := []service{
+		NewService(repo, n, slog.New(slog.DiscardHandler)),
+		NewService(repo, n, slog.New(slog.DiscardHandler)),
+	}
+	for _, s := range replicas {
+		if _, err := s.CheckOverdue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(n.notified, []uint{1, 2}) {
+		t.Errorf("notified %v, want each mission once", n.notified)
+	}
+}
@@ -0,0 +1,30 @@
+package overdue
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/notifier"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type (
+	repo interface {
+		ClaimOverdueMissions(ctx context.Context, now time.Time) ([]entity.Mission, error)
+		ReleaseOverdue(ctx context.Context, missionID uint, at time.Time) error
+	}
+
+	service struct {
+		repo     repo
+		notifier notifier.Notifier
+		l        *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	notifier notifier.Notifier,
+	l *slog.Logger,
+) service {
+	return service{repo, notifier, l}
+}
@@ -0,0 +1,153 @@
+package payroll
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// computePayout prorates the monthly salary of the cat over the days of
+// [from, to) it was employed, see employedPeriods. changes and statusChanges
+// must be ordered chronologically.
+// The payout is in the currency of the last segment, earlier segments in other
+// currencies are converted. Amounts are summed as salary*days before dividing,
+// so rounding happens once.
+func computePayout(cat entity.Cat, changes []entity.SalaryChange, statusChanges []entity.CatStatusChange, from, to time.Time, rates rates) (response.Payout, error) {
+	payout := response.Payout{
+		CatID:    cat.ID,
+		CatName:  cat.Name,
+		Segments: make([]response.PayoutSegment, 0),
+	}
+
+	start := maxTime(from, truncateDay(cat.CreatedAt))
+	end := to
+	if cat.DeletedAt != nil {
+		end = minTime(to, truncateDay(*cat.DeletedAt))
+	}
+	if !start.Before(end) {
+		return payout, nil
+	}
+
+	// Salary in effect at the start of the period, overridden below by changes
+	// effective before it. Cats without any history fall back to the current salary.
+	salary := cat.Salary
+	if len(changes) > 0 {
+		salary = changes[0].PreviousSalary
+	}
+
+	periods := employedPeriods(statusChanges, start, end)
+
+	// closeSegment pays the salary from segmentAt until the given day,
+	// over the days the cat was employed
+	segmentAt := start
+	closeSegment := func(until time.Time) {
+		for _, p := range periods {
+			segFrom, segTo := maxTime(segmentAt, p.from), minTime(until, p.to)
+			d := days(segFrom, segTo)
+			if d <= 0 {
+				continue
+			}
+			payout.Days += d
+			payout.Segments = append(payout.Segments, response.PayoutSegment{
+				From:   segFrom.Format(config.DateLayout),
+				To:     segTo.Add(-day).Format(config.DateLayout),
+				Days:   d,
+				Salary: salary,
+			})
+		}
+		segmentAt = until
+	}
+
+	for _, c := range changes {
+		effective := truncateDay(c.EffectiveFrom)
+		if !effective.After(start) {
+			salary = c.Salary
+			continue
+		}
+		if !effective.Before(end) {
+			break
+		}
+		closeSegment(effective)
+		salary = c.Salary
+	}
+	closeSegment(end)
+	if len(payout.Segments) == 0 {
+		return payout, nil
+	}
+
+	currency := payout.Segments[len(payout.Segments)-1].Salary.Currency
+
+	var weighted int64 // sum of salary * days
+	for _, seg := range payout.Segments {
+		salary, err := rates.convert(seg.Salary, currency)
+		if err != nil {
+			return response.Payout{}, err
+		}
+		weighted += salary.Amount * int64(seg.Days)
+	}
+
+	monthDays := int64(days(from, to))
+	payout.Amount = money.New((weighted+monthDays/2)/monthDays, currency)
+	return payout, nil
+}
+
+type period struct {
+	from, to time.Time
+}
+
+// employedPeriods returns the periods of [start, end) the cat was employed,
+// ending on the day of a transition to retired or KIA and resuming on the day
+// it is reactivated. Cats on leave stay employed.
+func employedPeriods(statusChanges []entity.CatStatusChange, start, end time.Time) []period {
+	var periods []period
+
+	employed, since := true, start
+	for _, c := range statusChanges {
+		at := maxTime(start, truncateDay(c.CreatedAt))
+		if !at.Before(end) {
+			break
+		}
+
+		removed := c.To == config.CatStatusRetired || c.To == config.CatStatusKIA
+		switch {
+		case employed && removed:
+			if since.Before(at) {
+				periods = append(periods, period{since, at})
+			}
+			employed = false
+		case !employed && !removed:
+			employed, since = true, at
+		}
+	}
+	if employed && since.Before(end) {
+		periods = append(periods, period{since, end})
+	}
+
+	return periods
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from) / day)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(day)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
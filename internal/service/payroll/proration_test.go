@@ -0,0 +1,216 @@
+package payroll
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func march(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+
+func usd(amount int64) money.Money { return money.New(amount, "USD") }
+
+func statusChange(at time.Time, to string) entity.CatStatusChange {
+	return entity.CatStatusChange{CreatedAt: at, To: to}
+}
+
+func TestComputePayout(t *testing.T) {
+	from, to := march(1), march(32) // the whole of March, 31 days
+	deleted := march(21).Add(15 * time.Hour)
+
+	tests := []struct {
+		name          string
+		cat           entity.Cat
+		changes       []entity.SalaryChange
+		statusChanges []entity.CatStatusChange
+		wantDays      int
+		wantAmount    money.Money
+		wantSegments  []response.PayoutSegment
+	}{
+		{
+			name:       "whole month without history",
+			cat:        entity.Cat{CreatedAt: march(1).AddDate(0, -2, 0), Salary: usd(300000)},
+			wantDays:   31,
+			wantAmount: usd(300000),
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-31", Days: 31, Salary: usd(300000)},
+			},
+		},
+		{
+			name:       "hired mid month",
+			cat:        entity.Cat{CreatedAt: march(11).Add(9 * time.Hour), Salary: usd(300000)},
+			wantDays:   21,
+			wantAmount: usd(203226), // 203225.81
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-11", To: "2026-03-31", Days: 21, Salary: usd(300000)},
+			},
+		},
+		{
+			name:       "deleted mid month",
+			cat:        entity.Cat{CreatedAt: march(1), DeletedAt: &deleted, Salary: usd(300000)},
+			wantDays:   20,
+			wantAmount: usd(193548), // 193548.39
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-20", Days: 20, Salary: usd(300000)},
+			},
+		},
+		{
+			name: "salary change mid month",
+			cat:  entity.Cat{CreatedAt: march(1), Salary: usd(400000)},
+			changes: []entity.SalaryChange{
+				{Salary: usd(400000), PreviousSalary: usd(300000), EffectiveFrom: march(11)},
+			},
+			wantDays:   31,
+			wantAmount: usd(367742), // (10*3000 + 21*4000) / 31
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-10", Days: 10, Salary: usd(300000)},
+				{From: "2026-03-11", To: "2026-03-31", Days: 21, Salary: usd(400000)},
+			},
+		},
+		{
+			name: "changes outside the month",
+			cat:  entity.Cat{CreatedAt: march(1).AddDate(0, -2, 0), Salary: usd(500000)},
+			changes: []entity.SalaryChange{
+				{Salary: usd(300000), PreviousSalary: usd(200000), EffectiveFrom: march(1).AddDate(0, 0, -14)},
+				{Salary: usd(500000), PreviousSalary: usd(300000), EffectiveFrom: march(32).AddDate(0, 0, 4)},
+			},
+			wantDays:   31,
+			wantAmount: usd(300000),
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-31", Days: 31, Salary: usd(300000)},
+			},
+		},
+		{
+			name:          "retired mid month",
+			cat:           entity.Cat{CreatedAt: march(1), Salary: usd(300000)},
+			statusChanges: []entity.CatStatusChange{statusChange(march(21).Add(8*time.Hour), config.CatStatusRetired)},
+			wantDays:      20,
+			wantAmount:    usd(193548),
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-20", Days: 20, Salary: usd(300000)},
+			},
+		},
+		{
+			name: "retired and reactivated",
+			cat:  entity.Cat{CreatedAt: march(1), Salary: usd(300000)},
+			statusChanges: []entity.CatStatusChange{
+				statusChange(march(11), config.CatStatusRetired),
+				statusChange(march(21), config.CatStatusActive),
+			},
+			wantDays:   21,
+			wantAmount: usd(203226),
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-10", Days: 10, Salary: usd(300000)},
+				{From: "2026-03-21", To: "2026-03-31", Days: 11, Salary: usd(300000)},
+			},
+		},
+		{
+			name:          "on leave stays paid",
+			cat:           entity.Cat{CreatedAt: march(1), Salary: usd(300000)},
+			statusChanges: []entity.CatStatusChange{statusChange(march(11), config.CatStatusOnLeave)},
+			wantDays:      31,
+			wantAmount:    usd(300000),
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-31", Days: 31, Salary: usd(300000)},
+			},
+		},
+		{
+			name:          "retired before the month",
+			cat:           entity.Cat{CreatedAt: march(1).AddDate(0, -2, 0), Salary: usd(300000)},
+			statusChanges: []entity.CatStatusChange{statusChange(march(1).AddDate(0, 0, -19), config.CatStatusRetired)},
+			wantSegments:  []response.PayoutSegment{},
+		},
+		{
+			name: "salary change and KIA",
+			cat:  entity.Cat{CreatedAt: march(1), Salary: usd(400000)},
+			changes: []entity.SalaryChange{
+				{Salary: usd(400000), PreviousSalary: usd(300000), EffectiveFrom: march(11)},
+			},
+			statusChanges: []entity.CatStatusChange{statusChange(march(21), config.CatStatusKIA)},
+			wantDays:      20,
+			wantAmount:    usd(225806), // (10*3000 + 10*4000) / 31
+			wantSegments: []response.PayoutSegment{
+				{From: "2026-03-01", To: "2026-03-10", Days: 10, Salary: usd(300000)},
+				{From: "2026-03-11", To: "2026-03-20", Days: 10, Salary: usd(400000)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payout, err := computePayout(tt.cat, tt.changes, tt.statusChanges, from, to, rates{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if payout.Days != tt.wantDays || payout.Amount != tt.wantAmount {
+				t.Errorf("payout = %d days, %v, want %d days, %v", payout.Days, payout.Amount, tt.wantDays, tt.wantAmount)
+			}
+			if !reflect.DeepEqual(payout.Segments, tt.wantSegments) {
+				t.Errorf("segments = %+v, want %+v", payout.Segments, tt.wantSegments)
+			}
+		})
+	}
+}
+
+func TestEmployedPeriods(t *testing.T) {
+	start, end := march(1), march(32)
+
+	tests := []struct {
+		name          string
+		statusChanges []entity.CatStatusChange
+		want          []period
+	}{
+		{"no changes", nil, []period{{start, end}}},
+		{
+			"retired",
+			[]entity.CatStatusChange{statusChange(march(10), config.CatStatusRetired)},
+			[]period{{start, march(10)}},
+		},
+		{
+			"retired on the first day",
+			[]entity.CatStatusChange{statusChange(march(1).Add(time.Hour), config.CatStatusRetired)},
+			nil,
+		},
+		{
+			"retired before and reactivated",
+			[]entity.CatStatusChange{
+				statusChange(march(1).AddDate(0, -1, 0), config.CatStatusRetired),
+				statusChange(march(15), config.CatStatusActive),
+			},
+			[]period{{march(15), end}},
+		},
+		{
+			"KIA after retirement keeps the cat removed",
+			[]entity.CatStatusChange{
+				statusChange(march(5), config.CatStatusRetired),
+				statusChange(march(8), config.CatStatusKIA),
+			},
+			[]period{{start, march(5)}},
+		},
+		{
+			"leave is employment",
+			[]entity.CatStatusChange{
+				statusChange(march(5), config.CatStatusOnLeave),
+				statusChange(march(8), config.CatStatusActive),
+			},
+			[]period{{start, end}},
+		},
+		{
+			"changes after the end are ignored",
+			[]entity.CatStatusChange{statusChange(march(32), config.CatStatusRetired)},
+			[]period{{start, end}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := employedPeriods(tt.statusChanges, start, end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("periods = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
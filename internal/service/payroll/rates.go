@@ -0,0 +1,39 @@
+package payroll
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"fmt"
+	"math/big"
+)
+
+// rates maps a [base, quote] currency pair to its exchange rate.
+type rates map[[2]string]*big.Rat
+
+func newRates(list []entity.ExchangeRate) (rates, error) {
+	res := make(rates, len(list))
+	for _, r := range list {
+		rate, err := money.ParseRate(r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rate %s/%s: %w", r.BaseCurrency, r.QuoteCurrency, err)
+		}
+		res[[2]string{r.BaseCurrency, r.QuoteCurrency}] = rate
+	}
+	return res, nil
+}
+
+// convert converts the money using the direct rate, or the inverse one
+// if only the opposite pair is maintained.
+func (r rates) convert(m money.Money, to string) (money.Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	if rate, ok := r[[2]string{m.Currency, to}]; ok {
+		return m.Convert(to, rate), nil
+	}
+	if rate, ok := r[[2]string{to, m.Currency}]; ok {
+		return m.Convert(to, new(big.Rat).Inv(rate)), nil
+	}
+	return money.Money{}, config.ErrExchangeRateNotFound.WithDetail(m.Currency + "/" + to)
+}
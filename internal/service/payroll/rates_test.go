@@ -0,0 +1,98 @@
+package payroll
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"errors"
+	"testing"
+)
+
+func TestRatesConvert(t *testing.T) {
+	rates, err := newRates([]entity.ExchangeRate{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: "1.1"},
+		{BaseCurrency: "USD", QuoteCurrency: "JPY", Rate: "150"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		m       money.Money
+		to      string
+		want    money.Money
+		wantErr error
+	}{
+		{name: "same currency", m: money.New(1000, "GBP"), to: "GBP", want: money.New(1000, "GBP")},
+		{name: "direct pair", m: money.New(1000, "EUR"), to: "USD", want: money.New(1100, "USD")},
+		{name: "inverse pair", m: money.New(1100, "USD"), to: "EUR", want: money.New(1000, "EUR")},
+		{name: "inverse pair across exponents", m: money.New(1500, "JPY"), to: "USD", want: money.New(1000, "USD")},
+		{name: "no chained conversion", m: money.New(1000, "EUR"), to: "JPY", wantErr: config.ErrExchangeRateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.convert(tt.m, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("convert = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRatesInvalid(t *testing.T) {
+	if _, err := newRates([]entity.ExchangeRate{{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: "0"}}); err == nil {
+		t.Fatal("zero rate accepted")
+	}
+}
+
+func TestComputePayoutConverts(t *testing.T) {
+	from, to := march(1), march(32)
+	cat := entity.Cat{CreatedAt: march(1), Salary: usd(300000)}
+	changes := []entity.SalaryChange{
+		{Salary: usd(300000), PreviousSalary: money.New(200000, "EUR"), EffectiveFrom: march(11)},
+	}
+
+	tests := []struct {
+		name    string
+		rates   []entity.ExchangeRate
+		want    money.Money
+		wantErr error
+	}{
+		{
+			name:  "direct rate",
+			rates: []entity.ExchangeRate{{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: "1.1"}},
+			want:  usd(274194), // (10*2200 + 21*3000) / 31
+		},
+		{
+			name:  "inverse rate",
+			rates: []entity.ExchangeRate{{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: "0.8"}},
+			want:  usd(283871), // (10*2500 + 21*3000) / 31
+		},
+		{
+			name:    "missing rate",
+			wantErr: config.ErrExchangeRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := newRates(tt.rates)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			payout, err := computePayout(cat, changes, nil, from, to, rates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if payout.Amount != tt.want {
+				t.Errorf("amount = %v, want %v", payout.Amount, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package payroll
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/money"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GetPayroll computes monthly payouts of every cat employed during the month
+// (YYYY-MM). Salaries are monthly and prorated by days, the total is
+// converted into the given currency.
+func (s service) GetPayroll(ctx context.Context, month, currency string) (response.PayrollReport, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.PayrollReport{}, err
+	}
+	from, err := time.Parse(config.MonthLayout, month)
+	if err != nil {
+		return response.PayrollReport{}, config.ErrInvalidMonth.WithDetail(month)
+	}
+	to := from.AddDate(0, 1, 0)
+
+	if !money.IsCurrency(currency) {
+		return response.PayrollReport{}, config.ErrInvalidCurrency.WithDetail(currency)
+	}
+
+	rates, err := s.getRates(ctx)
+	if err != nil {
+		return response.PayrollReport{}, err
+	}
+
+	cats, err := s.catRepo.GetCatsEmployedBetween(ctx, from, to)
+	if err != nil {
+		return response.PayrollReport{}, config.DBError(fmt.Errorf("get cats: %w", err))
+	}
+
+	changes, err := s.salaryRepo.GetSalaryChangesBefore(ctx, to)
+	if err != nil {
+		return response.PayrollReport{}, config.DBError(fmt.Errorf("get salary changes: %w", err))
+	}
+
+	changesByCat := make(map[uint][]entity.SalaryChange)
+	for _, c := range changes {
+		changesByCat[c.CatID] = append(changesByCat[c.CatID], c)
+	}
+
+	statusChanges, err := s.catRepo.GetStatusChangesBefore(ctx, to)
+	if err != nil {
+		return response.PayrollReport{}, config.DBError(fmt.Errorf("get status changes: %w", err))
+	}
+
+	statusByCat := make(map[uint][]entity.CatStatusChange)
+	for _, c := range statusChanges {
+		statusByCat[c.CatID] = append(statusByCat[c.CatID], c)
+	}
+
+	report := response.PayrollReport{
+		Month:    month,
+		Days:     days(from, to),
+		Currency: currency,
+		Total:    money.New(0, currency),
+		Totals:   make([]money.Money, 0),
+		Payouts:  make([]response.Payout, 0, len(cats)),
+	}
+
+	totals := make(map[string]int64)
+	for _, cat := range cats {
+		payout, err := computePayout(cat, changesByCat[cat.ID], statusByCat[cat.ID], from, to, rates)
+		if err != nil {
+			return response.PayrollReport{}, err
+		}
+		if payout.Days == 0 {
+			continue
+		}
+		totals[payout.Amount.Currency] += payout.Amount.Amount
+		report.Payouts = append(report.Payouts, payout)
+	}
+
+	for cur, amount := range totals {
+		total := money.New(amount, cur)
+		report.Totals = append(report.Totals, total)
+
+		converted, err := rates.convert(total, currency)
+		if err != nil {
+			return response.PayrollReport{}, err
+		}
+		report.Total.Amount += converted.Amount
+	}
+	sort.Slice(report.Totals, func(i, j int) bool {
+		return report.Totals[i].Currency < report.Totals[j].Currency
+	})
+
+	return report, nil
+}
+
+func (s service) GetExchangeRates(ctx context.Context) ([]response.ExchangeRate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	list, err := s.exchangeRateRepo.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get exchange rates: %w", err))
+	}
+	return dto.ExchangeRatesToResponse(list), nil
+}
+
+func (s service) SetExchangeRate(ctx context.Context, body request.ExchangeRate, base, quote string) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	rate, err := dto.ExchangeRateToEntity(body, base, quote)
+	if err != nil {
+		return err
+	}
+
+	err = s.exchangeRateRepo.UpsertExchangeRate(ctx, rate)
+	return config.DBError(err)
+}
+
+func (s service) DeleteExchangeRate(ctx context.Context, base, quote string) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	deleted, err := s.exchangeRateRepo.DeleteExchangeRate(ctx, base, quote)
+	if err != nil {
+		return config.DBError(fmt.Errorf("delete exchange rate: %w", err))
+	}
+	if !deleted {
+		return config.ErrExchangeRateNotFound.WithDetail(base + "/" + quote)
+	}
+	return nil
+}
+
+func (s service) getRates(ctx context.Context) (rates, error) {
+	list, err := s.exchangeRateRepo.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get exchange rates: %w", err))
+	}
+
+	res, err := newRates(list)
+	if err != nil {
+		return nil, config.Wrap(config.CodeInternal, "invalid stored exchange rate", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package payroll
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type (
+	catRepo interface {
+		GetCatsEmployedBetween(ctx context.Context, from, to time.Time) ([]entity.Cat, error)
+		GetStatusChangesBefore(ctx context.Context, before time.Time) ([]entity.CatStatusChange, error)
+	}
+
+	salaryRepo interface {
+		GetSalaryChangesBefore(ctx context.Context, before time.Time) ([]entity.SalaryChange, error)
+	}
+
+	exchangeRateRepo interface {
+		GetExchangeRates(ctx context.Context) ([]entity.ExchangeRate, error)
+		UpsertExchangeRate(ctx context.Context, rate entity.ExchangeRate) error
+		DeleteExchangeRate(ctx context.Context, base, quote string) (bool, error)
+	}
+
+	service struct {
+		catRepo          catRepo
+		salaryRepo       salaryRepo
+		exchangeRateRepo exchangeRateRepo
+		l                *slog.Logger
+	}
+)
+
+func NewService(
+	catRepo catRepo,
+	salaryRepo salaryRepo,
+	exchangeRateRepo exchangeRateRepo,
+	l *slog.Logger,
+) service {
+	return service{catRepo, salaryRepo, exchangeRateRepo, l}
+}
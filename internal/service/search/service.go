@@ -0,0 +1,51 @@
+package search
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Search runs the full-text query over the given types, all if empty.
+// Cat callers only find their own missions' targets and themselves.
+func (s service) Search(ctx context.Context, q string, types []string, limit int) ([]response.SearchResult, error) {
+	if err := auth.RequireCaller(ctx); err != nil {
+		return nil, err
+	}
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, config.ErrEmptySearchQuery
+	}
+
+	if limit == 0 {
+		limit = config.DefaultSearchLimit
+	}
+	if limit < 0 || limit > config.MaxSearchLimit {
+		return nil, config.ErrInvalidSearchLimit.WithDetail(
+			fmt.Sprintf("limit should be in range (1|%d)", config.MaxSearchLimit))
+	}
+
+	if len(types) == 0 {
+		types = []string{config.SearchTypeTarget, config.SearchTypeCat}
+	}
+	for _, t := range types {
+		if t != config.SearchTypeTarget && t != config.SearchTypeCat {
+			return nil, config.ErrUnknownSearchType.WithDetail(t)
+		}
+	}
+
+	var catID *uint
+	if caller := auth.FromContext(ctx); !caller.IsHandler() {
+		catID = &caller.CatID
+	}
+
+	hits, err := s.repo.Search(ctx, q, types, catID, limit)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("search: %w", err))
+	}
+	return dto.SearchResultsToResponse(hits), nil
+}
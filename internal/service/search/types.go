@@ -0,0 +1,25 @@
+package search
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		Search(ctx context.Context, q string, types []string, catID *uint, limit int) ([]entity.SearchHit, error)
+	}
+
+	service struct {
+		repo repo
+		l    *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	l *slog.Logger,
+) service {
+	return service{repo, l}
+}
@@ -0,0 +1,112 @@
+package template
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+)
+
+func (s service) GetTemplates(ctx context.Context) ([]response.MissionTemplate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	templates, err := s.repo.GetTemplates(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get mission templates: %w", err))
+	}
+	return dto.TemplatesToResponse(templates), nil
+}
+
+func (s service) GetTemplate(ctx context.Context, templateID uint) (response.MissionTemplate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	template, err := s.repo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return response.MissionTemplate{}, config.DBError(fmt.Errorf("get mission template: %w", err))
+	}
+	if template.ID == 0 {
+		return response.MissionTemplate{}, config.ErrTemplateNotFound
+	}
+	return dto.TemplateToResponse(template), nil
+}
+
+func (s service) CreateTemplate(ctx context.Context, body request.MissionTemplate) (response.MissionTemplate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	if err := s.checkName(ctx, body.Name, 0); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	if err := s.validateType(ctx, &body); err != nil {
+		return response.MissionTemplate{}, err
+	}
+
+	template, err := s.repo.CreateTemplate(ctx, dto.TemplateToEntity(body, 0))
+	if err != nil {
+		return response.MissionTemplate{}, config.DBError(fmt.Errorf("create mission template: %w", err))
+	}
+	return dto.TemplateToResponse(template), nil
+}
+
+// UpdateTemplate replaces the template, missions created from it are not changed.
+func (s service) UpdateTemplate(ctx context.Context, body request.MissionTemplate, templateID uint) (response.MissionTemplate, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	if _, err := s.GetTemplate(ctx, templateID); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	if err := s.checkName(ctx, body.Name, templateID); err != nil {
+		return response.MissionTemplate{}, err
+	}
+	if err := s.validateType(ctx, &body); err != nil {
+		return response.MissionTemplate{}, err
+	}
+
+	if err := s.repo.UpdateTemplate(ctx, dto.TemplateToEntity(body, templateID)); err != nil {
+		return response.MissionTemplate{}, config.DBError(fmt.Errorf("update mission template: %w", err))
+	}
+	return s.GetTemplate(ctx, templateID)
+}
+
+func (s service) DeleteTemplate(ctx context.Context, templateID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	if _, err := s.GetTemplate(ctx, templateID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTemplate(ctx, templateID); err != nil {
+		return config.DBError(fmt.Errorf("delete mission template: %w", err))
+	}
+	return nil
+}
+
+// checkName fails if another template than templateID has the name.
+func (s service) checkName(ctx context.Context, name string, templateID uint) error {
+	existing, err := s.repo.GetTemplateByName(ctx, name)
+	if err != nil {
+		return config.DBError(fmt.Errorf("get mission template: %w", err))
+	}
+	if existing.ID != 0 && existing.ID != templateID {
+		return config.ErrTemplateDuplicate.WithDetail(existing.Name)
+	}
+	return nil
+}
+
+// validateType checks the targets against the template's type and sets the type
+// to the default one if it was omitted.
+func (s service) validateType(ctx context.Context, body *request.MissionTemplate) error {
+	missionType, err := s.types.GetMissionType(ctx, body.TypeID)
+	if err != nil {
+		return err
+	}
+	body.TypeID = &missionType.ID
+	return dto.ValidateTemplateTargets(*body, missionType)
+}
@@ -0,0 +1,39 @@
+package template
+
+import (
+	entity "backend/internal/entity/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"log/slog"
+)
+
+type (
+	repo interface {
+		GetTemplates(ctx context.Context) ([]entity.MissionTemplate, error)
+		GetTemplate(ctx context.Context, templateID uint) (entity.MissionTemplate, error)
+		GetTemplateByName(ctx context.Context, name string) (entity.MissionTemplate, error)
+
+		CreateTemplate(ctx context.Context, template entity.MissionTemplate) (entity.MissionTemplate, error)
+		UpdateTemplate(ctx context.Context, template entity.MissionTemplate) error
+		DeleteTemplate(ctx context.Context, templateID uint) error
+	}
+
+	// missionTypes resolves the type of a template, the default type for nil.
+	missionTypes interface {
+		GetMissionType(ctx context.Context, typeID *uint) (response.MissionType, error)
+	}
+
+	service struct {
+		repo  repo
+		types missionTypes
+		l     *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	types missionTypes,
+	l *slog.Logger,
+) service {
+	return service{repo, types, l}
+}
@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"backend/config"
+	"backend/internal/auth"
+	dto "backend/internal/dto/cat"
+	entity "backend/internal/entity/cat"
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (s service) GetWebhooks(ctx context.Context) ([]response.Webhook, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	webhooks, err := s.repo.GetWebhooks(ctx)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get webhooks: %w", err))
+	}
+	return dto.WebhooksToResponse(webhooks), nil
+}
+
+func (s service) GetWebhook(ctx context.Context, webhookID uint) (response.Webhook, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Webhook{}, err
+	}
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return response.Webhook{}, err
+	}
+	return dto.WebhookToResponse(webhook), nil
+}
+
+// CreateWebhook subscribes the webhook, returning its secret once.
+func (s service) CreateWebhook(ctx context.Context, body request.Webhook) (response.Webhook, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return response.Webhook{}, err
+	}
+	webhook := dto.WebhookToEntity(body)
+	if webhook.Secret == "" {
+		secret := make([]byte, 24)
+		if _, err := rand.Read(secret); err != nil {
+			return response.Webhook{}, config.Wrap(config.CodeInternal, "generate webhook secret", err)
+		}
+		webhook.Secret = "whsec_" + hex.EncodeToString(secret)
+	}
+
+	webhook, err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return response.Webhook{}, config.DBError(fmt.Errorf("create webhook: %w", err))
+	}
+
+	res := dto.WebhookToResponse(webhook)
+	res.Secret = webhook.Secret
+	return res, nil
+}
+
+func (s service) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		return config.DBError(fmt.Errorf("delete webhook: %w", err))
+	}
+	if !deleted {
+		return config.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// GetDeliveries returns the delivery log of the webhook, newest first.
+func (s service) GetDeliveries(ctx context.Context, webhookID uint) ([]response.WebhookDelivery, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.GetDeliveries(ctx, webhookID, config.MaxWebhookDeliveries)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get webhook deliveries: %w", err))
+	}
+	return dto.WebhookDeliveriesToResponse(deliveries), nil
+}
+
+// Redeliver queues copies of the deliveries with the same payload and
+// event ID, keeping the originals in the log.
+func (s service) Redeliver(ctx context.Context, body request.Redeliver, webhookID uint) ([]response.WebhookDelivery, error) {
+	if err := auth.RequireHandler(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	originals, err := s.repo.GetDeliveriesByIDs(ctx, webhookID, body.DeliveryIDs)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("get webhook deliveries: %w", err))
+	}
+	if len(originals) == 0 {
+		return nil, config.ErrNothingToRedeliver
+	}
+	if len(body.DeliveryIDs) > 0 && len(originals) != len(body.DeliveryIDs) {
+		return nil, config.ErrNothingToRedeliver.WithDetail("some deliveries do not belong to the webhook")
+	}
+
+	now := time.Now()
+	deliveries := make([]entity.WebhookDelivery, 0, len(originals))
+	for _, d := range originals {
+		deliveries = append(deliveries, entity.WebhookDelivery{
+			WebhookID:     webhookID,
+			EventID:       d.EventID,
+			Event:         d.Event,
+			Payload:       d.Payload,
+			Status:        config.DeliveryPending,
+			NextAttemptAt: &now,
+			RedeliveryOf:  &d.ID,
+		})
+	}
+
+	deliveries, err = s.repo.CreateDeliveries(ctx, deliveries)
+	if err != nil {
+		return nil, config.DBError(fmt.Errorf("create webhook deliveries: %w", err))
+	}
+	return dto.WebhookDeliveriesToResponse(deliveries), nil
+}
+
+// Publish queues a delivery of the event to every webhook subscribed to it.
+func (s service) Publish(ctx context.Context, event entity.Event) error {
+	webhooks, err := s.repo.GetWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+
+	payload, err := json.Marshal(response.Event{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	now := time.Now()
+	var deliveries []entity.WebhookDelivery
+	for _, w := range webhooks {
+		if !slices.Contains(strings.Split(w.Events, ","), event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, entity.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       event.ID,
+			Event:         event.Type,
+			Payload:       string(payload),
+			Status:        config.DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if _, err = s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (s service) getWebhook(ctx context.Context, webhookID uint) (entity.Webhook, error) {
+	webhook, err := s.repo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return entity.Webhook{}, config.DBError(fmt.Errorf("get webhook: %w", err))
+	}
+	if webhook.ID == 0 {
+		return entity.Webhook{}, config.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
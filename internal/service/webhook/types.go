@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type (
+	repo interface {
+		GetWebhooks(ctx context.Context) ([]entity.Webhook, error)
+		GetWebhookByID(ctx context.Context, webhookID uint) (entity.Webhook, error)
+		CreateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+		DeleteWebhook(ctx context.Context, webhookID uint) (bool, error)
+
+		GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]entity.WebhookDelivery, error)
+		GetDeliveriesByIDs(ctx context.Context, webhookID uint, ids []uint) ([]entity.WebhookDelivery, error)
+		CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) ([]entity.WebhookDelivery, error)
+		ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error)
+		UpdateDeliveryAttempt(ctx context.Context, delivery entity.WebhookDelivery) error
+	}
+
+	service struct {
+		repo   repo
+		cfg    config.Webhooks
+		client *http.Client
+		l      *slog.Logger
+	}
+)
+
+func NewService(
+	repo repo,
+	cfg config.Webhooks,
+	l *slog.Logger,
+) service {
+	return service{repo, cfg, &http.Client{Timeout: cfg.Timeout}, l}
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/backoff"
+	"backend/pkg/signature"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent    = "X-Spycat-Event"
+	HeaderDelivery = "X-Spycat-Delivery"
+
+	// maxErrorLen bounds the response body kept in the delivery log.
+	maxErrorLen = 512
+)
+
+// Run delivers due webhook deliveries every poll interval until ctx is done.
+func (s service) Run(ctx context.Context) {
+	if s.cfg.PollInterval <= 0 {
+		s.l.Warn("webhook delivery disabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := s.DeliverDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.l.Error("webhook delivery failed", "err", err)
+			}
+			// a full batch means more deliveries may be due
+			if err != nil || n < config.WebhookDeliveryBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends a batch of due deliveries and records the attempts.
+// It returns the number of deliveries attempted.
+func (s service) DeliverDue(ctx context.Context) (int, error) {
+	// the lease outlives an attempt, so a crashed worker's claims are retried
+	lease := 2*s.cfg.Timeout + time.Minute
+
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, time.Now(), lease, config.WebhookDeliveryBatch)
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	webhooks := make(map[uint]entity.Webhook)
+	for _, d := range deliveries {
+		webhook, ok := webhooks[d.WebhookID]
+		if !ok {
+			if webhook, err = s.repo.GetWebhookByID(ctx, d.WebhookID); err != nil {
+				return 0, fmt.Errorf("get webhook: %w", err)
+			}
+			webhooks[d.WebhookID] = webhook
+		}
+
+		d = s.attempt(ctx, webhook, d)
+		if err = s.repo.UpdateDeliveryAttempt(ctx, d); err != nil {
+			return 0, fmt.Errorf("update webhook delivery: %w", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends the delivery and returns it updated with the outcome,
+// scheduling a retry with exponential backoff on failure.
+func (s service) attempt(ctx context.Context, webhook entity.Webhook, d entity.WebhookDelivery) entity.WebhookDelivery {
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = 0
+	d.LastError = ""
+
+	if webhook.ID == 0 {
+		d.Status = config.DeliveryFailed
+		d.NextAttemptAt = nil
+		d.LastError = "webhook deleted"
+		return d
+	}
+
+	status, err := s.send(ctx, webhook, d)
+	d.ResponseStatus = status
+	if err == nil {
+		d.Status = config.DeliverySucceeded
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &now
+		return d
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= s.cfg.MaxAttempts {
+		d.Status = config.DeliveryFailed
+		d.NextAttemptAt = nil
+		return d
+	}
+
+	next := now.Add(backoff.Exponential(s.cfg.BackoffBase, s.cfg.BackoffMax, d.Attempts))
+	d.Status = config.DeliveryPending
+	d.NextAttemptAt = &next
+	return d
+}
+
+func (s service) send(ctx context.Context, webhook entity.Webhook, d entity.WebhookDelivery) (int, error) {
+	body := []byte(d.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(signature.Header, signature.Sign(webhook.Secret, body, time.Now()))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLen))
+		return resp.StatusCode, fmt.Errorf("responded %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/signature"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttempt(t *testing.T) {
+	const secret = "whsec"
+	cfg := config.Webhooks{Timeout: time.Second, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour}
+
+	tests := []struct {
+		name         string
+		status       int
+		deleted      bool
+		attempts     int // before this one
+		wantStatus   string
+		wantResponse int
+		wantRetryIn  time.Duration // 0 if no retry is scheduled
+		wantError    string
+	}{
+		{name: "delivered", status: http.StatusNoContent, wantStatus: config.DeliverySucceeded, wantResponse: http.StatusNoContent},
+		{name: "retried with backoff", status: http.StatusBadGateway, attempts: 1, wantStatus: config.DeliveryPending, wantResponse: http.StatusBadGateway, wantRetryIn: 2 * time.Minute, wantError: "upstream down"},
+		{name: "failed after the last attempt", status: http.StatusInternalServerError, attempts: 2, wantStatus: config.DeliveryFailed, wantResponse: http.StatusInternalServerError, wantError: "upstream down"},
+		{name: "webhook deleted", deleted: true, wantStatus: config.DeliveryFailed, wantError: "webhook deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if err := signature.Verify(secret, body, r.Header.Get(signature.Header), time.Minute, time.Now()); err != nil {
+					t.Errorf("signature: %v", err)
+				}
+				if r.Header.Get(HeaderEvent) != "mission.completed" || r.Header.Get(HeaderDelivery) != "5" {
+					t.Errorf("headers = %v", r.Header)
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, "upstream down\n")
+			}))
+			defer srv.Close()
+
+			webhook := entity.Webhook{ID: 1, URL: srv.URL, Secret: secret}
+			if tt.deleted {
+				webhook = entity.Webhook{}
+			}
+			s := NewService(nil, cfg, slog.New(slog.DiscardHandler))
+
+			before := time.Now()
+			d := s.attempt(context.Background(), webhook, entity.WebhookDelivery{
+				ID:       5,
+				Event:    "mission.completed",
+				Payload:  `{"mission_id":7}`,
+				Attempts: tt.attempts,
+			})
+
+			if d.Attempts != tt.attempts+1 || d.LastAttemptAt == nil {
+				t.Errorf("attempts = %d, last attempt %v", d.Attempts, d.LastAttemptAt)
+			}
+			if d.Status != tt.wantStatus || d.ResponseStatus != tt.wantResponse {
+				t.Errorf("status = %s, %d, want %s, %d", d.Status, d.ResponseStatus, tt.wantStatus, tt.wantResponse)
+			}
+			if !strings.Contains(d.LastError, tt.wantError) || (tt.wantError == "") != (d.LastError == "") {
+				t.Errorf("last error = %q, want %q", d.LastError, tt.wantError)
+			}
+			if (d.DeliveredAt != nil) != (tt.wantStatus == config.DeliverySucceeded) {
+				t.Errorf("delivered at = %v", d.DeliveredAt)
+			}
+
+			if tt.wantRetryIn == 0 {
+				if d.NextAttemptAt != nil {
+					t.Errorf("next attempt = %v, want none", d.NextAttemptAt)
+				}
+				return
+			}
+			if d.NextAttemptAt == nil || d.NextAttemptAt.Before(before.Add(tt.wantRetryIn)) || d.NextAttemptAt.After(time.Now().Add(tt.wantRetryIn)) {
+				t.Errorf("next attempt = %v, want in %v", d.NextAttemptAt, tt.wantRetryIn)
+			}
+		})
+	}
+}
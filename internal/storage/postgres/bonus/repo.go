@@ -0,0 +1,108 @@
+package bonus
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+const selectRules = `
+	SELECT id, created_at, updated_at, deleted_at,
+		kind, amount_amount, amount_currency, COALESCE(multiplier::text, '') AS multiplier,
+		country, min_experience, description
+	FROM bonus_rules
+	WHERE deleted_at IS NULL
+	ORDER BY id ASC`
+
+func (r repo) GetBonusRules(ctx context.Context) (rules []entity.BonusRule, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(selectRules).Scan(&rules).Error
+	return
+}
+
+// LockBonusRules returns the bonus rules, locked against changes until tx ends.
+func (r repo) LockBonusRules(ctx context.Context, tx *gorm.DB) (rules []entity.BonusRule, err error) {
+	err = tx.WithContext(ctx).Raw(selectRules + ` FOR SHARE`).Scan(&rules).Error
+	return
+}
+
+func (r repo) CreateBonusRule(ctx context.Context, rule entity.BonusRule) (entity.BonusRule, error) {
+	db := r.db.Instance().WithContext(ctx)
+	if rule.Multiplier == "" {
+		// empty string is not a valid numeric
+		db = db.Omit("multiplier")
+	}
+	err := db.Create(&rule).Error
+	return rule, err
+}
+
+func (r repo) DeleteBonusRule(ctx context.Context, ruleID uint) (deleted bool, err error) {
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE bonus_rules
+		SET deleted_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		time.Now(), ruleID)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r repo) GetBonusEntriesByCat(ctx context.Context, catID uint) (entries []entity.BonusEntry, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM bonus_ledger
+		WHERE cat_id = ?
+		ORDER BY created_at DESC, id DESC`,
+		catID).Scan(&entries).Error
+	return
+}
+
+func (r repo) CreateBonusEntries(ctx context.Context, tx *gorm.DB, entries []entity.BonusEntry) error {
+	return tx.WithContext(ctx).Create(&entries).Error
+}
+
+// NormalizeCountries rewrites country multiplier countries to normalize(country),
+// returning the distinct countries it could not resolve.
+func (r repo) NormalizeCountries(ctx context.Context, normalize func(string) (string, bool)) (unknown []string, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	var countries []string
+	if err = db.Raw(`SELECT DISTINCT country FROM bonus_rules WHERE country <> ''`).Scan(&countries).Error; err != nil {
+		return nil, err
+	}
+
+	for _, country := range countries {
+		code, ok := normalize(country)
+		if !ok {
+			unknown = append(unknown, country)
+			continue
+		}
+		if code == country {
+			continue
+		}
+		if err = db.Exec(`
+			UPDATE bonus_rules
+			SET country = ?, updated_at = ?
+			WHERE country = ?`,
+			code, time.Now(), country).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return unknown, nil
+}
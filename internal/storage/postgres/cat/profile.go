@@ -0,0 +1,148 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetCatProfiles loads skills, languages and leaves of the cats in place.
+func (r repo) GetCatProfiles(ctx context.Context, cats []entity.Cat) error {
+	if len(cats) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(cats))
+	index := make(map[uint]int, len(cats))
+	for i := range cats {
+		ids = append(ids, cats[i].ID)
+		index[cats[i].ID] = i
+
+		cats[i].Skills = make([]entity.CatSkill, 0)
+		cats[i].Languages = make([]entity.CatLanguage, 0)
+		cats[i].Leaves = make([]entity.CatLeave, 0)
+	}
+
+	db := r.db.Instance().WithContext(ctx)
+
+	var skills []entity.CatSkill
+	if err := db.Raw(`
+		SELECT * FROM cat_skills
+		WHERE cat_id IN ?
+		ORDER BY proficiency DESC, name ASC`,
+		ids).Scan(&skills).Error; err != nil {
+		return err
+	}
+	for _, s := range skills {
+		cats[index[s.CatID]].Skills = append(cats[index[s.CatID]].Skills, s)
+	}
+
+	var languages []entity.CatLanguage
+	if err := db.Raw(`
+		SELECT * FROM cat_languages
+		WHERE cat_id IN ?
+		ORDER BY id ASC`,
+		ids).Scan(&languages).Error; err != nil {
+		return err
+	}
+	for _, l := range languages {
+		cats[index[l.CatID]].Languages = append(cats[index[l.CatID]].Languages, l)
+	}
+
+	var leaves []entity.CatLeave
+	if err := db.Raw(`
+		SELECT * FROM cat_leaves
+		WHERE cat_id IN ?
+		ORDER BY starts_on ASC`,
+		ids).Scan(&leaves).Error; err != nil {
+		return err
+	}
+	for _, l := range leaves {
+		cats[index[l.CatID]].Leaves = append(cats[index[l.CatID]].Leaves, l)
+	}
+
+	return nil
+}
+
+// UpdateCatProfile saves clearance and home country, and replaces
+// the profile lists which are not nil.
+func (r repo) UpdateCatProfile(ctx context.Context, tx *gorm.DB, cat entity.Cat) error {
+	tx = tx.WithContext(ctx)
+
+	if err := tx.Exec(`
+		UPDATE cats
+		SET clearance_level = ?, home_country = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		cat.ClearanceLevel, cat.HomeCountry, time.Now(),
+		cat.ID).Error; err != nil {
+		return err
+	}
+
+	if cat.Skills != nil {
+		if err := tx.Exec(`DELETE FROM cat_skills WHERE cat_id = ?`, cat.ID).Error; err != nil {
+			return err
+		}
+		if len(cat.Skills) > 0 {
+			if err := tx.Create(&cat.Skills).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if cat.Languages != nil {
+		if err := tx.Exec(`DELETE FROM cat_languages WHERE cat_id = ?`, cat.ID).Error; err != nil {
+			return err
+		}
+		if len(cat.Languages) > 0 {
+			if err := tx.Create(&cat.Languages).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if cat.Leaves != nil {
+		if err := tx.Exec(`DELETE FROM cat_leaves WHERE cat_id = ?`, cat.ID).Error; err != nil {
+			return err
+		}
+		if len(cat.Leaves) > 0 {
+			if err := tx.Create(&cat.Leaves).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// NormalizeHomeCountries rewrites cat home countries to normalize(country),
+// returning the distinct countries it could not resolve.
+func (r repo) NormalizeHomeCountries(ctx context.Context, normalize func(string) (string, bool)) (unknown []string, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	var countries []string
+	if err = db.Raw(`SELECT DISTINCT home_country FROM cats WHERE home_country <> ''`).Scan(&countries).Error; err != nil {
+		return nil, err
+	}
+
+	for _, country := range countries {
+		code, ok := normalize(country)
+		if !ok {
+			unknown = append(unknown, country)
+			continue
+		}
+		if code == country {
+			continue
+		}
+		if err = db.Exec(`
+			UPDATE cats
+			SET home_country = ?
+			WHERE home_country = ?`,
+			code, country).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return unknown, nil
+}
@@ -54,17 +54,82 @@ func (r repo) GetCatByID(ctx context.Context, catID uint) (cat entity.Cat, err e
 	return
 }
 
-func (r repo) CreateCat(ctx context.Context, cat entity.Cat) (entity.Cat, error) {
-	err := r.db.Instance().WithContext(ctx).Create(&cat).Error
+// GetCatByName returns the oldest cat with the given name (case-insensitive).
+func (r repo) GetCatByName(ctx context.Context, name string) (cat entity.Cat, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM cats
+		WHERE LOWER(name) = LOWER(?) AND deleted_at IS NULL
+		ORDER BY id ASC
+		LIMIT 1`,
+		name).Scan(&cat).Error
+	return
+}
+
+// StreamCats calls fn for every cat, reading them from the database one by one.
+func (r repo) StreamCats(ctx context.Context, fn func(entity.Cat) error) error {
+	db := r.db.Instance().WithContext(ctx)
+
+	rows, err := db.Raw(`
+		SELECT * FROM cats
+		WHERE deleted_at IS NULL
+		ORDER BY id ASC`).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cat entity.Cat
+		if err := db.ScanRows(rows, &cat); err != nil {
+			return err
+		}
+		if err := fn(cat); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetCatsEmployedBetween returns cats created before to and not deleted before from,
+// including soft deleted ones.
+func (r repo) GetCatsEmployedBetween(ctx context.Context, from, to time.Time) (cats []entity.Cat, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM cats
+		WHERE created_at < ? AND (deleted_at IS NULL OR deleted_at >= ?)
+		ORDER BY id ASC`,
+		to, from).Scan(&cats).Error
+	return
+}
+
+func (r repo) CreateCat(ctx context.Context, tx *gorm.DB, cat entity.Cat) (entity.Cat, error) {
+	err := tx.WithContext(ctx).Create(&cat).Error
 	return cat, err
 }
 
-func (r repo) UpdateCat(ctx context.Context, cat entity.Cat) error {
-	return r.db.Instance().WithContext(ctx).Exec(`
+func (r repo) CreateCats(ctx context.Context, tx *gorm.DB, cats []entity.Cat) ([]entity.Cat, error) {
+	err := tx.WithContext(ctx).CreateInBatches(&cats, 100).Error
+	return cats, err
+}
+
+// LockCat locks the cat until the end of tx, serializing changes to its
+// history. It reports whether the cat exists.
+func (r repo) LockCat(ctx context.Context, tx *gorm.DB, catID uint) (bool, error) {
+	var ids []uint
+	err := tx.WithContext(ctx).Raw(`
+		SELECT id FROM cats
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE`,
+		catID).Scan(&ids).Error
+	return len(ids) > 0, err
+}
+
+func (r repo) UpdateCat(ctx context.Context, tx *gorm.DB, cat entity.Cat) error {
+	return tx.WithContext(ctx).Exec(`
 		UPDATE cats
-		SET salary = ?, updated_at = ?
+		SET salary_amount = ?, salary_currency = ?, updated_at = ?
 		WHERE id = ? AND deleted_at IS NULL`,
-		cat.Salary, time.Now(),
+		cat.Salary.Amount, cat.Salary.Currency, time.Now(),
 		cat.ID).Error
 }
 
@@ -0,0 +1,47 @@
+package cat
+
+import (
+	entity "backend/internal/entity/cat"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (r repo) GetStatusHistory(ctx context.Context, catID uint) (changes []entity.CatStatusChange, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM cat_status_changes
+		WHERE cat_id = ?
+		ORDER BY created_at DESC, id DESC`,
+		catID).Scan(&changes).Error
+	return
+}
+
+// GetStatusChangesBefore returns status transitions made before the given time
+// for all cats, ordered by cat and then chronologically.
+func (r repo) GetStatusChangesBefore(ctx context.Context, before time.Time) (changes []entity.CatStatusChange, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM cat_status_changes
+		WHERE created_at < ?
+		ORDER BY cat_id ASC, created_at ASC, id ASC`,
+		before).Scan(&changes).Error
+	return
+}
+
+// UpdateCatStatus sets the status and records the transition, unless the
+// status is no longer change.From. It reports whether the status was updated.
+func (r repo) UpdateCatStatus(ctx context.Context, tx *gorm.DB, change entity.CatStatusChange) (bool, error) {
+	tx = tx.WithContext(ctx)
+
+	res := tx.Exec(`
+		UPDATE cats
+		SET status = ?, status_reason = ?, updated_at = ?
+		WHERE id = ? AND status = ? AND deleted_at IS NULL`,
+		change.To, change.Reason, time.Now(),
+		change.CatID, change.From)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+
+	return true, tx.Create(&change).Error
+}
@@ -0,0 +1,88 @@
+package debrief
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+// GetDebrief returns the mission debrief with its target outcomes, ID is 0 if there is none.
+func (r repo) GetDebrief(ctx context.Context, missionID uint) (debrief entity.Debrief, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	err = db.Raw(`
+		SELECT * FROM debriefs
+		WHERE mission_id = ?`,
+		missionID).Scan(&debrief).Error
+	if err != nil || debrief.ID == 0 {
+		return
+	}
+
+	err = db.Raw(`
+		SELECT * FROM debrief_targets
+		WHERE debrief_id = ?
+		ORDER BY target_id ASC`,
+		debrief.ID).Scan(&debrief.Targets).Error
+	return
+}
+
+// SaveDebrief creates or updates the handler part of the mission debrief,
+// the target outcomes are replaced. The self-assessment is kept.
+func (r repo) SaveDebrief(ctx context.Context, debrief entity.Debrief) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var debriefID uint
+		err := tx.Raw(`
+			INSERT INTO debriefs (created_at, updated_at, mission_id, summary, rating, self_assessment)
+			VALUES (?, ?, ?, ?, ?, '')
+			ON CONFLICT (mission_id) DO UPDATE
+			SET summary = EXCLUDED.summary, rating = EXCLUDED.rating, updated_at = EXCLUDED.updated_at
+			RETURNING id`,
+			now, now, debrief.MissionID, debrief.Summary, debrief.Rating).Scan(&debriefID).Error
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Exec(`DELETE FROM debrief_targets WHERE debrief_id = ?`, debriefID).Error; err != nil {
+			return err
+		}
+		if len(debrief.Targets) == 0 {
+			return nil
+		}
+		for i := range debrief.Targets {
+			debrief.Targets[i].DebriefID = debriefID
+		}
+		return tx.Create(&debrief.Targets).Error
+	})
+}
+
+// SaveSelfAssessment creates or updates the cat part of the mission debrief.
+func (r repo) SaveSelfAssessment(ctx context.Context, missionID uint, selfAssessment string) error {
+	now := time.Now()
+	return r.db.Instance().WithContext(ctx).Exec(`
+		INSERT INTO debriefs (created_at, updated_at, mission_id, summary, self_assessment)
+		VALUES (?, ?, ?, '', ?)
+		ON CONFLICT (mission_id) DO UPDATE
+		SET self_assessment = EXCLUDED.self_assessment, updated_at = EXCLUDED.updated_at`,
+		now, now, missionID, selfAssessment).Error
+}
@@ -0,0 +1,230 @@
+package dossier
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/envelope"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db    postgres.Database
+	notes envelope.Cipher
+}
+
+func NewRepo(db postgres.Database, notes envelope.Cipher) repo {
+	return repo{db, notes}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+// GetDossiers returns dossiers whose name or alias contains q, all if q is empty.
+func (r repo) GetDossiers(ctx context.Context, q string) (dossiers []entity.Dossier, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT d.* FROM dossiers d
+		WHERE d.deleted_at IS NULL AND (? = '' OR d.name ILIKE '%' || ? || '%' OR EXISTS (
+			SELECT 1 FROM dossier_aliases a
+			WHERE a.dossier_id = d.id AND a.alias ILIKE '%' || ? || '%'))
+		ORDER BY d.name ASC, d.id ASC`,
+		q, q, q).Scan(&dossiers).Error
+	if err != nil {
+		return nil, err
+	}
+	return dossiers, r.loadDetails(ctx, dossiers)
+}
+
+// GetDossiersByCountry returns dossiers of the country with their aliases.
+func (r repo) GetDossiersByCountry(ctx context.Context, country string) (dossiers []entity.Dossier, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM dossiers
+		WHERE country = ? AND deleted_at IS NULL
+		ORDER BY id ASC`,
+		country).Scan(&dossiers).Error
+	if err != nil {
+		return nil, err
+	}
+	return dossiers, r.loadDetails(ctx, dossiers)
+}
+
+func (r repo) GetDossierByID(ctx context.Context, dossierID uint) (entity.Dossier, error) {
+	var dossier entity.Dossier
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM dossiers
+		WHERE id = ? AND deleted_at IS NULL`,
+		dossierID).Scan(&dossier).Error
+	if err != nil || dossier.ID == 0 {
+		return dossier, err
+	}
+
+	dossiers := []entity.Dossier{dossier}
+	err = r.loadDetails(ctx, dossiers)
+	return dossiers[0], err
+}
+
+// GetDossierHistory returns every target ever linked to the dossier, including
+// soft deleted ones, and their missions.
+func (r repo) GetDossierHistory(ctx context.Context, dossierID uint) (targets []entity.Target, missions []entity.Mission, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	if err = db.Raw(`
+		SELECT * FROM targets
+		WHERE dossier_id = ?
+		ORDER BY created_at ASC, id ASC`,
+		dossierID).Scan(&targets).Error; err != nil {
+		return nil, nil, err
+	}
+	for i := range targets {
+		aad := envelope.RecordAAD("targets", targets[i].ID)
+		if targets[i].Notes, err = r.notes.Decrypt(ctx, targets[i].Notes, targets[i].NotesKeyID, aad); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(targets) == 0 {
+		return targets, nil, nil
+	}
+
+	missionIDs := make([]uint, 0, len(targets))
+	for _, t := range targets {
+		missionIDs = append(missionIDs, t.MissionID)
+	}
+
+	err = db.Raw(`
+		SELECT * FROM missions
+		WHERE id IN ?`,
+		missionIDs).Scan(&missions).Error
+	return targets, missions, err
+}
+
+func (r repo) CreateDossier(ctx context.Context, tx *gorm.DB, dossier entity.Dossier) (entity.Dossier, error) {
+	tx = tx.WithContext(ctx)
+
+	if err := tx.Create(&dossier).Error; err != nil {
+		return dossier, err
+	}
+	return dossier, r.saveDetails(tx, &dossier)
+}
+
+// UpdateDossier saves name and country, and replaces aliases
+// and attributes which are not nil.
+func (r repo) UpdateDossier(ctx context.Context, tx *gorm.DB, dossier entity.Dossier) error {
+	tx = tx.WithContext(ctx)
+
+	if err := tx.Exec(`
+		UPDATE dossiers
+		SET name = ?, country = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		dossier.Name, dossier.Country, time.Now(),
+		dossier.ID).Error; err != nil {
+		return err
+	}
+
+	if dossier.Aliases != nil {
+		if err := tx.Exec(`DELETE FROM dossier_aliases WHERE dossier_id = ?`, dossier.ID).Error; err != nil {
+			return err
+		}
+	}
+	if dossier.Attributes != nil {
+		if err := tx.Exec(`DELETE FROM dossier_attributes WHERE dossier_id = ?`, dossier.ID).Error; err != nil {
+			return err
+		}
+	}
+	return r.saveDetails(tx, &dossier)
+}
+
+// BackfillDossiers links targets without a dossier to one with the same name
+// and country, creating the missing dossiers.
+func (r repo) BackfillDossiers(ctx context.Context) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO dossiers (created_at, updated_at, name, country)
+			SELECT MIN(t.created_at), NOW(), MIN(TRIM(t.name)), t.country
+			FROM targets t
+			WHERE t.dossier_id IS NULL AND NOT EXISTS (
+				SELECT 1 FROM dossiers d
+				WHERE LOWER(d.name) = LOWER(TRIM(t.name)) AND d.country = t.country AND d.deleted_at IS NULL)
+			GROUP BY LOWER(TRIM(t.name)), t.country`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE targets t
+			SET dossier_id = (
+				SELECT MIN(d.id) FROM dossiers d
+				WHERE LOWER(d.name) = LOWER(TRIM(t.name)) AND d.country = t.country AND d.deleted_at IS NULL)
+			WHERE t.dossier_id IS NULL`).Error
+	})
+}
+
+func (r repo) loadDetails(ctx context.Context, dossiers []entity.Dossier) error {
+	if len(dossiers) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(dossiers))
+	index := make(map[uint]int, len(dossiers))
+	for i := range dossiers {
+		ids = append(ids, dossiers[i].ID)
+		index[dossiers[i].ID] = i
+
+		dossiers[i].Aliases = make([]entity.DossierAlias, 0)
+		dossiers[i].Attributes = make([]entity.DossierAttribute, 0)
+	}
+
+	db := r.db.Instance().WithContext(ctx)
+
+	var aliases []entity.DossierAlias
+	if err := db.Raw(`
+		SELECT * FROM dossier_aliases
+		WHERE dossier_id IN ?
+		ORDER BY id ASC`,
+		ids).Scan(&aliases).Error; err != nil {
+		return err
+	}
+	for _, a := range aliases {
+		dossiers[index[a.DossierID]].Aliases = append(dossiers[index[a.DossierID]].Aliases, a)
+	}
+
+	var attributes []entity.DossierAttribute
+	if err := db.Raw(`
+		SELECT * FROM dossier_attributes
+		WHERE dossier_id IN ?
+		ORDER BY key ASC`,
+		ids).Scan(&attributes).Error; err != nil {
+		return err
+	}
+	for _, a := range attributes {
+		dossiers[index[a.DossierID]].Attributes = append(dossiers[index[a.DossierID]].Attributes, a)
+	}
+
+	return nil
+}
+
+func (r repo) saveDetails(tx *gorm.DB, dossier *entity.Dossier) error {
+	for i := range dossier.Aliases {
+		dossier.Aliases[i].DossierID = dossier.ID
+	}
+	for i := range dossier.Attributes {
+		dossier.Attributes[i].DossierID = dossier.ID
+	}
+
+	if len(dossier.Aliases) > 0 {
+		if err := tx.Create(&dossier.Aliases).Error; err != nil {
+			return err
+		}
+	}
+	if len(dossier.Attributes) > 0 {
+		if err := tx.Create(&dossier.Attributes).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package evidence
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetEvidence(ctx context.Context, missionID, targetID uint) (evidence []entity.Evidence, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM target_evidence
+		WHERE mission_id = ? AND target_id = ?
+		ORDER BY id ASC`,
+		missionID, targetID).Scan(&evidence).Error
+	return
+}
+
+func (r repo) GetEvidenceByID(ctx context.Context, evidenceID uint) (evidence entity.Evidence, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM target_evidence
+		WHERE id = ?`,
+		evidenceID).Scan(&evidence).Error
+	return
+}
+
+// CountEvidence counts the evidence of the target in tx, which should hold the
+// target lock so the evidence cannot be deleted before tx ends.
+func (r repo) CountEvidence(ctx context.Context, tx *gorm.DB, targetID uint) (count int64, err error) {
+	err = tx.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM target_evidence
+		WHERE target_id = ?`,
+		targetID).Scan(&count).Error
+	return
+}
+
+func (r repo) CreateEvidence(ctx context.Context, evidence entity.Evidence) (entity.Evidence, error) {
+	err := r.db.Instance().WithContext(ctx).Create(&evidence).Error
+	return evidence, err
+}
+
+// DeleteEvidence deletes the evidence unless its target is completed,
+// reporting whether it was deleted. The target is locked for the check, so
+// the delete waits for a concurrent completion and then fails.
+func (r repo) DeleteEvidence(ctx context.Context, evidenceID uint) (bool, error) {
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		DELETE FROM target_evidence e
+		WHERE e.id = ? AND EXISTS (
+			SELECT 1 FROM targets t
+			WHERE t.id = e.target_id AND t.is_completed = false
+			FOR SHARE
+		)`,
+		evidenceID)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,30 @@
+package evidence
+
+import (
+	"backend/pkg/postgres/postgrestest"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDeleteEvidence(t *testing.T) {
+	for _, affected := range []int64{0, 1} {
+		var query string
+		db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+			query = q.SQL
+			return postgrestest.Result{RowsAffected: affected}
+		})
+
+		deleted, err := NewRepo(db).DeleteEvidence(context.Background(), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != (affected == 1) {
+			t.Errorf("deleted = %v with %d rows affected", deleted, affected)
+		}
+		// the shared lock waits for a completion holding the target
+		if !strings.Contains(query, "t.is_completed = false FOR SHARE") {
+			t.Errorf("query = %q, want the uncompleted target locked", query)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package exchangerate
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetExchangeRates(ctx context.Context) (rates []entity.ExchangeRate, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT id, created_at, updated_at, base_currency, quote_currency, rate::text AS rate
+		FROM exchange_rates
+		ORDER BY base_currency ASC, quote_currency ASC`).Scan(&rates).Error
+	return
+}
+
+func (r repo) UpsertExchangeRate(ctx context.Context, rate entity.ExchangeRate) error {
+	now := time.Now()
+	return r.db.Instance().WithContext(ctx).Exec(`
+		INSERT INTO exchange_rates (created_at, updated_at, base_currency, quote_currency, rate)
+		VALUES (?, ?, ?, ?, ?::numeric)
+		ON CONFLICT (base_currency, quote_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at`,
+		now, now, rate.BaseCurrency, rate.QuoteCurrency, rate.Rate).Error
+}
+
+func (r repo) DeleteExchangeRate(ctx context.Context, base, quote string) (deleted bool, err error) {
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		DELETE FROM exchange_rates
+		WHERE base_currency = ? AND quote_currency = ?`,
+		base, quote)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,100 @@
+package message
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/api/caller"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+// GetMessages returns the messages of the mission after afterID, oldest first,
+// with their attachments but not the attachment data.
+func (r repo) GetMessages(ctx context.Context, missionID, afterID uint) (messages []entity.Message, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	err = db.Raw(`
+		SELECT * FROM messages
+		WHERE mission_id = ? AND id > ?
+		ORDER BY id ASC`,
+		missionID, afterID).Scan(&messages).Error
+	if err != nil || len(messages) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(messages))
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+
+	var attachments []entity.MessageAttachment
+	err = db.Raw(`
+		SELECT id, created_at, message_id, filename, content_type, size
+		FROM message_attachments
+		WHERE message_id IN ?
+		ORDER BY id ASC`,
+		ids).Scan(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byMessage := make(map[uint][]entity.MessageAttachment, len(messages))
+	for _, a := range attachments {
+		byMessage[a.MessageID] = append(byMessage[a.MessageID], a)
+	}
+	for i := range messages {
+		messages[i].Attachments = byMessage[messages[i].ID]
+	}
+	return
+}
+
+// GetAttachment returns the attachment with its data, if its message belongs to the mission.
+func (r repo) GetAttachment(ctx context.Context, missionID, messageID, attachmentID uint) (attachment entity.MessageAttachment, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT a.* FROM message_attachments a
+		JOIN messages m ON m.id = a.message_id
+		WHERE a.id = ? AND a.message_id = ? AND m.mission_id = ?`,
+		attachmentID, messageID, missionID).Scan(&attachment).Error
+	return
+}
+
+// CreateMessage saves the message with its attachments.
+func (r repo) CreateMessage(ctx context.Context, message entity.Message) (entity.Message, error) {
+	err := r.db.Instance().WithContext(ctx).Create(&message).Error
+	return message, err
+}
+
+// MarkRead records that the reader role read the messages of the other side
+// up to upToID, all of them if upToID is 0. It returns how many were marked.
+func (r repo) MarkRead(ctx context.Context, missionID, upToID uint, readerRole string, at time.Time) (int64, error) {
+	column := "read_by_handler_at"
+	if readerRole == caller.RoleCat {
+		column = "read_by_cat_at"
+	}
+
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE messages
+		SET `+column+` = ?
+		WHERE mission_id = ? AND (? = 0 OR id <= ?)
+			AND author_role <> ? AND `+column+` IS NULL`,
+		at, missionID, upToID, upToID, readerRole)
+	return res.RowsAffected, res.Error
+}
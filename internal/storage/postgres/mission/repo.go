@@ -1,21 +1,27 @@
 package mission
 
 import (
+	"backend/config"
 	entity "backend/internal/entity/cat"
+	"backend/pkg/envelope"
+	"backend/pkg/money"
 	"backend/pkg/postgres"
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type repo struct {
-	db postgres.Database
+	db    postgres.Database
+	notes envelope.Cipher
 }
 
-func NewRepo(db postgres.Database) repo {
-	return repo{db}
+func NewRepo(db postgres.Database, notes envelope.Cipher) repo {
+	return repo{db, notes}
 }
 
 func (r repo) GetDB(ctx context.Context) *gorm.DB {
@@ -26,20 +32,27 @@ func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
 	return r.db.Instance().WithContext(ctx).Begin()
 }
 
-func (r repo) GetMissions(ctx context.Context) ([]entity.Mission, error) {
+// GetMissions returns missions matching the filter with their targets,
+// newest first unless the filter sorts otherwise.
+func (r repo) GetMissions(ctx context.Context, filter entity.MissionFilter) ([]entity.Mission, error) {
+	where, args := missionFilterWhere(filter)
+
 	rows, err := r.db.Instance().WithContext(ctx).Raw(`
 		SELECT 
 			m.id, m.created_at, m.updated_at, m.deleted_at,
-			m.cat_id, m.is_completed,
+			m.cat_id, m.type_id, m.is_completed, m.priority, m.starts_at, m.due_at, m.overdue_at,
 			c.id, c.created_at, c.updated_at, c.deleted_at,
-			c.name, c.years_experience, c.breed, c.salary,
+			c.name, c.years_experience, c.breed, c.salary_amount, c.salary_currency,
+			c.status, c.status_reason,
 			t.id, t.created_at, t.updated_at, t.deleted_at,
-			t.mission_id, t.name, t.country, t.notes, t.is_completed
+			t.mission_id, t.dossier_id, t.name, t.country, t.notes, t.notes_key_id, t.is_completed,
+			t.priority, t.starts_at, t.due_at
 		FROM missions m
-		LEFT JOIN cats c ON m.cat_id = c.id AND c.deleted_at IS NULL
+		LEFT JOIN cats c ON m.cat_id = c.id
 		LEFT JOIN targets t ON m.id = t.mission_id AND t.deleted_at IS NULL
-		WHERE m.deleted_at IS NULL
-		ORDER BY m.created_at DESC, t.id ASC`).Rows()
+		WHERE m.deleted_at IS NULL`+where+`
+		ORDER BY `+missionOrder(filter)+`, t.id ASC`,
+		args...).Rows()
 	if err != nil {
 		return nil, err
 	}
@@ -54,25 +67,27 @@ func (r repo) GetMissions(ctx context.Context) ([]entity.Mission, error) {
 			target  entity.Target
 
 			catID, catYearsExperience, catSalary,
-			targetID, targetMissionID sql.NullInt64
+			targetID, targetMissionID, targetDossierID sql.NullInt64
 
 			catCreatedAt, catUpdatedAt,
 			catDeletedAt, targetCreatedAt, targetUpdatedAt,
-			targetDeletedAt sql.NullTime
+			targetDeletedAt, targetStartsAt, targetDueAt sql.NullTime
 
-			catName, catBreed, targetName,
-			targetCountry, targetNotes sql.NullString
+			catName, catBreed, catSalaryCurrency, catStatus, catStatusReason, targetName,
+			targetCountry, targetNotes, targetNotesKeyID, targetPriority sql.NullString
 
 			targetIsCompleted sql.NullBool
 		)
 
 		if err = rows.Scan(
 			&mission.ID, &mission.CreatedAt, &mission.UpdatedAt, &mission.DeletedAt,
-			&mission.CatID, &mission.IsCompleted,
+			&mission.CatID, &mission.TypeID, &mission.IsCompleted, &mission.Priority, &mission.StartsAt, &mission.DueAt, &mission.OverdueAt,
 			&catID, &catCreatedAt, &catUpdatedAt, &catDeletedAt,
-			&catName, &catYearsExperience, &catBreed, &catSalary,
+			&catName, &catYearsExperience, &catBreed, &catSalary, &catSalaryCurrency,
+			&catStatus, &catStatusReason,
 			&targetID, &targetCreatedAt, &targetUpdatedAt, &targetDeletedAt,
-			&targetMissionID, &targetName, &targetCountry, &targetNotes, &targetIsCompleted,
+			&targetMissionID, &targetDossierID, &targetName, &targetCountry, &targetNotes, &targetNotesKeyID, &targetIsCompleted,
+			&targetPriority, &targetStartsAt, &targetDueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -89,7 +104,9 @@ func (r repo) GetMissions(ctx context.Context) ([]entity.Mission, error) {
 					Name:            catName.String,
 					YearsExperience: uint8(catYearsExperience.Int64),
 					Breed:           catBreed.String,
-					Salary:          uint64(catSalary.Int64),
+					Salary:          money.New(catSalary.Int64, catSalaryCurrency.String),
+					Status:          catStatus.String,
+					StatusReason:    catStatusReason.String,
 				}
 				if catDeletedAt.Valid {
 					deletedAt := catDeletedAt.Time
@@ -110,8 +127,24 @@ func (r repo) GetMissions(ctx context.Context) ([]entity.Mission, error) {
 				MissionID:   uint(targetMissionID.Int64),
 				Name:        targetName.String,
 				Country:     targetCountry.String,
-				Notes:       targetNotes.String,
 				IsCompleted: targetIsCompleted.Bool,
+				Priority:    targetPriority.String,
+			}
+			if targetStartsAt.Valid {
+				startsAt := targetStartsAt.Time
+				target.StartsAt = &startsAt
+			}
+			if targetDueAt.Valid {
+				dueAt := targetDueAt.Time
+				target.DueAt = &dueAt
+			}
+			aad := envelope.RecordAAD("targets", target.ID)
+			if target.Notes, err = r.notes.Decrypt(ctx, targetNotes.String, targetNotesKeyID.String, aad); err != nil {
+				return nil, err
+			}
+			if targetDossierID.Valid {
+				dossierID := uint(targetDossierID.Int64)
+				target.DossierID = &dossierID
 			}
 			if targetDeletedAt.Valid {
 				deletedAt := targetDeletedAt.Time
@@ -130,16 +163,46 @@ func (r repo) GetMissions(ctx context.Context) ([]entity.Mission, error) {
 }
 
 func (r repo) GetMission(ctx context.Context, missionID uint) (entity.Mission, error) {
-	rows, err := r.db.Instance().WithContext(ctx).Raw(`
+	return r.getMission(ctx, r.db.Instance().WithContext(ctx), missionID)
+}
+
+// LockMission locks the mission and its targets until tx ends and returns the
+// mission as of the lock.
+func (r repo) LockMission(ctx context.Context, tx *gorm.DB, missionID uint) (entity.Mission, error) {
+	db := tx.WithContext(ctx)
+	err := db.Exec(`
+		SELECT id FROM missions
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE`,
+		missionID).Error
+	if err != nil {
+		return entity.Mission{}, err
+	}
+	err = db.Exec(`
+		SELECT id FROM targets
+		WHERE mission_id = ? AND deleted_at IS NULL
+		ORDER BY id ASC
+		FOR UPDATE`,
+		missionID).Error
+	if err != nil {
+		return entity.Mission{}, err
+	}
+	return r.getMission(ctx, db, missionID)
+}
+
+func (r repo) getMission(ctx context.Context, db *gorm.DB, missionID uint) (entity.Mission, error) {
+	rows, err := db.Raw(`
 		SELECT 
 			m.id, m.created_at, m.updated_at, m.deleted_at,
-			m.cat_id, m.is_completed,
+			m.cat_id, m.type_id, m.is_completed, m.priority, m.starts_at, m.due_at, m.overdue_at,
 			c.id, c.created_at, c.updated_at, c.deleted_at,
-			c.name, c.years_experience, c.breed, c.salary,
+			c.name, c.years_experience, c.breed, c.salary_amount, c.salary_currency,
+			c.status, c.status_reason,
 			t.id, t.created_at, t.updated_at, t.deleted_at,
-			t.mission_id, t.name, t.country, t.notes, t.is_completed
+			t.mission_id, t.dossier_id, t.name, t.country, t.notes, t.notes_key_id, t.is_completed,
+			t.priority, t.starts_at, t.due_at
 		FROM missions m
-		LEFT JOIN cats c ON m.cat_id = c.id AND c.deleted_at IS NULL
+		LEFT JOIN cats c ON m.cat_id = c.id
 		LEFT JOIN targets t ON m.id = t.mission_id AND t.deleted_at IS NULL
 		WHERE m.id = ? AND m.deleted_at IS NULL
 		ORDER BY t.id ASC`,
@@ -159,25 +222,27 @@ func (r repo) GetMission(ctx context.Context, missionID uint) (entity.Mission, e
 			target      entity.Target
 
 			catID, catYearsExperience, catSalary,
-			targetID, targetMissionID sql.NullInt64
+			targetID, targetMissionID, targetDossierID sql.NullInt64
 
 			catCreatedAt, catUpdatedAt,
 			catDeletedAt, targetCreatedAt, targetUpdatedAt,
-			targetDeletedAt sql.NullTime
+			targetDeletedAt, targetStartsAt, targetDueAt sql.NullTime
 
-			catName, catBreed, targetName,
-			targetCountry, targetNotes sql.NullString
+			catName, catBreed, catSalaryCurrency, catStatus, catStatusReason, targetName,
+			targetCountry, targetNotes, targetNotesKeyID, targetPriority sql.NullString
 
 			targetIsCompleted sql.NullBool
 		)
 
 		if err = rows.Scan(
 			&tempMission.ID, &tempMission.CreatedAt, &tempMission.UpdatedAt, &tempMission.DeletedAt,
-			&tempMission.CatID, &tempMission.IsCompleted,
+			&tempMission.CatID, &tempMission.TypeID, &tempMission.IsCompleted, &tempMission.Priority, &tempMission.StartsAt, &tempMission.DueAt, &tempMission.OverdueAt,
 			&catID, &catCreatedAt, &catUpdatedAt, &catDeletedAt,
-			&catName, &catYearsExperience, &catBreed, &catSalary,
+			&catName, &catYearsExperience, &catBreed, &catSalary, &catSalaryCurrency,
+			&catStatus, &catStatusReason,
 			&targetID, &targetCreatedAt, &targetUpdatedAt, &targetDeletedAt,
-			&targetMissionID, &targetName, &targetCountry, &targetNotes, &targetIsCompleted,
+			&targetMissionID, &targetDossierID, &targetName, &targetCountry, &targetNotes, &targetNotesKeyID, &targetIsCompleted,
+			&targetPriority, &targetStartsAt, &targetDueAt,
 		); err != nil {
 			return entity.Mission{}, err
 		}
@@ -194,7 +259,9 @@ func (r repo) GetMission(ctx context.Context, missionID uint) (entity.Mission, e
 					Name:            catName.String,
 					YearsExperience: uint8(catYearsExperience.Int64),
 					Breed:           catBreed.String,
-					Salary:          uint64(catSalary.Int64),
+					Salary:          money.New(catSalary.Int64, catSalaryCurrency.String),
+					Status:          catStatus.String,
+					StatusReason:    catStatusReason.String,
 				}
 				if catDeletedAt.Valid {
 					deletedAt := catDeletedAt.Time
@@ -213,8 +280,24 @@ func (r repo) GetMission(ctx context.Context, missionID uint) (entity.Mission, e
 				MissionID:   uint(targetMissionID.Int64),
 				Name:        targetName.String,
 				Country:     targetCountry.String,
-				Notes:       targetNotes.String,
 				IsCompleted: targetIsCompleted.Bool,
+				Priority:    targetPriority.String,
+			}
+			if targetStartsAt.Valid {
+				startsAt := targetStartsAt.Time
+				target.StartsAt = &startsAt
+			}
+			if targetDueAt.Valid {
+				dueAt := targetDueAt.Time
+				target.DueAt = &dueAt
+			}
+			aad := envelope.RecordAAD("targets", target.ID)
+			if target.Notes, err = r.notes.Decrypt(ctx, targetNotes.String, targetNotesKeyID.String, aad); err != nil {
+				return entity.Mission{}, err
+			}
+			if targetDossierID.Valid {
+				dossierID := uint(targetDossierID.Int64)
+				target.DossierID = &dossierID
 			}
 			if targetDeletedAt.Valid {
 				deletedAt := targetDeletedAt.Time
@@ -232,20 +315,71 @@ func (r repo) CreateMission(ctx context.Context, tx *gorm.DB, mission entity.Mis
 	return mission, err
 }
 
-func (r repo) AssignCat(ctx context.Context, missionID, catID uint) error {
-	return r.db.Instance().WithContext(ctx).Exec(`
+// AssignCat assigns the cat to the mission unless it has one already,
+// reporting whether the mission was assigned.
+func (r repo) AssignCat(ctx context.Context, tx *gorm.DB, missionID, catID uint) (bool, error) {
+	res := tx.WithContext(ctx).Exec(`
 		UPDATE missions
 		SET cat_id = ?, updated_at = ?
-		WHERE id = ? AND deleted_at IS NULL`,
-		catID, time.Now(), missionID).Error
+		WHERE id = ? AND cat_id IS NULL AND deleted_at IS NULL`,
+		catID, time.Now(), missionID)
+	return res.RowsAffected > 0, res.Error
 }
 
-func (r repo) CompleteMission(ctx context.Context, missionID uint) error {
-	return r.db.Instance().WithContext(ctx).Exec(`
+// CompleteMission completes the mission unless it is completed already,
+// reporting whether the mission was completed.
+func (r repo) CompleteMission(ctx context.Context, tx *gorm.DB, missionID uint) (bool, error) {
+	res := tx.WithContext(ctx).Exec(`
 		UPDATE missions
 		SET is_completed = true, updated_at = ?
+		WHERE id = ? AND is_completed = false AND deleted_at IS NULL`,
+		time.Now(), missionID)
+	return res.RowsAffected > 0, res.Error
+}
+
+// UpdateMissionSchedule sets the priority and dates of the mission, clearing
+// the overdue flag if the due date changes.
+func (r repo) UpdateMissionSchedule(ctx context.Context, mission entity.Mission) error {
+	return r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE missions
+		SET priority = ?, starts_at = ?, due_at = ?,
+			overdue_at = CASE WHEN due_at IS NOT DISTINCT FROM ? THEN overdue_at END,
+			updated_at = ?
 		WHERE id = ? AND deleted_at IS NULL`,
-		time.Now(), missionID).Error
+		mission.Priority, mission.StartsAt, mission.DueAt,
+		mission.DueAt, time.Now(), mission.ID).Error
+}
+
+// ClaimOverdueMissions flags incomplete missions due before now which are not
+// flagged as overdue yet, and returns them. The flag is set in one statement
+// and its transaction, so concurrent checks never claim the same mission.
+func (r repo) ClaimOverdueMissions(ctx context.Context, now time.Time) (missions []entity.Mission, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		WITH claimed AS (
+			UPDATE missions
+			SET overdue_at = ?
+			WHERE id IN (
+				SELECT id FROM missions
+				WHERE due_at < ? AND overdue_at IS NULL
+					AND is_completed = false AND deleted_at IS NULL
+				FOR UPDATE SKIP LOCKED
+			) AND overdue_at IS NULL
+			RETURNING *
+		)
+		SELECT * FROM claimed
+		ORDER BY due_at ASC, id ASC`,
+		now, now).Scan(&missions).Error
+	return
+}
+
+// ReleaseOverdue clears the overdue flag set by the claim at, so the
+// mission is claimed again by the next check.
+func (r repo) ReleaseOverdue(ctx context.Context, missionID uint, at time.Time) error {
+	return r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE missions
+		SET overdue_at = NULL
+		WHERE id = ? AND overdue_at = ?`,
+		missionID, at).Error
 }
 
 func (r repo) DeleteMission(ctx context.Context, missionID uint) error {
@@ -255,3 +389,69 @@ func (r repo) DeleteMission(ctx context.Context, missionID uint) error {
 		WHERE id = ? AND deleted_at IS NULL`,
 		time.Now(), missionID).Error
 }
+
+// priorityRank orders priorities from config.Priorities in SQL.
+var priorityRank = func() string {
+	var b strings.Builder
+	b.WriteString("CASE m.priority")
+	for i, p := range config.Priorities {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", p, i)
+	}
+	b.WriteString(" END")
+	return b.String()
+}()
+
+func missionFilterWhere(filter entity.MissionFilter) (string, []any) {
+	var (
+		where strings.Builder
+		args  []any
+	)
+
+	if len(filter.Priorities) > 0 {
+		where.WriteString(" AND m.priority IN ?")
+		args = append(args, filter.Priorities)
+	}
+	if filter.DueAfter != nil {
+		where.WriteString(" AND m.due_at >= ?")
+		args = append(args, *filter.DueAfter)
+	}
+	if filter.DueBefore != nil {
+		where.WriteString(" AND m.due_at < ?")
+		args = append(args, *filter.DueBefore)
+	}
+	if filter.Completed != nil {
+		where.WriteString(" AND m.is_completed = ?")
+		args = append(args, *filter.Completed)
+	}
+	if filter.CatID != nil {
+		where.WriteString(" AND m.cat_id = ?")
+		args = append(args, *filter.CatID)
+	}
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			where.WriteString(" AND m.due_at < NOW() AND m.is_completed = false")
+		} else {
+			where.WriteString(" AND (m.due_at IS NULL OR m.due_at >= NOW() OR m.is_completed = true)")
+		}
+	}
+
+	return where.String(), args
+}
+
+func missionOrder(filter entity.MissionFilter) string {
+	column := "m.created_at"
+	switch filter.Sort {
+	case config.MissionSortPriority:
+		column = priorityRank
+	case config.MissionSortStartsAt:
+		column = "m.starts_at"
+	case config.MissionSortDueAt:
+		column = "m.due_at"
+	}
+
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+	return column + " " + direction + " NULLS LAST, m.created_at DESC, m.id ASC"
+}
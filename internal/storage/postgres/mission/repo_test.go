@@ -0,0 +1,40 @@
+package mission
+
+import (
+	"backend/pkg/envelope"
+	"backend/pkg/postgres/postgrestest"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLockMission(t *testing.T) {
+	db := postgrestest.New(t, func(postgrestest.Query) postgrestest.Result { return postgrestest.Result{} })
+	r := NewRepo(db, envelope.Cipher{})
+
+	ctx := context.Background()
+	tx := r.NewTransaction(ctx)
+	mission, err := r.LockMission(ctx, tx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+	if mission.ID != 0 {
+		t.Errorf("mission = %+v, want none", mission)
+	}
+
+	queries := db.Queries()
+	if len(queries) != 3 {
+		t.Fatalf("queries = %v, want the mission and targets locked, then read", queries)
+	}
+	for i, prefix := range []string{"SELECT id FROM missions", "SELECT id FROM targets", "SELECT m.id"} {
+		q := queries[i]
+		if !strings.HasPrefix(q.SQL, prefix) || (i < 2) != strings.HasSuffix(q.SQL, "FOR UPDATE") ||
+			len(q.Args) != 1 || q.Args[0] != int64(7) {
+			t.Errorf("query %d = %q %v, want %q for mission 7", i, q.SQL, q.Args, prefix)
+		}
+	}
+	if got := db.Transactions(); len(got) != 1 {
+		t.Errorf("transactions = %v, want the locks in the one given", got)
+	}
+}
@@ -0,0 +1,162 @@
+package missiontype
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetMissionTypes(ctx context.Context) (types []entity.MissionType, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_types
+		ORDER BY name ASC`).Scan(&types).Error
+	if err != nil {
+		return nil, err
+	}
+	return types, r.loadCountries(ctx, types)
+}
+
+// GetMissionType returns the type with its countries, ID is 0 if there is none.
+func (r repo) GetMissionType(ctx context.Context, typeID uint) (entity.MissionType, error) {
+	var missionType entity.MissionType
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_types
+		WHERE id = ?`,
+		typeID).Scan(&missionType).Error
+	return r.withCountries(ctx, missionType, err)
+}
+
+// GetMissionTypeByName returns the type with its countries, ID is 0 if there is none.
+func (r repo) GetMissionTypeByName(ctx context.Context, name string) (entity.MissionType, error) {
+	var missionType entity.MissionType
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_types
+		WHERE LOWER(name) = LOWER(?)`,
+		name).Scan(&missionType).Error
+	return r.withCountries(ctx, missionType, err)
+}
+
+func (r repo) CreateMissionType(ctx context.Context, missionType entity.MissionType) (entity.MissionType, error) {
+	err := r.db.Instance().WithContext(ctx).Create(&missionType).Error
+	return missionType, err
+}
+
+// UpdateMissionType replaces the type and its countries.
+func (r repo) UpdateMissionType(ctx context.Context, missionType entity.MissionType) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE mission_types
+			SET name = ?, description = ?, min_targets = ?, max_targets = ?, updated_at = ?
+			WHERE id = ?`,
+			missionType.Name, missionType.Description, missionType.MinTargets, missionType.MaxTargets, time.Now(),
+			missionType.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`DELETE FROM mission_type_countries WHERE mission_type_id = ?`, missionType.ID).Error; err != nil {
+			return err
+		}
+		if len(missionType.Countries) == 0 {
+			return nil
+		}
+		return tx.Create(&missionType.Countries).Error
+	})
+}
+
+func (r repo) DeleteMissionType(ctx context.Context, typeID uint) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM mission_type_countries WHERE mission_type_id = ?`, typeID).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM mission_types WHERE id = ?`, typeID).Error
+	})
+}
+
+// CountUsage returns how many missions, deleted ones included, and templates have the type.
+func (r repo) CountUsage(ctx context.Context, typeID uint) (count int64, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM missions WHERE type_id = ?) +
+			(SELECT COUNT(*) FROM mission_templates WHERE type_id = ?)`,
+		typeID, typeID).Scan(&count).Error
+	return
+}
+
+// EnsureDefaultType creates the default type if missing and assigns it
+// to missions without a type.
+func (r repo) EnsureDefaultType(ctx context.Context, missionType entity.MissionType) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Exec(`
+			INSERT INTO mission_types (created_at, updated_at, name, description, min_targets, max_targets)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (name) DO NOTHING`,
+			now, now, missionType.Name, missionType.Description,
+			missionType.MinTargets, missionType.MaxTargets).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE missions
+			SET type_id = (SELECT id FROM mission_types WHERE name = ?)
+			WHERE type_id IS NULL`,
+			missionType.Name).Error
+	})
+}
+
+func (r repo) withCountries(ctx context.Context, missionType entity.MissionType, err error) (entity.MissionType, error) {
+	if err != nil || missionType.ID == 0 {
+		return missionType, err
+	}
+
+	types := []entity.MissionType{missionType}
+	err = r.loadCountries(ctx, types)
+	return types[0], err
+}
+
+func (r repo) loadCountries(ctx context.Context, types []entity.MissionType) error {
+	if len(types) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(types))
+	byID := make(map[uint]*entity.MissionType, len(types))
+	for i := range types {
+		ids = append(ids, types[i].ID)
+		byID[types[i].ID] = &types[i]
+	}
+
+	var countries []entity.MissionTypeCountry
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_type_countries
+		WHERE mission_type_id IN ?
+		ORDER BY country ASC`,
+		ids).Scan(&countries).Error
+	if err != nil {
+		return err
+	}
+
+	for _, c := range countries {
+		byID[c.MissionTypeID].Countries = append(byID[c.MissionTypeID].Countries, c)
+	}
+	return nil
+}
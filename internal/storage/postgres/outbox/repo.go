@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+// CreateEvents writes the events in the transaction of the change they describe.
+func (r repo) CreateEvents(ctx context.Context, tx *gorm.DB, events ...entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.OutboxEvent, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, entity.OutboxEvent{
+			EventID:       e.ID,
+			Type:          e.Type,
+			AggregateType: e.AggregateType,
+			AggregateID:   e.AggregateID,
+			Payload:       string(e.Payload),
+			OccurredAt:    e.OccurredAt,
+			CreatedAt:     time.Now(),
+		})
+	}
+	return tx.WithContext(ctx).Create(&rows).Error
+}
+
+// ClaimDueEvents returns up to limit events due at now in the order of their
+// transactions, and leases them until now+lease so concurrent relays skip them.
+// Only the first pending event of each aggregate is due, so the events of an
+// aggregate are published one at a time, and only events of transactions older
+// than every running one, so a transaction committing late cannot add events
+// before ones already relayed.
+func (r repo) ClaimDueEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) (events []entity.OutboxEvent, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		UPDATE outbox
+		SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT e.id FROM outbox e
+			WHERE e.published_at IS NULL AND e.dead_at IS NULL
+				AND (e.next_attempt_at IS NULL OR e.next_attempt_at <= ?)
+				AND e.tx_id < pg_snapshot_xmin(pg_current_snapshot())
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox p
+					WHERE p.aggregate_type = e.aggregate_type AND p.aggregate_id = e.aggregate_id
+						AND p.published_at IS NULL AND p.dead_at IS NULL
+						AND (p.tx_id, p.id) < (e.tx_id, e.id))
+			ORDER BY e.tx_id ASC, e.id ASC
+			LIMIT ?
+			FOR UPDATE OF e SKIP LOCKED)
+		RETURNING *`,
+		now.Add(lease), now, limit).Scan(&events).Error
+
+	slices.SortFunc(events, func(a, b entity.OutboxEvent) int {
+		return cmp.Or(cmp.Compare(a.TxID, b.TxID), cmp.Compare(a.ID, b.ID))
+	})
+	return
+}
+
+// UpdateEventAttempt records the outcome of a publish attempt.
+func (r repo) UpdateEventAttempt(ctx context.Context, event entity.OutboxEvent) error {
+	return r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE outbox
+		SET published_at = ?, dead_at = ?, attempts = ?, next_attempt_at = ?, last_error = ?
+		WHERE id = ?`,
+		event.PublishedAt, event.DeadAt, event.Attempts, event.NextAttemptAt, event.LastError,
+		event.ID).Error
+}
+
+// DeletePublishedBefore prunes events published before the given time.
+func (r repo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		DELETE FROM outbox
+		WHERE published_at < ?`,
+		before)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"backend/pkg/postgres/postgrestest"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClaimDueEvents(t *testing.T) {
+	// xid8 transaction ids carry the epoch in their high 32 bits
+	const epoch = int64(1) << 32
+
+	var claim postgrestest.Query
+	db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+		claim = q
+		// RETURNING gives no order
+		return postgrestest.Result{
+			Columns: []string{"id", "tx_id", "event_id", "aggregate_type", "aggregate_id"},
+			Rows: [][]driver.Value{
+				{int64(4), epoch + 7, "evt-4", "mission", int64(2)},
+				{int64(1), epoch + 9, "evt-1", "cat", int64(1)},
+				{int64(3), 2*epoch + 1, "evt-3", "cat", int64(2)},
+				{int64(2), epoch + 7, "evt-2", "mission", int64(1)},
+			},
+		}
+	})
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	events, err := NewRepo(db).ClaimDueEvents(context.Background(), now, time.Minute, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.EventID)
+	}
+	// by transaction, then by id within a transaction
+	if want := "evt-2,evt-4,evt-1,evt-3"; strings.Join(got, ",") != want {
+		t.Errorf("events = %v, want %s", got, want)
+	}
+	if events[3].TxID != uint64(2*epoch+1) {
+		t.Errorf("tx id = %d, want %d", events[3].TxID, 2*epoch+1)
+	}
+
+	if len(claim.Args) != 3 || claim.Args[0] != now.Add(time.Minute) || claim.Args[1] != now || claim.Args[2] != int64(10) {
+		t.Errorf("claim args = %v, want the lease, now and the limit", claim.Args)
+	}
+	for _, guard := range []string{
+		"e.tx_id < pg_snapshot_xmin(pg_current_snapshot())", // transactions still running may commit earlier events
+		"(p.tx_id, p.id) < (e.tx_id, e.id)",                 // one pending event per aggregate
+		"FOR UPDATE OF e SKIP LOCKED",                       // concurrent relays claim other events
+	} {
+		if !strings.Contains(claim.SQL, guard) {
+			t.Errorf("claim query lacks %q", guard)
+		}
+	}
+}
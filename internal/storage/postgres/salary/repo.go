@@ -0,0 +1,87 @@
+package salary
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/money"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetSalaryHistory(ctx context.Context, catID uint) (changes []entity.SalaryChange, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM salary_changes
+		WHERE cat_id = ?
+		ORDER BY effective_from DESC, id DESC`,
+		catID).Scan(&changes).Error
+	return
+}
+
+// GetCatSalaryChanges returns the salary history of the cat chronologically.
+func (r repo) GetCatSalaryChanges(ctx context.Context, tx *gorm.DB, catID uint) (changes []entity.SalaryChange, err error) {
+	err = tx.WithContext(ctx).Raw(`
+		SELECT * FROM salary_changes
+		WHERE cat_id = ?
+		ORDER BY effective_from ASC, id ASC`,
+		catID).Scan(&changes).Error
+	return
+}
+
+// GetSalaryChangesBefore returns changes effective before the given time
+// for all cats, ordered by cat and then chronologically.
+func (r repo) GetSalaryChangesBefore(ctx context.Context, before time.Time) (changes []entity.SalaryChange, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM salary_changes
+		WHERE effective_from < ?
+		ORDER BY cat_id ASC, effective_from ASC, id ASC`,
+		before).Scan(&changes).Error
+	return
+}
+
+func (r repo) CreateSalaryChange(ctx context.Context, tx *gorm.DB, change entity.SalaryChange) error {
+	return tx.WithContext(ctx).Create(&change).Error
+}
+
+func (r repo) UpdatePreviousSalary(ctx context.Context, tx *gorm.DB, changeID uint, previous money.Money) error {
+	return tx.WithContext(ctx).Exec(`
+		UPDATE salary_changes
+		SET previous_salary_amount = ?, previous_salary_currency = ?
+		WHERE id = ?`,
+		previous.Amount, previous.Currency, changeID).Error
+}
+
+func (r repo) CreateSalaryChanges(ctx context.Context, tx *gorm.DB, changes []entity.SalaryChange) error {
+	return tx.WithContext(ctx).CreateInBatches(&changes, 100).Error
+}
+
+// BackfillInitialSalaries records the current salary of cats
+// without any salary history, effective from their creation.
+func (r repo) BackfillInitialSalaries(ctx context.Context, reason string) error {
+	return r.db.Instance().WithContext(ctx).Exec(`
+		INSERT INTO salary_changes (
+			created_at, cat_id, salary_amount, salary_currency,
+			previous_salary_amount, previous_salary_currency, effective_from, reason)
+		SELECT NOW(), c.id, c.salary_amount, c.salary_currency,
+			0, c.salary_currency, DATE_TRUNC('day', c.created_at), ?
+		FROM cats c
+		WHERE NOT EXISTS (SELECT 1 FROM salary_changes sc WHERE sc.cat_id = c.id)`,
+		reason).Error
+}
@@ -0,0 +1,155 @@
+package search
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/envelope"
+	"backend/pkg/postgres"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+// htmlEscaper escapes text before highlighting, as ts_headline copies it into
+// the HTML snippet as is. The parser keeps entities whole, so they are never
+// split by a highlight.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// escapeHTML is the SQL counterpart of htmlEscaper for the expression.
+func escapeHTML(expr string) string {
+	return `REPLACE(REPLACE(REPLACE(REPLACE(` + expr + `, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`
+}
+
+type (
+	repo struct {
+		db    postgres.Database
+		notes envelope.Cipher
+		// searchEncrypted highlights decrypted notes in target snippets.
+		searchEncrypted bool
+	}
+
+	hit struct {
+		entity.SearchHit
+		Notes      string
+		NotesKeyID string
+	}
+)
+
+func NewRepo(db postgres.Database, notes envelope.Cipher, searchEncrypted bool) repo {
+	return repo{db, notes, searchEncrypted}
+}
+
+// Search ranks live targets and cats matching the web search style query q.
+// If catID is set, only targets of the cat missions and the cat itself are searched.
+// Encrypted notes only match through their opt-in notes_search index.
+func (r repo) Search(ctx context.Context, q string, types []string, catID *uint, limit int) ([]entity.SearchHit, error) {
+	var (
+		parts []string
+		args  []any
+	)
+
+	if slices.Contains(types, config.SearchTypeTarget) {
+		query := `
+			SELECT 'target' AS type, t.id, t.mission_id, t.name AS title,
+				ts_headline('english', ` + escapeHTML(`CASE WHEN t.notes_key_id <> '' THEN t.name
+					ELSE CONCAT_WS(' - ', t.name, t.notes) END`) + `, q, '` + headlineOptions + `') AS snippet,
+				ts_rank(t.search, q) + COALESCE(ts_rank(t.notes_search, q), 0) AS rank,
+				t.notes, t.notes_key_id
+			FROM targets t
+			JOIN missions m ON m.id = t.mission_id AND m.deleted_at IS NULL,
+				websearch_to_tsquery('english', ?) q
+			WHERE (t.search @@ q OR t.notes_search @@ q) AND t.deleted_at IS NULL`
+		args = append(args, q)
+		if catID != nil {
+			query += ` AND m.cat_id = ?`
+			args = append(args, *catID)
+		}
+		parts = append(parts, query)
+	}
+
+	if slices.Contains(types, config.SearchTypeCat) {
+		query := `
+			SELECT 'cat' AS type, c.id, NULL::bigint AS mission_id, c.name AS title,
+				ts_headline('simple', ` + escapeHTML("c.name") + `, q, '` + headlineOptions + `') AS snippet,
+				ts_rank(c.search, q) AS rank,
+				'' AS notes, '' AS notes_key_id
+			FROM cats c, websearch_to_tsquery('simple', ?) q
+			WHERE c.search @@ q AND c.deleted_at IS NULL`
+		args = append(args, q)
+		if catID != nil {
+			query += ` AND c.id = ?`
+			args = append(args, *catID)
+		}
+		parts = append(parts, query)
+	}
+
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	var rows []hit
+	args = append(args, limit)
+	if err := r.db.Instance().WithContext(ctx).Raw(
+		strings.Join(parts, "\n\t\t\tUNION ALL")+`
+		ORDER BY rank DESC, type ASC, id ASC
+		LIMIT ?`,
+		args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	if r.notes.Enabled() && r.searchEncrypted {
+		if err := r.highlightEncrypted(ctx, q, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	hits := make([]entity.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, row.SearchHit)
+	}
+	return hits, nil
+}
+
+// highlightEncrypted replaces the snippets of targets with encrypted notes
+// by highlights of the decrypted notes.
+func (r repo) highlightEncrypted(ctx context.Context, q string, rows []hit) error {
+	var (
+		indexes []int
+		values  []string
+		args    = []any{q}
+	)
+	for i, row := range rows {
+		if row.NotesKeyID == "" {
+			continue
+		}
+		notes, err := r.notes.Decrypt(ctx, row.Notes, row.NotesKeyID, envelope.RecordAAD("targets", row.ID))
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, i)
+		values = append(values, fmt.Sprintf("(%d, ?::text)", len(indexes)))
+		args = append(args, htmlEscaper.Replace(row.Title+" - "+notes))
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	var snippets []string
+	if err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT ts_headline('english', d.doc, websearch_to_tsquery('english', ?), '`+headlineOptions+`')
+		FROM (VALUES `+strings.Join(values, ", ")+`) AS d(n, doc)
+		ORDER BY d.n`,
+		args...).Scan(&snippets).Error; err != nil {
+		return err
+	}
+
+	for j, i := range indexes {
+		if j < len(snippets) {
+			rows[i].Snippet = snippets[j]
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package target
 
 import (
 	entity "backend/internal/entity/cat"
+	"backend/pkg/envelope"
 	"backend/pkg/postgres"
 	"context"
 	"time"
@@ -9,12 +10,26 @@ import (
 	"gorm.io/gorm"
 )
 
+// notesIndex sets notes_search from (index bool, plaintext notes) arguments.
+const notesIndex = `notes_search = CASE WHEN ?::boolean
+	THEN NULLIF(to_tsvector('english'::regconfig, ?::text), ''::tsvector) END`
+
 type repo struct {
-	db postgres.Database
+	db    postgres.Database
+	notes envelope.Cipher
+	// indexNotes writes the notes_search full-text index.
+	indexNotes bool
+}
+
+// NewRepo returns a repo storing notes encrypted with the notes cipher.
+// Notes are indexed for search unless encrypted without searchEncrypted.
+func NewRepo(db postgres.Database, notes envelope.Cipher, searchEncrypted bool) repo {
+	return repo{db, notes, !notes.Enabled() || searchEncrypted}
 }
 
-func NewRepo(db postgres.Database) repo {
-	return repo{db}
+// IndexesNotes reports whether notes are indexed for search.
+func (r repo) IndexesNotes() bool {
+	return r.indexNotes
 }
 
 func (r repo) GetDB(ctx context.Context) *gorm.DB {
@@ -30,6 +45,24 @@ func (r repo) GetTargetByID(ctx context.Context, targetID, missionID uint) (targ
 		SELECT * FROM targets
 		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL`,
 		targetID, missionID).Scan(&target).Error
+	if err != nil {
+		return
+	}
+	target.Notes, err = r.notes.Decrypt(ctx, target.Notes, target.NotesKeyID, notesAAD(target.ID))
+	return
+}
+
+// LockTarget locks the target until tx ends and returns it as of the lock.
+func (r repo) LockTarget(ctx context.Context, tx *gorm.DB, targetID, missionID uint) (target entity.Target, err error) {
+	err = tx.WithContext(ctx).Raw(`
+		SELECT * FROM targets
+		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL
+		FOR UPDATE`,
+		targetID, missionID).Scan(&target).Error
+	if err != nil {
+		return
+	}
+	target.Notes, err = r.notes.Decrypt(ctx, target.Notes, target.NotesKeyID, notesAAD(target.ID))
 	return
 }
 
@@ -38,29 +71,70 @@ func (r repo) GetTargetsByMissionID(ctx context.Context, missionID uint) (target
 		SELECT * FROM targets
 		WHERE mission_id = ? AND deleted_at IS NULL`,
 		missionID).Scan(&targets).Error
+	if err != nil {
+		return
+	}
+	for i := range targets {
+		if targets[i].Notes, err = r.notes.Decrypt(ctx, targets[i].Notes, targets[i].NotesKeyID, notesAAD(targets[i].ID)); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
-func (r repo) CreateTarget(ctx context.Context, target entity.Target) error {
-	return r.db.Instance().WithContext(ctx).Create(&target).Error
-}
-
+// CreateTargets stores targets with encrypted notes and returns them
+// with plaintext notes. Notes are written once the targets have IDs,
+// their encryption is bound to.
 func (r repo) CreateTargets(ctx context.Context, tx *gorm.DB, targets []entity.Target) ([]entity.Target, error) {
-	err := tx.WithContext(ctx).Create(&targets).Error
-	return targets, err
+	tx = tx.WithContext(ctx)
+
+	plaintext := make([]string, len(targets))
+	for i := range targets {
+		plaintext[i] = targets[i].Notes
+		targets[i].Notes, targets[i].NotesKeyID = "", ""
+	}
+
+	if err := tx.Create(&targets).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range targets {
+		targets[i].Notes = plaintext[i]
+		if plaintext[i] == "" {
+			continue
+		}
+		if err := r.writeNotes(ctx, tx, &targets[i]); err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
 }
 
 func (r repo) UpdateTarget(ctx context.Context, target entity.Target) error {
+	notes, keyID, err := r.notes.Encrypt(ctx, target.Notes, notesAAD(target.ID))
+	if err != nil {
+		return err
+	}
+
 	return r.db.Instance().WithContext(ctx).Exec(`
 		UPDATE targets
-		SET notes = ?, updated_at = ?
+		SET notes = ?, notes_key_id = ?, `+notesIndex+`, updated_at = ?
 		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL`,
-		target.Notes, time.Now(),
+		notes, keyID, r.indexNotes, target.Notes, time.Now(),
 		target.ID, target.MissionID).Error
 }
 
-func (r repo) CompleteTarget(ctx context.Context, targetID, missionID uint) error {
+func (r repo) UpdateTargetSchedule(ctx context.Context, target entity.Target) error {
 	return r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE targets
+		SET priority = ?, starts_at = ?, due_at = ?, updated_at = ?
+		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL`,
+		target.Priority, target.StartsAt, target.DueAt, time.Now(),
+		target.ID, target.MissionID).Error
+}
+
+func (r repo) CompleteTarget(ctx context.Context, tx *gorm.DB, targetID, missionID uint) error {
+	return tx.WithContext(ctx).Exec(`
 		UPDATE targets
 		SET is_completed = true, updated_at = ?
 		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL`,
@@ -74,3 +148,135 @@ func (r repo) DeleteTarget(ctx context.Context, targetID, missionID uint) error
 		WHERE id = ? AND mission_id = ? AND deleted_at IS NULL`,
 		time.Now(), targetID, missionID).Error
 }
+
+// NormalizeCountries rewrites target countries to normalize(country),
+// returning the distinct countries it could not resolve.
+func (r repo) NormalizeCountries(ctx context.Context, normalize func(string) (string, bool)) (unknown []string, err error) {
+	db := r.db.Instance().WithContext(ctx)
+
+	var countries []string
+	if err = db.Raw(`SELECT DISTINCT country FROM targets`).Scan(&countries).Error; err != nil {
+		return nil, err
+	}
+
+	for _, country := range countries {
+		code, ok := normalize(country)
+		if !ok {
+			unknown = append(unknown, country)
+			continue
+		}
+		if code == country {
+			continue
+		}
+		if err = db.Exec(`
+			UPDATE targets
+			SET country = ?
+			WHERE country = ?`,
+			code, country).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return unknown, nil
+}
+
+// ReindexNotes aligns the notes_search index with the search setting: plaintext
+// notes are indexed in SQL, or every index is dropped if notes are not searchable.
+// Encrypted notes are indexed by RotateNotes and on write.
+func (r repo) ReindexNotes(ctx context.Context) error {
+	db := r.db.Instance().WithContext(ctx)
+	if !r.indexNotes {
+		return db.Exec(`
+			UPDATE targets
+			SET notes_search = NULL
+			WHERE notes_search IS NOT NULL`).Error
+	}
+
+	return db.Exec(`
+		UPDATE targets
+		SET notes_search = to_tsvector('english'::regconfig, notes)
+		WHERE notes_search IS NULL AND notes <> '' AND notes_key_id = ''`).Error
+}
+
+// RotateNotes re-encrypts, batchSize targets per transaction, every note that
+// is plaintext or wrapped by a master key other than the active one,
+// soft deleted targets included. It returns the number of notes rotated.
+func (r repo) RotateNotes(ctx context.Context, batchSize int) (rotated int, err error) {
+	if !r.notes.Enabled() {
+		return 0, envelope.ErrNoKMS
+	}
+
+	var lastID uint
+	for {
+		var batch []entity.Target
+		if err = r.db.Instance().WithContext(ctx).Raw(`
+			SELECT id, notes, notes_key_id FROM targets
+			WHERE id > ?
+			ORDER BY id ASC
+			LIMIT ?`,
+			lastID, batchSize).Scan(&batch).Error; err != nil {
+			return rotated, err
+		}
+		if len(batch) == 0 {
+			return rotated, nil
+		}
+		lastID = batch[len(batch)-1].ID
+
+		count := 0
+		err = r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, target := range batch {
+				if !r.notes.NeedsRotation(target.Notes, target.NotesKeyID) {
+					continue
+				}
+
+				plaintext, err := r.notes.Decrypt(ctx, target.Notes, target.NotesKeyID, notesAAD(target.ID))
+				if err != nil {
+					return err
+				}
+				notes, keyID, err := r.notes.Encrypt(ctx, plaintext, notesAAD(target.ID))
+				if err != nil {
+					return err
+				}
+
+				// The notes guard skips targets updated meanwhile,
+				// they are already encrypted with the active key.
+				res := tx.Exec(`
+					UPDATE targets
+					SET notes = ?, notes_key_id = ?, `+notesIndex+`
+					WHERE id = ? AND notes = ?`,
+					notes, keyID, r.indexNotes, plaintext, target.ID, target.Notes)
+				if res.Error != nil {
+					return res.Error
+				}
+				count += int(res.RowsAffected)
+			}
+			return nil
+		})
+		if err != nil {
+			return rotated, err
+		}
+		rotated += count
+	}
+}
+
+// writeNotes encrypts the plaintext notes of the created target
+// and stores them with their search index.
+func (r repo) writeNotes(ctx context.Context, tx *gorm.DB, target *entity.Target) error {
+	notes, keyID, err := r.notes.Encrypt(ctx, target.Notes, notesAAD(target.ID))
+	if err != nil {
+		return err
+	}
+	target.NotesKeyID = keyID
+
+	return tx.Exec(`
+		UPDATE targets
+		SET notes = ?, notes_key_id = ?, `+notesIndex+`
+		WHERE id = ?`,
+		notes, keyID, r.indexNotes, target.Notes,
+		target.ID).Error
+}
+
+// notesAAD binds the encrypted notes to their target.
+func notesAAD(targetID uint) []byte {
+	return envelope.RecordAAD("targets", targetID)
+}
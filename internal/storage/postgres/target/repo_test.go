@@ -0,0 +1,218 @@
+package target
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/envelope"
+	"backend/pkg/postgres/postgrestest"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testCipher(t *testing.T, active string) envelope.Cipher {
+	t.Helper()
+	kms, err := envelope.NewKeyring(map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, envelope.MasterKeySize),
+		"k2": bytes.Repeat([]byte{2}, envelope.MasterKeySize),
+	}, active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return envelope.New(kms)
+}
+
+func TestWriteNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := testCipher(t, "k1")
+
+	var stored []driver.Value // notes, key id of the notes update
+	db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+		switch {
+		case strings.HasPrefix(q.SQL, "INSERT INTO"):
+			return postgrestest.Result{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(5)}}}
+		case strings.HasPrefix(q.SQL, "UPDATE targets SET notes"):
+			stored = q.Args
+			return postgrestest.Result{RowsAffected: 1}
+		}
+		return postgrestest.Result{Err: errors.New("unexpected query " + q.SQL)}
+	})
+	r := NewRepo(db, notes, false)
+
+	created, err := r.CreateTargets(ctx, db.Instance(), []entity.Target{{MissionID: 2, Name: "Ivan", Notes: "meets at noon"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created[0].ID != 5 || created[0].Notes != "meets at noon" || created[0].NotesKeyID != "k1" {
+		t.Fatalf("created = %+v, want plaintext notes and the active key", created[0])
+	}
+
+	// notes, key id, index, plaintext, target id
+	if len(stored) != 5 {
+		t.Fatalf("notes update args = %v", stored)
+	}
+	ciphertext, keyID := stored[0].(string), stored[1].(string)
+	if strings.Contains(ciphertext, "noon") || stored[2] != false || stored[4] != int64(5) {
+		t.Errorf("notes update args = %v, want encrypted, unindexed notes of target 5", stored)
+	}
+
+	if got, err := notes.Decrypt(ctx, ciphertext, keyID, notesAAD(5)); err != nil || got != "meets at noon" {
+		t.Errorf("Decrypt = %q, %v", got, err)
+	}
+	// notes copied to another target do not decrypt
+	if _, err := notes.Decrypt(ctx, ciphertext, keyID, notesAAD(6)); err == nil {
+		t.Error("notes of target 5 decrypted as notes of target 6")
+	}
+}
+
+func TestGetTargetByIDWrongTarget(t *testing.T) {
+	ctx := context.Background()
+	notes := testCipher(t, "k1")
+	ciphertext, keyID, err := notes.Encrypt(ctx, "meets at noon", notesAAD(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      int64
+		want    string
+		wantErr bool
+	}{
+		{name: "own notes", id: 5, want: "meets at noon"},
+		{name: "notes of another target", id: 6, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+				return postgrestest.Result{
+					Columns: []string{"id", "mission_id", "notes", "notes_key_id"},
+					Rows:    [][]driver.Value{{tt.id, int64(2), ciphertext, keyID}},
+				}
+			})
+
+			target, err := NewRepo(db, notes, false).GetTargetByID(ctx, uint(tt.id), 2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && target.Notes != tt.want {
+				t.Errorf("notes = %q, want %q", target.Notes, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotateNotes(t *testing.T) {
+	ctx := context.Background()
+	old := testCipher(t, "k1")
+	oldNotes, oldKeyID, err := old.Encrypt(ctx, "old key", notesAAD(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	movedNotes, movedKeyID, err := old.Encrypt(ctx, "updated meanwhile", notesAAD(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notes := testCipher(t, "k2")
+	activeNotes, activeKeyID, err := notes.Encrypt(ctx, "active key", notesAAD(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := [][]driver.Value{
+		{int64(1), "plaintext", ""},
+		{int64(2), oldNotes, oldKeyID},
+		{int64(3), activeNotes, activeKeyID},
+		{int64(4), movedNotes, movedKeyID},
+		{int64(5), "", ""},
+	}
+
+	type update struct {
+		id        int64
+		notes     string
+		keyID     string
+		plaintext string
+		guard     string
+	}
+	var updates []update
+	db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+		switch {
+		case strings.HasPrefix(q.SQL, "SELECT"):
+			// batches of 2 after the last id
+			lastID, limit := q.Args[0].(int64), q.Args[1].(int64)
+			var batch [][]driver.Value
+			for _, row := range rows {
+				if row[0].(int64) > lastID && int64(len(batch)) < limit {
+					batch = append(batch, row)
+				}
+			}
+			return postgrestest.Result{Columns: []string{"id", "notes", "notes_key_id"}, Rows: batch}
+		case strings.HasPrefix(q.SQL, "UPDATE"):
+			u := update{
+				notes:     q.Args[0].(string),
+				keyID:     q.Args[1].(string),
+				plaintext: q.Args[3].(string),
+				id:        q.Args[4].(int64),
+				guard:     q.Args[5].(string),
+			}
+			updates = append(updates, u)
+			if u.id == 4 {
+				// updated since read, the guard no longer matches
+				return postgrestest.Result{RowsAffected: 0}
+			}
+			return postgrestest.Result{RowsAffected: 1}
+		}
+		return postgrestest.Result{Err: errors.New("unexpected query " + q.SQL)}
+	})
+
+	rotated, err := NewRepo(db, notes, true).RotateNotes(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != 2 {
+		t.Errorf("rotated = %d, want 2, the target updated meanwhile is skipped", rotated)
+	}
+
+	want := []struct {
+		id        int64
+		plaintext string
+		guard     string
+	}{
+		{1, "plaintext", "plaintext"},
+		{2, "old key", oldNotes},
+		{4, "updated meanwhile", movedNotes},
+	}
+	if len(updates) != len(want) {
+		t.Fatalf("updated %d targets, want %d: %+v", len(updates), len(want), updates)
+	}
+	for i, w := range want {
+		u := updates[i]
+		if u.id != w.id || u.plaintext != w.plaintext || u.guard != w.guard {
+			t.Errorf("update %d = target %d, plaintext %q, guard %q, want target %d, %q, guard on the notes read",
+				i, u.id, u.plaintext, u.guard, w.id, w.plaintext)
+		}
+		if u.keyID != "k2" {
+			t.Errorf("target %d key = %q, want the active key", u.id, u.keyID)
+		}
+		if got, err := notes.Decrypt(ctx, u.notes, u.keyID, notesAAD(uint(u.id))); err != nil || got != w.plaintext {
+			t.Errorf("target %d notes = %q, %v", u.id, got, err)
+		}
+	}
+
+	if got := db.Transactions(); len(got) != 3 || got[0] != "commit" || got[1] != "commit" || got[2] != "commit" {
+		t.Errorf("transactions = %v, want a commit per batch", got)
+	}
+}
+
+func TestRotateNotesDisabled(t *testing.T) {
+	db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+		return postgrestest.Result{Err: errors.New("unexpected query " + q.SQL)}
+	})
+	if _, err := NewRepo(db, envelope.Cipher{}, false).RotateNotes(context.Background(), 10); !errors.Is(err, envelope.ErrNoKMS) {
+		t.Errorf("err = %v, want ErrNoKMS", err)
+	}
+}
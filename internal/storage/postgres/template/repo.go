@@ -0,0 +1,121 @@
+package template
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetTemplates(ctx context.Context) (templates []entity.MissionTemplate, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_templates
+		ORDER BY name ASC`).Scan(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, r.loadTargets(ctx, templates)
+}
+
+// GetTemplate returns the template with its targets, ID is 0 if there is none.
+func (r repo) GetTemplate(ctx context.Context, templateID uint) (entity.MissionTemplate, error) {
+	var template entity.MissionTemplate
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_templates
+		WHERE id = ?`,
+		templateID).Scan(&template).Error
+	if err != nil || template.ID == 0 {
+		return template, err
+	}
+
+	templates := []entity.MissionTemplate{template}
+	err = r.loadTargets(ctx, templates)
+	return templates[0], err
+}
+
+func (r repo) GetTemplateByName(ctx context.Context, name string) (template entity.MissionTemplate, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM mission_templates
+		WHERE LOWER(name) = LOWER(?)`,
+		name).Scan(&template).Error
+	return
+}
+
+func (r repo) CreateTemplate(ctx context.Context, template entity.MissionTemplate) (entity.MissionTemplate, error) {
+	err := r.db.Instance().WithContext(ctx).Create(&template).Error
+	return template, err
+}
+
+// UpdateTemplate replaces the template and its targets.
+func (r repo) UpdateTemplate(ctx context.Context, template entity.MissionTemplate) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE mission_templates
+			SET name = ?, description = ?, priority = ?, updated_at = ?
+			WHERE id = ?`,
+			template.Name, template.Description, template.Priority, time.Now(),
+			template.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`DELETE FROM template_targets WHERE template_id = ?`, template.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(&template.Targets).Error
+	})
+}
+
+func (r repo) DeleteTemplate(ctx context.Context, templateID uint) error {
+	return r.db.Instance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM template_targets WHERE template_id = ?`, templateID).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM mission_templates WHERE id = ?`, templateID).Error
+	})
+}
+
+func (r repo) loadTargets(ctx context.Context, templates []entity.MissionTemplate) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(templates))
+	byID := make(map[uint]*entity.MissionTemplate, len(templates))
+	for i := range templates {
+		ids = append(ids, templates[i].ID)
+		byID[templates[i].ID] = &templates[i]
+	}
+
+	var targets []entity.TemplateTarget
+	err := r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM template_targets
+		WHERE template_id IN ?
+		ORDER BY id ASC`,
+		ids).Scan(&targets).Error
+	if err != nil {
+		return err
+	}
+
+	for _, t := range targets {
+		byID[t.TemplateID].Targets = append(byID[t.TemplateID].Targets, t)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"backend/config"
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db postgres.Database
+}
+
+func NewRepo(db postgres.Database) repo {
+	return repo{db}
+}
+
+func (r repo) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx)
+}
+
+func (r repo) NewTransaction(ctx context.Context) *gorm.DB {
+	return r.db.Instance().WithContext(ctx).Begin()
+}
+
+func (r repo) GetWebhooks(ctx context.Context) (webhooks []entity.Webhook, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM webhooks
+		WHERE deleted_at IS NULL
+		ORDER BY id ASC`).Scan(&webhooks).Error
+	return
+}
+
+func (r repo) GetWebhookByID(ctx context.Context, webhookID uint) (webhook entity.Webhook, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM webhooks
+		WHERE id = ? AND deleted_at IS NULL`,
+		webhookID).Scan(&webhook).Error
+	return
+}
+
+func (r repo) CreateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	err := r.db.Instance().WithContext(ctx).Create(&webhook).Error
+	return webhook, err
+}
+
+func (r repo) DeleteWebhook(ctx context.Context, webhookID uint) (deleted bool, err error) {
+	res := r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE webhooks
+		SET deleted_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		time.Now(), webhookID)
+	return res.RowsAffected > 0, res.Error
+}
+
+// GetDeliveries returns the latest deliveries of the webhook, newest first.
+func (r repo) GetDeliveries(ctx context.Context, webhookID uint, limit int) (deliveries []entity.WebhookDelivery, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		SELECT * FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY id DESC
+		LIMIT ?`,
+		webhookID, limit).Scan(&deliveries).Error
+	return
+}
+
+// GetDeliveriesByIDs returns deliveries of the webhook with the given IDs,
+// or every failed delivery of the webhook if no IDs are given.
+func (r repo) GetDeliveriesByIDs(ctx context.Context, webhookID uint, ids []uint) (deliveries []entity.WebhookDelivery, err error) {
+	db := r.db.Instance().WithContext(ctx)
+	if len(ids) == 0 {
+		err = db.Raw(`
+			SELECT * FROM webhook_deliveries
+			WHERE webhook_id = ? AND status = ?
+			ORDER BY id ASC`,
+			webhookID, config.DeliveryFailed).Scan(&deliveries).Error
+		return
+	}
+
+	err = db.Raw(`
+		SELECT * FROM webhook_deliveries
+		WHERE webhook_id = ? AND id IN ?
+		ORDER BY id ASC`,
+		webhookID, ids).Scan(&deliveries).Error
+	return
+}
+
+// CreateDeliveries enqueues the deliveries, skipping first deliveries of an
+// event already enqueued for the webhook, so events published again are delivered once.
+// Only redeliveries, which never conflict, are returned with their IDs.
+func (r repo) CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) ([]entity.WebhookDelivery, error) {
+	err := r.db.Instance().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "redelivery_of IS NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries due at now, and
+// leases them until now+lease so concurrent workers skip them.
+func (r repo) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) (deliveries []entity.WebhookDelivery, err error) {
+	err = r.db.Instance().WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries
+		SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`,
+		now.Add(lease), config.DeliveryPending, now, limit).Scan(&deliveries).Error
+	return
+}
+
+// UpdateDeliveryAttempt records the outcome of a delivery attempt.
+func (r repo) UpdateDeliveryAttempt(ctx context.Context, delivery entity.WebhookDelivery) error {
+	return r.db.Instance().WithContext(ctx).Exec(`
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+			response_status = ?, last_error = ?, delivered_at = ?, updated_at = ?
+		WHERE id = ?`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt, time.Now(),
+		delivery.ID).Error
+}
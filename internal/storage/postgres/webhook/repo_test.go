@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	entity "backend/internal/entity/cat"
+	"backend/pkg/postgres/postgrestest"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestDeliveryUniqueIndex(t *testing.T) {
+	s, err := schema.Parse(&entity.WebhookDelivery{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, idx := range s.ParseIndexes() {
+		if idx.Name != "idx_webhook_event" {
+			continue
+		}
+		var columns []string
+		for _, f := range idx.Fields {
+			columns = append(columns, f.DBName)
+		}
+		if idx.Class != "UNIQUE" || strings.Join(columns, ",") != "webhook_id,event_id" || idx.Where != "redelivery_of IS NULL" {
+			t.Errorf("idx_webhook_event = %s (%v) where %q, want unique first deliveries per webhook and event",
+				idx.Class, columns, idx.Where)
+		}
+		return
+	}
+	t.Error("no unique index on webhook deliveries")
+}
+
+func TestCreateDeliveriesSkipsDuplicates(t *testing.T) {
+	var inserts []string
+	db := postgrestest.New(t, func(q postgrestest.Query) postgrestest.Result {
+		inserts = append(inserts, q.SQL)
+		return postgrestest.Result{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}, {int64(2)}}}
+	})
+
+	_, err := NewRepo(db).CreateDeliveries(context.Background(), []entity.WebhookDelivery{
+		{WebhookID: 1, EventID: "evt-1", Event: "mission.completed"},
+		{WebhookID: 2, EventID: "evt-1", Event: "mission.completed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// other constraint violations still fail
+	const skip = `ON CONFLICT ("webhook_id","event_id") WHERE redelivery_of IS NULL DO NOTHING`
+	if len(inserts) != 1 || !strings.Contains(inserts[0], skip) {
+		t.Errorf("queries = %v, want one insert skipping duplicate first deliveries", inserts)
+	}
+}
@@ -0,0 +1,11 @@
+// Package caller names the headers identifying the API caller, set by the
+// authenticating gateway and by the client SDK.
+package caller
+
+const (
+	HeaderRole  = "X-Spycat-Role"
+	HeaderCatID = "X-Spycat-Cat-ID"
+
+	RoleHandler = "handler"
+	RoleCat     = "cat"
+)
@@ -0,0 +1,15 @@
+package cat
+
+import "backend/pkg/money"
+
+// BonusRule pays Amount for per_target and per_mission kinds, while
+// multiplier kinds scale those bonuses by Multiplier (decimal string, e.g. "1.5")
+// for targets in Country or for cats with at least MinExperience years.
+type BonusRule struct {
+	Kind          string      `json:"kind" valid:"required"`
+	Amount        money.Money `json:"amount"`
+	Multiplier    string      `json:"multiplier"`
+	Country       string      `json:"country"`
+	MinExperience uint8       `json:"min_experience"`
+	Description   string      `json:"description"`
+}
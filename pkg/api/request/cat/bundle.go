@@ -0,0 +1,8 @@
+package cat
+
+import response "backend/pkg/api/response/cat"
+
+// MissionBundle is the import side of the portable mission document.
+type MissionBundle struct {
+	response.MissionBundle
+}
@@ -0,0 +1,22 @@
+package cat
+
+type (
+	// Debrief is written by a handler once the mission is completed, it replaces
+	// the summary, rating and target outcomes. Targets can be left out.
+	Debrief struct {
+		Summary string          `json:"summary"`
+		Rating  *int            `json:"rating"` // 1 to 5
+		Targets []DebriefTarget `json:"targets"`
+	}
+
+	DebriefTarget struct {
+		TargetID uint   `json:"target_id"`
+		Outcome  string `json:"outcome"` // success, partial, failure or aborted
+		Notes    string `json:"notes"`
+	}
+
+	// SelfAssessment is written by the assigned cat once the mission is completed.
+	SelfAssessment struct {
+		SelfAssessment string `json:"self_assessment"`
+	}
+)
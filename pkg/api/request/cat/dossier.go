@@ -0,0 +1,19 @@
+package cat
+
+type (
+	Dossier struct {
+		Name       string            `json:"name" valid:"required"`
+		Country    string            `json:"country" valid:"required"` // ISO 3166-1 code or name
+		Aliases    []string          `json:"aliases"`
+		Attributes map[string]string `json:"attributes"`
+	}
+
+	// UpdateDossier is a partial update: omitted fields are kept,
+	// aliases and attributes present in the body replace the stored ones.
+	UpdateDossier struct {
+		Name       *string           `json:"name"`
+		Country    *string           `json:"country"`
+		Aliases    []string          `json:"aliases"`
+		Attributes map[string]string `json:"attributes"`
+	}
+)
@@ -0,0 +1,7 @@
+package cat
+
+// ExchangeRate is the price of one unit of the base currency in the quote
+// currency, as a decimal string (e.g. "1.0834") so it never passes through floats.
+type ExchangeRate struct {
+	Rate string `json:"rate" valid:"required"`
+}
@@ -0,0 +1,22 @@
+package cat
+
+type (
+	// Message posted to a mission thread, it needs a body or attachments.
+	Message struct {
+		Body        string       `json:"body"`
+		Attachments []Attachment `json:"attachments"`
+	}
+
+	// Attachment data is base64 encoded in JSON, the content type
+	// is sniffed from the data if empty.
+	Attachment struct {
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type"`
+		Data        []byte `json:"data"`
+	}
+
+	// MarkRead marks messages of the other side read up to UpToID, all if 0.
+	MarkRead struct {
+		UpToID uint `json:"up_to_id"`
+	}
+)
@@ -0,0 +1,10 @@
+package cat
+
+// MissionType is created or replaced as a whole, no countries allow any country.
+type MissionType struct {
+	Name        string   `json:"name" valid:"required"`
+	Description string   `json:"description"`
+	MinTargets  int      `json:"min_targets"`
+	MaxTargets  int      `json:"max_targets"`
+	Countries   []string `json:"countries"` // ISO 3166-1 codes or names
+}
@@ -0,0 +1,25 @@
+package cat
+
+type (
+	// CatProfile is a partial profile update: omitted fields are kept,
+	// lists present in the body replace the stored ones ([] clears them).
+	CatProfile struct {
+		Skills         []Skill       `json:"skills"`
+		Languages      []string      `json:"languages"` // ISO 639-1, e.g. "en"
+		ClearanceLevel *uint8        `json:"clearance_level"`
+		HomeCountry    *string       `json:"home_country"` // ISO 3166-1 code or name, stored as alpha-2
+		Availability   []LeavePeriod `json:"availability"`
+	}
+
+	Skill struct {
+		Name        string `json:"name" valid:"required"`
+		Proficiency uint8  `json:"proficiency"`
+	}
+
+	// LeavePeriod is a period the cat is unavailable, both dates inclusive.
+	LeavePeriod struct {
+		From   string `json:"from" valid:"required"` // YYYY-MM-DD
+		To     string `json:"to" valid:"required"`   // YYYY-MM-DD
+		Reason string `json:"reason"`
+	}
+)
@@ -0,0 +1,59 @@
+package cat
+
+import (
+	"backend/pkg/money"
+	"time"
+)
+
+type (
+	// Salary currency defaults to USD if empty,
+	// a bare number is accepted as the amount for backward compatibility.
+	Cat struct {
+		Name            string      `json:"name" valid:"required"`
+		YearsExperience uint8       `json:"years_experience"`
+		Breed           string      `json:"breed" valid:"required"`
+		Salary          money.Money `json:"salary"`
+	}
+
+	UpdateCat struct {
+		Salary        money.Money `json:"salary" valid:"required"`
+		EffectiveFrom string      `json:"effective_from"` // YYYY-MM-DD, today if empty
+		Reason        string      `json:"reason"`
+	}
+
+	CatStatus struct {
+		Status string `json:"status" valid:"required"` // active, on_leave, retired or kia
+		Reason string `json:"reason" valid:"required"`
+	}
+
+	Mission struct {
+		CatID    *uint      `json:"cat_id"`
+		TypeID   *uint      `json:"type_id"`  // the default type if empty
+		Priority string     `json:"priority"` // low, normal (default), high or critical
+		StartsAt *time.Time `json:"starts_at"`
+		DueAt    *time.Time `json:"due_at"`
+		Targets  Targets    `json:"targets"`
+	}
+
+	// Country accepts an ISO 3166-1 code, name or common alias,
+	// validation normalizes it to the alpha-2 code.
+	Target struct {
+		Name    string `json:"name" binding:"required"`
+		Country string `json:"country" binding:"required"`
+		Notes   string `json:"notes"`
+
+		Priority string     `json:"priority"` // low, normal (default), high or critical
+		StartsAt *time.Time `json:"starts_at"`
+		DueAt    *time.Time `json:"due_at"`
+
+		// Links the target to the dossier, if empty a dossier with a similar
+		// name in the same country is linked or a new one created.
+		DossierID *uint `json:"dossier_id"`
+	}
+
+	UpdateTarget struct {
+		Notes string `json:"notes"`
+	}
+
+	Targets []Target
+)
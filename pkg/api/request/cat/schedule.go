@@ -0,0 +1,24 @@
+package cat
+
+import "time"
+
+type (
+	// Schedule replaces the priority and dates of a mission or target,
+	// omitted dates are cleared.
+	Schedule struct {
+		Priority string     `json:"priority"` // low, normal (default), high or critical
+		StartsAt *time.Time `json:"starts_at"`
+		DueAt    *time.Time `json:"due_at"`
+	}
+
+	// MissionFilter is the GET /missions query. Dates are RFC 3339 or YYYY-MM-DD,
+	// sort is a mission field such as "created_at", prefixed with "-" for descending.
+	MissionFilter struct {
+		Priority  string `form:"priority"` // comma separated
+		DueAfter  string `form:"due_after"`
+		DueBefore string `form:"due_before"`
+		Overdue   string `form:"overdue"`
+		Completed string `form:"completed"`
+		Sort      string `form:"sort"`
+	}
+)
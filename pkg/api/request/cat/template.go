@@ -0,0 +1,48 @@
+package cat
+
+import "time"
+
+type (
+	// MissionTemplate is created or replaced as a whole.
+	MissionTemplate struct {
+		Name        string           `json:"name" valid:"required"`
+		Description string           `json:"description"`
+		TypeID      *uint            `json:"type_id"`  // the default type if empty
+		Priority    string           `json:"priority"` // low, normal (default), high or critical
+		Targets     []TemplateTarget `json:"targets"`
+	}
+
+	// TemplateTarget is a target skeleton: a name placeholder, the country
+	// and default notes.
+	TemplateTarget struct {
+		Name     string `json:"name"`
+		Country  string `json:"country"` // ISO 3166-1 code or name
+		Notes    string `json:"notes"`
+		Priority string `json:"priority"`
+	}
+
+	// MissionFromTemplate fills in a template. Targets are matched to the
+	// template targets by position, empty fields keep the template values.
+	MissionFromTemplate struct {
+		CatID    *uint                `json:"cat_id"`
+		Priority string               `json:"priority"` // template priority if empty
+		StartsAt *time.Time           `json:"starts_at"`
+		DueAt    *time.Time           `json:"due_at"`
+		Targets  []TemplateFillTarget `json:"targets"`
+	}
+
+	TemplateFillTarget struct {
+		Name      string `json:"name"`
+		Notes     string `json:"notes"`
+		DossierID *uint  `json:"dossier_id"`
+	}
+
+	// CloneMission sets what a clone does not copy from its mission:
+	// the cat and the dates. The priority is copied if empty.
+	CloneMission struct {
+		CatID    *uint      `json:"cat_id"`
+		Priority string     `json:"priority"`
+		StartsAt *time.Time `json:"starts_at"`
+		DueAt    *time.Time `json:"due_at"`
+	}
+)
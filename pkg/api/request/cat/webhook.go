@@ -0,0 +1,16 @@
+package cat
+
+type (
+	// Webhook subscribes the URL to the named events, e.g. "mission.assigned".
+	// The secret signs payloads, one is generated if empty.
+	Webhook struct {
+		URL    string   `json:"url" valid:"required"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret"`
+	}
+
+	// Redeliver replays the deliveries, every failed one of the webhook if empty.
+	Redeliver struct {
+		DeliveryIDs []uint `json:"delivery_ids"`
+	}
+)
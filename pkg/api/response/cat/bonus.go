@@ -0,0 +1,41 @@
+package cat
+
+import (
+	"backend/pkg/money"
+	"time"
+)
+
+type (
+	BonusRule struct {
+		ID            uint        `json:"id"`
+		Kind          string      `json:"kind"`
+		Amount        money.Money `json:"amount"`
+		Multiplier    string      `json:"multiplier,omitempty"`
+		Country       string      `json:"country,omitempty"`
+		MinExperience uint8       `json:"min_experience,omitempty"`
+		Description   string      `json:"description"`
+
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	BonusEntry struct {
+		ID          uint        `json:"id,omitempty"`
+		CatID       uint        `json:"cat_id"`
+		MissionID   uint        `json:"mission_id"`
+		TargetID    *uint       `json:"target_id"`
+		RuleID      uint        `json:"rule_id"`
+		Amount      money.Money `json:"amount"`
+		Description string      `json:"description"`
+
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// BonusPreview is the bonus the mission would pay out if completed now.
+	BonusPreview struct {
+		MissionID uint          `json:"mission_id"`
+		CatID     *uint         `json:"cat_id"`
+		Entries   []BonusEntry  `json:"entries"`
+		Totals    []money.Money `json:"totals"` // per currency
+	}
+)
@@ -0,0 +1,53 @@
+package cat
+
+import "time"
+
+type (
+	// MissionBundle is a portable document with a whole mission, used to move
+	// missions between agencies. IDs are source IDs and get remapped on import.
+	MissionBundle struct {
+		Version    int           `json:"version"`
+		ExportedAt time.Time     `json:"exported_at"`
+		Mission    BundleMission `json:"mission"`
+	}
+
+	BundleMission struct {
+		SourceID    uint           `json:"source_id"`
+		Type        string         `json:"type,omitempty"` // mission type name, the default type if empty
+		IsCompleted bool           `json:"is_completed"`
+		Priority    string         `json:"priority,omitempty"`
+		StartsAt    *time.Time     `json:"starts_at,omitempty"`
+		DueAt       *time.Time     `json:"due_at,omitempty"`
+		CreatedAt   time.Time      `json:"created_at"`
+		UpdatedAt   time.Time      `json:"updated_at"`
+		Cat         *BundleCat     `json:"cat"`
+		Targets     []BundleTarget `json:"targets"`
+	}
+
+	// BundleCat references the assigned cat, which can be matched
+	// by name to a local cat on import.
+	BundleCat struct {
+		SourceID        uint   `json:"source_id"`
+		Name            string `json:"name"`
+		Breed           string `json:"breed"`
+		YearsExperience uint8  `json:"years_experience"`
+	}
+
+	BundleTarget struct {
+		SourceID    uint       `json:"source_id"`
+		Name        string     `json:"name"`
+		Country     string     `json:"country"`
+		Notes       string     `json:"notes"`
+		IsCompleted bool       `json:"is_completed"`
+		Priority    string     `json:"priority,omitempty"`
+		StartsAt    *time.Time `json:"starts_at,omitempty"`
+		DueAt       *time.Time `json:"due_at,omitempty"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+	}
+
+	BundleImport struct {
+		Mission   Mission       `json:"mission"`
+		TargetIDs map[uint]uint `json:"target_ids"` // source ID -> new ID
+	}
+)
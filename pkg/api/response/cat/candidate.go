@@ -0,0 +1,22 @@
+package cat
+
+type (
+	// Candidate is a cat ranked for a mission, Score is the sum of the breakdown.
+	Candidate struct {
+		Cat       Cat            `json:"cat"`
+		Score     int            `json:"score"`
+		Breakdown ScoreBreakdown `json:"breakdown"`
+	}
+
+	ScoreBreakdown struct {
+		Experience int `json:"experience"`
+		Languages  int `json:"languages"` // speaks languages of the target countries
+		Countries  int `json:"countries"` // home country and past completed targets there
+		Workload   int `json:"workload"`  // penalty for missions in progress
+		Clearance  int `json:"clearance"`
+	}
+)
+
+func (b ScoreBreakdown) Total() int {
+	return b.Experience + b.Languages + b.Countries + b.Workload + b.Clearance
+}
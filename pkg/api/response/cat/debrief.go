@@ -0,0 +1,35 @@
+package cat
+
+import "time"
+
+type (
+	// Debrief of a completed mission. Targets lists every mission target,
+	// those the handler gave no outcome have an empty one.
+	Debrief struct {
+		MissionID      uint            `json:"mission_id"`
+		CatID          *uint           `json:"cat_id"`
+		CatName        string          `json:"cat_name,omitempty"`
+		Priority       string          `json:"priority"`
+		Summary        string          `json:"summary"`
+		Rating         *int            `json:"rating"`
+		SelfAssessment string          `json:"self_assessment"`
+		Targets        []DebriefTarget `json:"targets"`
+		CreatedAt      time.Time       `json:"created_at"`
+		UpdatedAt      time.Time       `json:"updated_at"`
+	}
+
+	DebriefTarget struct {
+		TargetID uint   `json:"target_id"`
+		Name     string `json:"name"`
+		Country  string `json:"country"`
+		Outcome  string `json:"outcome,omitempty"`
+		Notes    string `json:"notes,omitempty"`
+	}
+
+	// DebriefDocument is a debrief rendered for download.
+	DebriefDocument struct {
+		Filename    string
+		ContentType string
+		Content     []byte
+	}
+)
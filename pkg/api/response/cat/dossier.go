@@ -0,0 +1,41 @@
+package cat
+
+import "time"
+
+type (
+	Dossier struct {
+		ID          uint              `json:"id"`
+		Name        string            `json:"name"`
+		Country     string            `json:"country"`
+		CountryName string            `json:"country_name"`
+		Aliases     []string          `json:"aliases"`
+		Attributes  map[string]string `json:"attributes"`
+
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// DossierDetail is the dossier with every mission target ever linked to it.
+	DossierDetail struct {
+		Dossier
+		Targets []DossierTarget `json:"targets"`
+	}
+
+	// DossierLink reports the dossier a target created without dossier_id was
+	// linked to, an existing dossier with a similar name or a new one.
+	DossierLink struct {
+		DossierID  uint    `json:"dossier_id"`
+		Name       string  `json:"name"`
+		Similarity float64 `json:"similarity,omitempty"` // of the names, 0 to 1, for an existing dossier
+		Created    bool    `json:"created"`
+	}
+
+	DossierTarget struct {
+		MissionID        uint       `json:"mission_id"`
+		MissionCompleted bool       `json:"mission_completed"`
+		MissionDeletedAt *time.Time `json:"mission_deleted_at"`
+		CatID            *uint      `json:"cat_id"`
+
+		Target Target `json:"target"`
+	}
+)
@@ -0,0 +1,32 @@
+package cat
+
+import (
+	"io"
+	"time"
+)
+
+type (
+	// Evidence is downloaded from DownloadURL until URLExpiresAt,
+	// list the evidence again for a fresh URL.
+	Evidence struct {
+		ID           uint      `json:"id"`
+		MissionID    uint      `json:"mission_id"`
+		TargetID     uint      `json:"target_id"`
+		Filename     string    `json:"filename"`
+		ContentType  string    `json:"content_type"`
+		Size         int64     `json:"size"`
+		SHA256       string    `json:"sha256"`
+		UploadedBy   Author    `json:"uploaded_by"`
+		CreatedAt    time.Time `json:"created_at"`
+		DownloadURL  string    `json:"download_url"`
+		URLExpiresAt time.Time `json:"url_expires_at"`
+	}
+
+	// EvidenceFile is the content of an evidence download, the caller closes it.
+	EvidenceFile struct {
+		Filename    string
+		ContentType string
+		Size        int64
+		Content     io.ReadCloser
+	}
+)
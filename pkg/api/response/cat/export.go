@@ -0,0 +1,24 @@
+package cat
+
+import (
+	"strconv"
+	"time"
+)
+
+// CatCSVHeader is the header row of the CSV cats export.
+// Salary is in minor units of the currency.
+var CatCSVHeader = []string{"id", "name", "years_experience", "breed", "salary", "currency", "created_at", "updated_at"}
+
+// CSVRecord returns the cat as a row matching CatCSVHeader.
+func (c Cat) CSVRecord() []string {
+	return []string{
+		strconv.FormatUint(uint64(c.ID), 10),
+		c.Name,
+		strconv.FormatUint(uint64(c.YearsExperience), 10),
+		c.Breed,
+		strconv.FormatInt(c.Salary.Amount, 10),
+		c.Salary.Currency,
+		c.CreatedAt.Format(time.RFC3339),
+		c.UpdatedAt.Format(time.RFC3339),
+	}
+}
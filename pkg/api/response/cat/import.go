@@ -0,0 +1,18 @@
+package cat
+
+type (
+	ImportReport struct {
+		Mode      string           `json:"mode"`
+		Total     int              `json:"total"`
+		Imported  int              `json:"imported"`
+		Failed    int              `json:"failed"`
+		Committed bool             `json:"committed"`
+		Errors    []ImportRowError `json:"errors"`
+	}
+
+	ImportRowError struct {
+		Row   int    `json:"row"`
+		Name  string `json:"name,omitempty"`
+		Error string `json:"error"`
+	}
+)
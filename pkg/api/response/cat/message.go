@@ -0,0 +1,44 @@
+package cat
+
+import "time"
+
+type (
+	// Thread is read-only once Archived, when its mission is completed.
+	Thread struct {
+		Archived bool      `json:"archived"`
+		Messages []Message `json:"messages"`
+	}
+
+	Message struct {
+		ID              uint                `json:"id"`
+		MissionID       uint                `json:"mission_id"`
+		Author          Author              `json:"author"`
+		Body            string              `json:"body"`
+		Attachments     []MessageAttachment `json:"attachments"`
+		ReadByCatAt     *time.Time          `json:"read_by_cat_at,omitempty"`
+		ReadByHandlerAt *time.Time          `json:"read_by_handler_at,omitempty"`
+		CreatedAt       time.Time           `json:"created_at"`
+	}
+
+	// Author of a message or upload, CatID is set for cats.
+	Author struct {
+		Role  string `json:"role"`
+		CatID *uint  `json:"cat_id,omitempty"`
+	}
+
+	// MessageAttachment is downloaded from
+	// /missions/:mission_id/messages/:message_id/attachments/:attachment_id.
+	MessageAttachment struct {
+		ID          uint   `json:"id"`
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type"`
+		Size        int    `json:"size"`
+	}
+
+	// AttachmentFile is an attachment with its data.
+	AttachmentFile struct {
+		Filename    string
+		ContentType string
+		Data        []byte
+	}
+)
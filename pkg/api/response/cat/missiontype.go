@@ -0,0 +1,24 @@
+package cat
+
+import (
+	"slices"
+	"time"
+)
+
+// MissionType limits the targets of its missions, any country is
+// allowed when Countries is empty.
+type MissionType struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MinTargets  int       `json:"min_targets"`
+	MaxTargets  int       `json:"max_targets"`
+	Countries   []string  `json:"countries"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AllowsCountry reports whether targets in the country (an ISO 3166-1 alpha-2 code) are allowed.
+func (t MissionType) AllowsCountry(code string) bool {
+	return len(t.Countries) == 0 || slices.Contains(t.Countries, code)
+}
@@ -0,0 +1,93 @@
+package cat
+
+import (
+	"backend/pkg/money"
+	"time"
+)
+
+type (
+	Cat struct {
+		ID              uint        `json:"id"`
+		Name            string      `json:"name"`
+		YearsExperience uint8       `json:"years_experience"`
+		Breed           string      `json:"breed"`
+		Salary          money.Money `json:"salary"`
+		ClearanceLevel  uint8       `json:"clearance_level"`
+		HomeCountry     string      `json:"home_country"`
+		Status          string      `json:"status"`
+		StatusReason    string      `json:"status_reason"`
+
+		// Profile lists are only loaded for a single cat
+		Skills       []Skill       `json:"skills,omitempty"`
+		Languages    []string      `json:"languages,omitempty"`
+		Availability []LeavePeriod `json:"availability,omitempty"`
+
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at"`
+	}
+
+	CatStatusChange struct {
+		ID     uint   `json:"id"`
+		CatID  uint   `json:"cat_id"`
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Reason string `json:"reason"`
+
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	Skill struct {
+		Name        string `json:"name"`
+		Proficiency uint8  `json:"proficiency"`
+	}
+
+	// LeavePeriod is a period the cat is unavailable, both dates inclusive.
+	LeavePeriod struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Reason string `json:"reason"`
+	}
+
+	Mission struct {
+		ID          uint  `json:"id"`
+		CatID       *uint `json:"cat_id"`
+		TypeID      *uint `json:"type_id"`
+		IsCompleted bool  `json:"is_completed"`
+
+		Priority  string     `json:"priority"`
+		StartsAt  *time.Time `json:"starts_at"`
+		DueAt     *time.Time `json:"due_at"`
+		IsOverdue bool       `json:"is_overdue"`
+		OverdueAt *time.Time `json:"overdue_at"` // when the overdue notification was sent
+
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at"`
+
+		Cat     Cat      `json:"cat"`
+		Targets []Target `json:"targets"`
+	}
+
+	Target struct {
+		ID          uint   `json:"id"`
+		MissionID   uint   `json:"mission_id"`
+		DossierID   *uint  `json:"dossier_id"`
+		Name        string `json:"name"`
+		Country     string `json:"country"` // ISO 3166-1 alpha-2
+		CountryName string `json:"country_name"`
+		Notes       string `json:"notes"`
+		IsCompleted bool   `json:"is_completed"`
+
+		Priority string     `json:"priority"`
+		StartsAt *time.Time `json:"starts_at"`
+		DueAt    *time.Time `json:"due_at"`
+
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at"`
+
+		// DossierLink is only set on creation, for targets without dossier_id
+		DossierLink *DossierLink `json:"dossier_link,omitempty"`
+	}
+)
@@ -0,0 +1,70 @@
+package cat
+
+import (
+	"backend/pkg/money"
+	"strconv"
+	"time"
+)
+
+type (
+	SalaryChange struct {
+		ID             uint        `json:"id"`
+		CatID          uint        `json:"cat_id"`
+		Salary         money.Money `json:"salary"`
+		PreviousSalary money.Money `json:"previous_salary"`
+		EffectiveFrom  string      `json:"effective_from"`
+		Reason         string      `json:"reason"`
+
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// PayrollReport sums payouts per currency, and in total
+	// converted into the report currency through exchange rates.
+	PayrollReport struct {
+		Month    string        `json:"month"`
+		Days     int           `json:"days"`
+		Currency string        `json:"currency"`
+		Total    money.Money   `json:"total"`
+		Totals   []money.Money `json:"totals"`
+		Payouts  []Payout      `json:"payouts"`
+	}
+
+	// Payout is the salary of a cat for the month, prorated by days across
+	// salary changes and employment dates, in the cat's latest currency.
+	Payout struct {
+		CatID    uint            `json:"cat_id"`
+		CatName  string          `json:"cat_name"`
+		Days     int             `json:"days"`
+		Amount   money.Money     `json:"amount"`
+		Segments []PayoutSegment `json:"segments"`
+	}
+
+	PayoutSegment struct {
+		From   string      `json:"from"`
+		To     string      `json:"to"` // inclusive
+		Days   int         `json:"days"`
+		Salary money.Money `json:"salary"`
+	}
+
+	ExchangeRate struct {
+		BaseCurrency  string    `json:"base_currency"`
+		QuoteCurrency string    `json:"quote_currency"`
+		Rate          string    `json:"rate"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	}
+)
+
+// PayrollCSVHeader is the header row of the CSV payroll report.
+// Amounts are in minor units of the currency.
+var PayrollCSVHeader = []string{"cat_id", "cat_name", "days", "amount", "currency"}
+
+// CSVRecord returns the payout as a row matching PayrollCSVHeader.
+func (p Payout) CSVRecord() []string {
+	return []string{
+		strconv.FormatUint(uint64(p.CatID), 10),
+		p.CatName,
+		strconv.Itoa(p.Days),
+		strconv.FormatInt(p.Amount.Amount, 10),
+		p.Amount.Currency,
+	}
+}
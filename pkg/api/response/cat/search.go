@@ -0,0 +1,10 @@
+package cat
+
+type SearchResult struct {
+	Type      string  `json:"type"` // target or cat
+	ID        uint    `json:"id"`
+	MissionID *uint   `json:"mission_id,omitempty"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"` // HTML escaped, matches wrapped in <mark></mark>
+	Rank      float64 `json:"rank"`
+}
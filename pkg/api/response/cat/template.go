@@ -0,0 +1,23 @@
+package cat
+
+import "time"
+
+type (
+	MissionTemplate struct {
+		ID          uint             `json:"id"`
+		Name        string           `json:"name"`
+		Description string           `json:"description"`
+		TypeID      *uint            `json:"type_id"`
+		Priority    string           `json:"priority"`
+		Targets     []TemplateTarget `json:"targets"`
+		CreatedAt   time.Time        `json:"created_at"`
+		UpdatedAt   time.Time        `json:"updated_at"`
+	}
+
+	TemplateTarget struct {
+		Name     string `json:"name"`
+		Country  string `json:"country"`
+		Notes    string `json:"notes"`
+		Priority string `json:"priority"`
+	}
+)
@@ -0,0 +1,70 @@
+package cat
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	// Webhook secret is only returned on creation.
+	Webhook struct {
+		ID     uint     `json:"id"`
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret,omitempty"`
+
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	WebhookDelivery struct {
+		ID             uint       `json:"id"`
+		WebhookID      uint       `json:"webhook_id"`
+		EventID        string     `json:"event_id"`
+		Event          string     `json:"event"`
+		Status         string     `json:"status"` // pending, succeeded or failed
+		Attempts       int        `json:"attempts"`
+		NextAttemptAt  *time.Time `json:"next_attempt_at"`
+		LastAttemptAt  *time.Time `json:"last_attempt_at"`
+		ResponseStatus int        `json:"response_status"`
+		LastError      string     `json:"last_error"`
+		DeliveredAt    *time.Time `json:"delivered_at"`
+		RedeliveryOf   *uint      `json:"redelivery_of"`
+		CreatedAt      time.Time  `json:"created_at"`
+	}
+
+	// Event is the body posted to webhooks. The ID stays the same across
+	// retries and redeliveries.
+	Event struct {
+		ID         string          `json:"id"`
+		Type       string          `json:"type"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Data       json.RawMessage `json:"data"`
+	}
+
+	// MissionEvent is the data of mission.assigned and mission.completed events.
+	MissionEvent struct {
+		MissionID uint  `json:"mission_id"`
+		CatID     *uint `json:"cat_id"`
+	}
+
+	// TargetEvent is the data of target.completed events.
+	TargetEvent struct {
+		MissionID uint  `json:"mission_id"`
+		TargetID  uint  `json:"target_id"`
+		CatID     *uint `json:"cat_id"`
+	}
+
+	// MissionUpdate is pushed to mission streams. Notes are left out and
+	// Truncated set when they are too large to push, refetch the mission then.
+	MissionUpdate struct {
+		Type       string    `json:"type"`
+		MissionID  uint      `json:"mission_id"`
+		TargetID   *uint     `json:"target_id,omitempty"`
+		MessageID  *uint     `json:"message_id,omitempty"`
+		CatID      *uint     `json:"cat_id,omitempty"`
+		Notes      *string   `json:"notes,omitempty"`
+		Truncated  bool      `json:"truncated,omitempty"`
+		OccurredAt time.Time `json:"occurred_at"`
+	}
+)
@@ -0,0 +1,14 @@
+// Package backoff computes the delays between retries of failed work.
+package backoff
+
+import "time"
+
+// Exponential returns the delay after the given number of attempts: base after
+// the first attempt, doubled per attempt after it, up to limit.
+func Exponential(base, limit time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
@@ -0,0 +1,31 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		base, limit time.Duration
+		attempts    int
+		want        time.Duration
+	}{
+		{30 * time.Second, 10 * time.Minute, 0, 30 * time.Second},
+		{30 * time.Second, 10 * time.Minute, 1, 30 * time.Second},
+		{30 * time.Second, 10 * time.Minute, 2, time.Minute},
+		{30 * time.Second, 10 * time.Minute, 3, 2 * time.Minute},
+		{30 * time.Second, 10 * time.Minute, 5, 8 * time.Minute},
+		{30 * time.Second, 10 * time.Minute, 6, 10 * time.Minute},
+		{30 * time.Second, 10 * time.Minute, 100, 10 * time.Minute},
+		{time.Second, 5 * time.Minute, 9, 256 * time.Second},
+		{time.Second, 5 * time.Minute, 10, 5 * time.Minute},
+		{time.Minute, 30 * time.Second, 1, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := Exponential(tt.base, tt.limit, tt.attempts); got != tt.want {
+			t.Errorf("Exponential(%v, %v, %d) = %v, want %v", tt.base, tt.limit, tt.attempts, got, tt.want)
+		}
+	}
+}
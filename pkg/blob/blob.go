@@ -0,0 +1,43 @@
+// Package blob stores opaque files under string keys, on the local
+// filesystem or in an S3 compatible bucket.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob: not found")
+	ErrInvalidKey = errors.New("blob: invalid key")
+)
+
+type (
+	Store interface {
+		// Put stores size bytes of r under key, replacing any previous blob.
+		Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+		// Open returns the blob content, ErrNotFound if there is none.
+		Open(ctx context.Context, key string) (io.ReadCloser, error)
+		// Delete removes the blob, deleting a missing blob is not an error.
+		Delete(ctx context.Context, key string) error
+	}
+
+	// Presigner is implemented by stores serving downloads themselves.
+	Presigner interface {
+		// PresignGet returns a URL downloading the blob as filename until expiry.
+		PresignGet(ctx context.Context, key, filename string, expiry time.Duration) (string, error)
+	}
+)
+
+// validKey accepts relative slash separated keys without dot segments.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key ||
+		strings.HasPrefix(key, "../") || key == ".." || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+	return nil
+}
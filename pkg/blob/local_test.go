@@ -0,0 +1,79 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewLocal(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s.Put(ctx, "missions/1/a.txt", strings.NewReader("evidence"), 8, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.Open(ctx, "missions/1/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(content) != "evidence" {
+		t.Errorf("Open = %q, %v, want the stored content", content, err)
+	}
+
+	// a short body leaves neither the blob nor a temporary file behind
+	if err = s.Put(ctx, "missions/1/b.txt", strings.NewReader("short"), 8, "text/plain"); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Put short = %v, want io.ErrUnexpectedEOF", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "blobs", "missions", "1"))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("files = %v, %v, want the first blob only", entries, err)
+	}
+
+	if err = s.Delete(ctx, "missions/1/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Open(ctx, "missions/1/a.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open deleted = %v, want ErrNotFound", err)
+	}
+	if err = s.Delete(ctx, "missions/1/a.txt"); err != nil {
+		t.Errorf("Delete missing = %v, want nil", err)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{key: "missions/1/2/abc.pdf", valid: true},
+		{key: "abc", valid: true},
+		{key: ""},
+		{key: "/etc/passwd"},
+		{key: "../secret"},
+		{key: ".."},
+		{key: "missions/../../secret"},
+		{key: "missions//1"},
+		{key: "missions/./1"},
+		{key: `missions\1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := validKey(tt.key)
+			if (err == nil) != tt.valid || (err != nil && !errors.Is(err, ErrInvalidKey)) {
+				t.Errorf("validKey(%q) = %v, want valid %v", tt.key, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 stores blobs in a bucket of an S3 compatible service, such as MinIO.
+type S3 struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 connects to the endpoint (host:port) and creates the bucket if it doesn't exist.
+func NewS3(ctx context.Context, endpoint, region, bucket, accessKey, secretKey string, useSSL bool) (S3, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return S3{}, err
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return S3{}, err
+	}
+	if !exists {
+		if err = client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+			return S3{}, err
+		}
+	}
+	return S3{client, bucket}, nil
+}
+
+func (s S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy, stat to report missing blobs now
+	if _, err = obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s S3) Delete(ctx context.Context, key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// PresignGet returns a URL of the bucket, signed for expiry, downloading the blob as filename.
+func (s S3) PresignGet(ctx context.Context, key, filename string, expiry time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	params := url.Values{}
+	params.Set("response-content-disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, expiry, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,58 @@
+package blob
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLSigner(t *testing.T) {
+	s := NewURLSigner([]byte("secret"))
+	expires := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	signed := s.Sign("/evidence/7/download", expires)
+
+	path, rawQuery, ok := strings.Cut(signed, "?")
+	if !ok || path != "/evidence/7/download" {
+		t.Fatalf("Sign = %q, want the path with a query", signed)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, signature := query.Get("expires"), query.Get("signature")
+
+	tests := []struct {
+		name      string
+		signer    URLSigner
+		path      string
+		expires   string
+		signature string
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", path: path, expires: ts, signature: signature, now: expires.Add(-time.Minute)},
+		{name: "at expiry", path: path, expires: ts, signature: signature, now: expires},
+		{name: "expired", path: path, expires: ts, signature: signature, now: expires.Add(time.Second), wantErr: ErrURLExpired},
+		{name: "other path", path: "/evidence/8/download", expires: ts, signature: signature, now: expires, wantErr: ErrURLSignature},
+		{name: "extended expiry", path: path, expires: "4102444800", signature: signature, now: expires, wantErr: ErrURLSignature},
+		{name: "tampered signature", path: path, expires: ts, signature: strings.Repeat("0", len(signature)), now: expires, wantErr: ErrURLSignature},
+		{name: "no signature", path: path, expires: ts, now: expires, wantErr: ErrURLSignature},
+		{name: "invalid expiry", path: path, expires: "tomorrow", signature: signature, now: expires, wantErr: ErrURLSignature},
+		{name: "other secret", signer: NewURLSigner([]byte("other")), path: path, expires: ts, signature: signature, now: expires, wantErr: ErrURLSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := s
+			if tt.signer.secret != nil {
+				signer = tt.signer
+			}
+			err := signer.Verify(tt.path, tt.expires, tt.signature, tt.now)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Verify = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
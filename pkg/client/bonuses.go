@@ -0,0 +1,39 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) GetBonusRules(ctx context.Context) ([]response.BonusRule, error) {
+	var rules []response.BonusRule
+	err := c.do(ctx, http.MethodGet, "/bonus-rules", nil, map[string]any{"bonus_rules": &rules})
+	return rules, err
+}
+
+func (c *Client) CreateBonusRule(ctx context.Context, body request.BonusRule) (response.BonusRule, error) {
+	var rule response.BonusRule
+	err := c.do(ctx, http.MethodPost, "/bonus-rules", body, map[string]any{"bonus_rule": &rule})
+	return rule, err
+}
+
+func (c *Client) DeleteBonusRule(ctx context.Context, ruleID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/bonus-rules/%d", ruleID), nil, nil)
+}
+
+// PreviewBonus returns the bonus the mission would pay if completed now.
+func (c *Client) PreviewBonus(ctx context.Context, missionID uint) (response.BonusPreview, error) {
+	var preview response.BonusPreview
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/missions/%d/bonus-preview", missionID), nil,
+		map[string]any{"bonus_preview": &preview})
+	return preview, err
+}
+
+func (c *Client) GetCatBonuses(ctx context.Context, catID uint) ([]response.BonusEntry, error) {
+	var entries []response.BonusEntry
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/cats/%d/bonuses", catID), nil, map[string]any{"bonuses": &entries})
+	return entries, err
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// GetCats lists cats, optionally filtered by breed.
+func (c *Client) GetCats(ctx context.Context, breed string) ([]response.Cat, error) {
+	path := "/cats"
+	if breed != "" {
+		path += "?breed=" + url.QueryEscape(breed)
+	}
+
+	var cats []response.Cat
+	err := c.do(ctx, http.MethodGet, path, nil, map[string]any{"cats": &cats})
+	return cats, err
+}
+
+func (c *Client) GetCat(ctx context.Context, catID uint) (response.Cat, error) {
+	var cat response.Cat
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/cats/%d", catID), nil, map[string]any{"cat": &cat})
+	return cat, err
+}
+
+func (c *Client) CreateCat(ctx context.Context, body request.Cat) (response.Cat, error) {
+	var cat response.Cat
+	err := c.do(ctx, http.MethodPost, "/cats", body, map[string]any{"cat": &cat})
+	return cat, err
+}
+
+func (c *Client) UpdateCat(ctx context.Context, catID uint, body request.UpdateCat) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/cats/%d", catID), body, nil)
+}
+
+func (c *Client) DeleteCat(ctx context.Context, catID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/cats/%d", catID), nil, nil)
+}
+
+// ImportCats uploads a CSV (text/csv) or NDJSON (application/x-ndjson) import.
+// When an atomic import is rejected the report is returned along with the error.
+func (c *Client) ImportCats(ctx context.Context, contentType string, r io.Reader, mode string) (response.ImportReport, error) {
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return response.ImportReport{}, fmt.Errorf("read import: %w", err)
+	}
+
+	resp, err := c.request(ctx, http.MethodPost, "/cats/import?mode="+url.QueryEscape(mode), contentType, payload)
+	if err != nil {
+		return response.ImportReport{}, err
+	}
+	defer resp.Body.Close()
+
+	var report response.ImportReport
+	err = decode(resp, map[string]any{"report": &report})
+	return report, err
+}
+
+// ExportCats streams the cats roster in the given format (csv or json) to w.
+func (c *Client) ExportCats(ctx context.Context, format string, w io.Writer) error {
+	resp, err := c.request(ctx, http.MethodGet, "/cats/export?format="+url.QueryEscape(format), "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (c *Client) GetSalaryHistory(ctx context.Context, catID uint) ([]response.SalaryChange, error) {
+	var history []response.SalaryChange
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/cats/%d/salary-history", catID), nil,
+		map[string]any{"salary_history": &history})
+	return history, err
+}
+
+// UpdateCatProfile applies the partial profile update, nil fields are kept.
+func (c *Client) UpdateCatProfile(ctx context.Context, catID uint, body request.CatProfile) (response.Cat, error) {
+	var cat response.Cat
+	err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/cats/%d/profile", catID), body, map[string]any{"cat": &cat})
+	return cat, err
+}
+
+// UpdateCatStatus moves the cat to the new status, e.g. retired, with a reason.
+func (c *Client) UpdateCatStatus(ctx context.Context, catID uint, body request.CatStatus) (response.Cat, error) {
+	var cat response.Cat
+	err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/cats/%d/status", catID), body, map[string]any{"cat": &cat})
+	return cat, err
+}
+
+func (c *Client) GetStatusHistory(ctx context.Context, catID uint) ([]response.CatStatusChange, error) {
+	var history []response.CatStatusChange
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/cats/%d/status-history", catID), nil,
+		map[string]any{"status_history": &history})
+	return history, err
+}
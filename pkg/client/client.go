@@ -0,0 +1,200 @@
+package client
+
+import (
+	"backend/pkg/api/caller"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	_defaultTimeout    = 10 * time.Second
+	_defaultMaxRetries = 3
+	_defaultBackoff    = 200 * time.Millisecond
+)
+
+type (
+	// Client - Spy Cats API client.
+	Client struct {
+		baseURL    string
+		httpClient *http.Client
+		maxRetries int
+		backoff    time.Duration
+		header     http.Header
+	}
+
+	// Option - represents client option.
+	Option func(*Client)
+
+	// envelope mirrors response.Response with lazily decoded data.
+	envelope struct {
+		Code    int                        `json:"code"`
+		Data    map[string]json.RawMessage `json:"data"`
+		Message string                     `json:"message"`
+		Detail  string                     `json:"detail"`
+	}
+)
+
+// HTTPClient - configures the underlying http client.
+func HTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// MaxRetries - configures how many times idempotent requests are retried.
+func MaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// Backoff - configures the initial delay between retries, doubled on each attempt.
+func Backoff(d time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = d
+	}
+}
+
+// Caller - sends requests as the given role (handler or cat), catID is set for cats.
+// The API expects these headers from an authenticating gateway.
+func Caller(role string, catID uint) Option {
+	return func(c *Client) {
+		c.header.Set(caller.HeaderRole, role)
+		if catID != 0 {
+			c.header.Set(caller.HeaderCatID, strconv.FormatUint(uint64(catID), 10))
+		}
+	}
+}
+
+// New - creates the API client for the given base URL, e.g. http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: _defaultTimeout},
+		maxRetries: _defaultMaxRetries,
+		backoff:    _defaultBackoff,
+		header:     make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// do sends the JSON request and decodes the response data into out,
+// where out maps data keys to destination pointers.
+func (c *Client) do(ctx context.Context, method, path string, body any, out map[string]any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+	}
+
+	resp, err := c.request(ctx, method, path, "application/json", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decode(resp, out)
+}
+
+// request sends the request, retrying transient failures with exponential backoff.
+func (c *Client) request(ctx context.Context, method, path, contentType string, payload []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, contentType, payload)
+		if attempt >= c.maxRetries || !retryable(method, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.backoff << attempt):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path, contentType string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// retryable reports whether the attempt failed transiently. Only idempotent
+// methods are retried, and never after the context is done.
+func retryable(method string, resp *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	if err != nil {
+		return !isContextErr(err)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusGatewayTimeout
+}
+
+func decode(resp *http.Response, out map[string]any) error {
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil && err != io.EOF {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	failed := resp.StatusCode >= http.StatusBadRequest || env.Code != 0
+
+	for key, dst := range out {
+		raw, ok := env.Data[key]
+		if !ok {
+			if failed {
+				continue
+			}
+			return fmt.Errorf("response has no %q data", key)
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return fmt.Errorf("decode %q data: %w", key, err)
+		}
+	}
+
+	if failed {
+		return newError(resp.StatusCode, env)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package client_test
+
+import (
+	"backend/config"
+	"backend/pkg/api/caller"
+	request "backend/pkg/api/request/cat"
+	"backend/pkg/client"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newServer serves handler and returns a client for it with fast retries.
+func newServer(t *testing.T, handler http.HandlerFunc, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]client.Option{client.Backoff(time.Millisecond)}, opts...)
+	return client.New(srv.URL+"/", opts...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	io.WriteString(w, body)
+}
+
+func TestGetCats(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/cats" || r.URL.Query().Get("breed") != "Maine Coon" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if got := r.Header.Get("Accept"); got != "application/json" {
+			t.Errorf("Accept = %q", got)
+		}
+		writeJSON(w, http.StatusOK, `{"code":0,"data":{"cats":[{"id":7,"name":"Tom","breed":"Maine Coon","salary":{"amount":120050,"currency":"usd"}}]}}`)
+	})
+
+	cats, err := c.GetCats(context.Background(), "Maine Coon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cats) != 1 || cats[0].ID != 7 || cats[0].Name != "Tom" || cats[0].Salary.String() != "1200.50 USD" {
+		t.Fatalf("cats = %+v", cats)
+	}
+}
+
+func TestCreateMission(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/missions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q", got)
+		}
+
+		var body request.Mission
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if body.Priority != "high" || len(body.Targets) != 1 || body.Targets[0].Name != "Ivan" {
+			t.Errorf("body = %+v", body)
+		}
+		writeJSON(w, http.StatusCreated, `{"code":0,"data":{"mission":{"id":3,"priority":"high"}}}`)
+	})
+
+	mission, err := c.CreateMission(context.Background(), request.Mission{
+		Priority: "high",
+		Targets:  request.Targets{{Name: "Ivan", Country: "UA"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mission.ID != 3 || mission.Priority != "high" {
+		t.Fatalf("mission = %+v", mission)
+	}
+}
+
+func TestCallerHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []client.Option
+		wantRole  string
+		wantCatID string
+	}{
+		{"none", nil, "", ""},
+		{"handler", []client.Option{client.Caller(caller.RoleHandler, 0)}, caller.RoleHandler, ""},
+		{"cat", []client.Option{client.Caller(caller.RoleCat, 42)}, caller.RoleCat, "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get(caller.HeaderRole); got != tt.wantRole {
+					t.Errorf("%s = %q, want %q", caller.HeaderRole, got, tt.wantRole)
+				}
+				if got := r.Header.Get(caller.HeaderCatID); got != tt.wantCatID {
+					t.Errorf("%s = %q, want %q", caller.HeaderCatID, got, tt.wantCatID)
+				}
+				writeJSON(w, http.StatusOK, `{"code":0,"data":{"cats":[]}}`)
+			}, tt.opts...)
+
+			if _, err := c.GetCats(context.Background(), ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus int
+		wantCode   client.Code
+		wantIs     error
+	}{
+		{
+			name:       "domain error",
+			status:     http.StatusNotFound,
+			body:       `{"code":4,"message":"cat not found"}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   client.CodeNotFound,
+			wantIs:     &client.Error{Code: client.CodeNotFound, Message: "cat not found"},
+		},
+		{
+			name:       "non-json body",
+			status:     http.StatusInternalServerError,
+			body:       `internal error`,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   client.CodeOK,
+		},
+		{
+			name:       "error code on 200",
+			status:     http.StatusOK,
+			body:       `{"code":1,"message":"bad request"}`,
+			wantStatus: http.StatusOK,
+			wantCode:   client.CodeBadRequest,
+			wantIs:     &client.Error{Code: client.CodeBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			_, err := c.GetCat(context.Background(), 1)
+
+			var apiErr *client.Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *client.Error", err)
+			}
+			if apiErr.StatusCode != tt.wantStatus || apiErr.Code != tt.wantCode {
+				t.Fatalf("err = %+v", apiErr)
+			}
+			if !client.IsCode(err, tt.wantCode) {
+				t.Fatalf("IsCode(%d) = false", tt.wantCode)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Fatalf("errors.Is(%v) = false", tt.wantIs)
+			}
+			if errors.Is(err, &client.Error{Code: tt.wantCode, Message: "mission not found"}) {
+				t.Fatal("matched an error with a different message")
+			}
+		})
+	}
+}
+
+// TestCodes keeps the SDK codes in sync with the server ones.
+func TestCodes(t *testing.T) {
+	codes := map[client.Code]config.ServiceCode{
+		client.CodeOK:                  config.CodeOK,
+		client.CodeBadRequest:          config.CodeBadRequest,
+		client.CodeUnprocessableEntity: config.CodeUnprocessableEntity,
+		client.CodeDatabaseError:       config.CodeDatabaseError,
+		client.CodeNotFound:            config.CodeNotFound,
+		client.CodeUnauthorized:        config.CodeUnauthorized,
+		client.CodeForbidden:           config.CodeForbidden,
+		client.CodeConflict:            config.CodeConflict,
+		client.CodeExternalRequestFail: config.CodeExternalRequestFail,
+		client.CodeInternal:            config.CodeInternal,
+	}
+	for code, want := range codes {
+		if int(code) != int(want) {
+			t.Errorf("client code %d, server code %d", code, want)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(*client.Client) error
+		failures  int32
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name:      "get recovers",
+			call:      func(c *client.Client) error { _, err := c.GetCat(context.Background(), 1); return err },
+			failures:  2,
+			wantCalls: 3,
+		},
+		{
+			name:      "get gives up",
+			call:      func(c *client.Client) error { _, err := c.GetCat(context.Background(), 1); return err },
+			failures:  10,
+			wantCalls: 3, // the first attempt and MaxRetries(2)
+			wantErr:   true,
+		},
+		{
+			name:      "post is not retried",
+			call:      func(c *client.Client) error { _, err := c.CreateCat(context.Background(), request.Cat{}); return err },
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				writeJSON(w, http.StatusOK, `{"code":0,"data":{"cat":{"id":1}}}`)
+			}, client.MaxRetries(2))
+
+			err := tt.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, client.Backoff(time.Hour))
+
+	if _, err := c.GetCat(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+}
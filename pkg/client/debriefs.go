@@ -0,0 +1,49 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) GetDebrief(ctx context.Context, missionID uint) (response.Debrief, error) {
+	var debrief response.Debrief
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/missions/%d/debrief", missionID), nil,
+		map[string]any{"debrief": &debrief})
+	return debrief, err
+}
+
+// RenderDebrief writes the debrief rendered as markdown or html to w.
+func (c *Client) RenderDebrief(ctx context.Context, missionID uint, format string, w io.Writer) error {
+	path := fmt.Sprintf("/missions/%d/debrief?format=%s", missionID, url.QueryEscape(format))
+	resp, err := c.request(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (c *Client) SaveDebrief(ctx context.Context, missionID uint, body request.Debrief) (response.Debrief, error) {
+	var debrief response.Debrief
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/missions/%d/debrief", missionID), body,
+		map[string]any{"debrief": &debrief})
+	return debrief, err
+}
+
+func (c *Client) SaveSelfAssessment(ctx context.Context, missionID uint, selfAssessment string) (response.Debrief, error) {
+	var debrief response.Debrief
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/missions/%d/debrief/self-assessment", missionID),
+		request.SelfAssessment{SelfAssessment: selfAssessment}, map[string]any{"debrief": &debrief})
+	return debrief, err
+}
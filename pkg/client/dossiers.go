@@ -0,0 +1,47 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetDossiers lists dossiers whose name or alias contains q, all if q is empty.
+func (c *Client) GetDossiers(ctx context.Context, q string) ([]response.Dossier, error) {
+	path := "/dossiers"
+	if q != "" {
+		path += "?q=" + url.QueryEscape(q)
+	}
+
+	var dossiers []response.Dossier
+	err := c.do(ctx, http.MethodGet, path, nil, map[string]any{"dossiers": &dossiers})
+	return dossiers, err
+}
+
+// GetDossier returns the dossier with every mission target linked to it.
+func (c *Client) GetDossier(ctx context.Context, dossierID uint) (response.DossierDetail, error) {
+	var dossier response.DossierDetail
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/dossiers/%d", dossierID), nil, map[string]any{"dossier": &dossier})
+	return dossier, err
+}
+
+// CreateDossier creates the dossier, unless a similar one exists and force is not set.
+func (c *Client) CreateDossier(ctx context.Context, body request.Dossier, force bool) (response.Dossier, error) {
+	path := "/dossiers"
+	if force {
+		path += "?force=true"
+	}
+
+	var dossier response.Dossier
+	err := c.do(ctx, http.MethodPost, path, body, map[string]any{"dossier": &dossier})
+	return dossier, err
+}
+
+func (c *Client) UpdateDossier(ctx context.Context, dossierID uint, body request.UpdateDossier) (response.Dossier, error) {
+	var dossier response.Dossier
+	err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/dossiers/%d", dossierID), body, map[string]any{"dossier": &dossier})
+	return dossier, err
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Code - service code of an API response, mirrors the codes of the server.
+type Code int
+
+const (
+	CodeOK                  Code = 0
+	CodeBadRequest          Code = 1
+	CodeUnprocessableEntity Code = 2
+	CodeDatabaseError       Code = 3
+	CodeNotFound            Code = 4
+	CodeUnauthorized        Code = 5
+	CodeForbidden           Code = 6
+	CodeConflict            Code = 7
+	CodeExternalRequestFail Code = 8
+	CodeInternal            Code = 9
+)
+
+// Error - error returned by the API, decoded from the response envelope.
+type Error struct {
+	StatusCode int
+	Code       Code
+	Message    string
+	Detail     string
+}
+
+func newError(status int, env envelope) *Error {
+	return &Error{
+		StatusCode: status,
+		Code:       Code(env.Code),
+		Message:    env.Message,
+		Detail:     env.Detail,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("spy cats api: %d (code %d): %s", e.StatusCode, e.Code, e.Message)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// Is matches the error against a target *Error by service code and message,
+// the message is not compared when the target has none:
+//
+//	errors.Is(err, &client.Error{Code: client.CodeNotFound, Message: "cat not found"})
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code && (t.Message == "" || e.Message == t.Message)
+}
+
+// IsCode reports whether err is an API error with the given service code.
+func IsCode(err error, code Code) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == code
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	response "backend/pkg/api/response/cat"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+func (c *Client) GetEvidence(ctx context.Context, missionID, targetID uint) ([]response.Evidence, error) {
+	var evidence []response.Evidence
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/missions/%d/targets/%d/evidence", missionID, targetID), nil,
+		map[string]any{"evidence": &evidence})
+	return evidence, err
+}
+
+// UploadEvidence uploads the content of r as a target evidence file named filename.
+func (c *Client) UploadEvidence(ctx context.Context, missionID, targetID uint, filename string, r io.Reader) (response.Evidence, error) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return response.Evidence{}, fmt.Errorf("create form file: %w", err)
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return response.Evidence{}, fmt.Errorf("read evidence: %w", err)
+	}
+	if err = form.Close(); err != nil {
+		return response.Evidence{}, fmt.Errorf("close form: %w", err)
+	}
+
+	path := fmt.Sprintf("/missions/%d/targets/%d/evidence", missionID, targetID)
+	resp, err := c.request(ctx, http.MethodPost, path, form.FormDataContentType(), buf.Bytes())
+	if err != nil {
+		return response.Evidence{}, err
+	}
+	defer resp.Body.Close()
+
+	var evidence response.Evidence
+	err = decode(resp, map[string]any{"evidence": &evidence})
+	return evidence, err
+}
+
+func (c *Client) DeleteEvidence(ctx context.Context, missionID, targetID, evidenceID uint) error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/missions/%d/targets/%d/evidence/%d", missionID, targetID, evidenceID), nil, nil)
+}
+
+// DownloadEvidence writes the file behind an evidence download URL to w.
+// Presigned object store URLs are fetched as is, without the client headers.
+func (c *Client) DownloadEvidence(ctx context.Context, downloadURL string, w io.Writer) error {
+	var (
+		resp *http.Response
+		err  error
+	)
+	if strings.HasPrefix(downloadURL, "http://") || strings.HasPrefix(downloadURL, "https://") {
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+		if reqErr != nil {
+			return fmt.Errorf("create request: %w", reqErr)
+		}
+		resp, err = c.httpClient.Do(req)
+	} else {
+		resp, err = c.request(ctx, http.MethodGet, downloadURL, "", nil)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
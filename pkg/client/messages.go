@@ -0,0 +1,56 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GetMessages returns the mission thread after afterID, the whole thread if 0.
+func (c *Client) GetMessages(ctx context.Context, missionID, afterID uint) (response.Thread, error) {
+	path := fmt.Sprintf("/missions/%d/messages", missionID)
+	if afterID != 0 {
+		path += fmt.Sprintf("?after_id=%d", afterID)
+	}
+
+	var thread response.Thread
+	err := c.do(ctx, http.MethodGet, path, nil,
+		map[string]any{"messages": &thread.Messages, "archived": &thread.Archived})
+	return thread, err
+}
+
+func (c *Client) PostMessage(ctx context.Context, missionID uint, body request.Message) (response.Message, error) {
+	var message response.Message
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/%d/messages", missionID), body,
+		map[string]any{"message": &message})
+	return message, err
+}
+
+// MarkMessagesRead marks messages of the other side read up to upToID, all if 0.
+// It returns how many messages were newly marked.
+func (c *Client) MarkMessagesRead(ctx context.Context, missionID, upToID uint) (int64, error) {
+	var marked int64
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/%d/messages/read", missionID),
+		request.MarkRead{UpToID: upToID}, map[string]any{"marked": &marked})
+	return marked, err
+}
+
+// GetMessageAttachment writes the attachment data to w.
+func (c *Client) GetMessageAttachment(ctx context.Context, missionID, messageID, attachmentID uint, w io.Writer) error {
+	path := fmt.Sprintf("/missions/%d/messages/%d/attachments/%d", missionID, messageID, attachmentID)
+	resp, err := c.request(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetMissions lists missions, empty filter fields are not applied.
+func (c *Client) GetMissions(ctx context.Context, filter request.MissionFilter) ([]response.Mission, error) {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"priority":   filter.Priority,
+		"due_after":  filter.DueAfter,
+		"due_before": filter.DueBefore,
+		"overdue":    filter.Overdue,
+		"completed":  filter.Completed,
+		"sort":       filter.Sort,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	path := "/missions"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var missions []response.Mission
+	err := c.do(ctx, http.MethodGet, path, nil, map[string]any{"missions": &missions})
+	return missions, err
+}
+
+func (c *Client) GetMission(ctx context.Context, missionID uint) (response.Mission, error) {
+	var mission response.Mission
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/missions/%d", missionID), nil,
+		map[string]any{"mission": &mission})
+	return mission, err
+}
+
+func (c *Client) CreateMission(ctx context.Context, body request.Mission) (response.Mission, error) {
+	var mission response.Mission
+	err := c.do(ctx, http.MethodPost, "/missions", body, map[string]any{"mission": &mission})
+	return mission, err
+}
+
+func (c *Client) AssignCat(ctx context.Context, missionID, catID uint) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/missions/%d/assign/%d", missionID, catID), nil, nil)
+}
+
+// GetCandidates returns cats available for the mission, best first.
+func (c *Client) GetCandidates(ctx context.Context, missionID uint) ([]response.Candidate, error) {
+	var candidates []response.Candidate
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/missions/%d/candidates", missionID), nil,
+		map[string]any{"candidates": &candidates})
+	return candidates, err
+}
+
+// AutoAssign assigns the best candidate to the mission.
+func (c *Client) AutoAssign(ctx context.Context, missionID uint) (response.Candidate, error) {
+	var candidate response.Candidate
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/%d/auto-assign", missionID), nil,
+		map[string]any{"candidate": &candidate})
+	return candidate, err
+}
+
+// UpdateMissionSchedule replaces the mission priority and dates.
+func (c *Client) UpdateMissionSchedule(ctx context.Context, missionID uint, body request.Schedule) (response.Mission, error) {
+	var mission response.Mission
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/missions/%d/schedule", missionID), body,
+		map[string]any{"mission": &mission})
+	return mission, err
+}
+
+func (c *Client) CompleteMission(ctx context.Context, missionID uint) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/missions/%d/complete", missionID), nil, nil)
+}
+
+func (c *Client) DeleteMission(ctx context.Context, missionID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/missions/%d", missionID), nil, nil)
+}
+
+func (c *Client) CreateTarget(ctx context.Context, missionID uint, body request.Target) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/%d/targets", missionID), body, nil)
+}
+
+func (c *Client) UpdateTarget(ctx context.Context, missionID, targetID uint, body request.UpdateTarget) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/missions/%d/targets/%d", missionID, targetID), body, nil)
+}
+
+func (c *Client) CompleteTarget(ctx context.Context, missionID, targetID uint) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/missions/%d/targets/%d/complete", missionID, targetID), nil, nil)
+}
+
+func (c *Client) DeleteTarget(ctx context.Context, missionID, targetID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/missions/%d/targets/%d", missionID, targetID), nil, nil)
+}
+
+// ExportMissionBundle downloads the mission as a portable bundle.
+func (c *Client) ExportMissionBundle(ctx context.Context, missionID uint) (response.MissionBundle, error) {
+	resp, err := c.request(ctx, http.MethodGet, fmt.Sprintf("/missions/%d/bundle", missionID), "", nil)
+	if err != nil {
+		return response.MissionBundle{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return response.MissionBundle{}, decode(resp, nil)
+	}
+
+	var bundle response.MissionBundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundle); err != nil {
+		return response.MissionBundle{}, fmt.Errorf("decode bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+// ImportMissionBundle creates a mission from the bundle, optionally
+// attaching the local cat with the same name as the bundle cat.
+func (c *Client) ImportMissionBundle(ctx context.Context, bundle response.MissionBundle, attachCat bool) (response.BundleImport, error) {
+	var imported response.BundleImport
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/bundle?attach_cat=%t", attachCat), bundle,
+		map[string]any{"mission": &imported.Mission, "target_ids": &imported.TargetIDs})
+	return imported, err
+}
+
+// UpdateTargetSchedule replaces the target priority and dates.
+func (c *Client) UpdateTargetSchedule(ctx context.Context, missionID, targetID uint, body request.Schedule) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/missions/%d/targets/%d/schedule", missionID, targetID), body, nil)
+}
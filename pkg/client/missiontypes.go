@@ -0,0 +1,39 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) GetMissionTypes(ctx context.Context) ([]response.MissionType, error) {
+	var types []response.MissionType
+	err := c.do(ctx, http.MethodGet, "/mission-types", nil, map[string]any{"types": &types})
+	return types, err
+}
+
+func (c *Client) GetMissionType(ctx context.Context, typeID uint) (response.MissionType, error) {
+	var missionType response.MissionType
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/mission-types/%d", typeID), nil,
+		map[string]any{"type": &missionType})
+	return missionType, err
+}
+
+func (c *Client) CreateMissionType(ctx context.Context, body request.MissionType) (response.MissionType, error) {
+	var missionType response.MissionType
+	err := c.do(ctx, http.MethodPost, "/mission-types", body, map[string]any{"type": &missionType})
+	return missionType, err
+}
+
+func (c *Client) UpdateMissionType(ctx context.Context, typeID uint, body request.MissionType) (response.MissionType, error) {
+	var missionType response.MissionType
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/mission-types/%d", typeID), body,
+		map[string]any{"type": &missionType})
+	return missionType, err
+}
+
+func (c *Client) DeleteMissionType(ctx context.Context, typeID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/mission-types/%d", typeID), nil, nil)
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetPayroll returns the payroll report for the month, formatted as YYYY-MM,
+// with the total converted into the currency (server default if empty).
+func (c *Client) GetPayroll(ctx context.Context, month, currency string) (response.PayrollReport, error) {
+	path := "/payroll/" + url.PathEscape(month)
+	if currency != "" {
+		path += "?currency=" + url.QueryEscape(currency)
+	}
+
+	var report response.PayrollReport
+	err := c.do(ctx, http.MethodGet, path, nil, map[string]any{"payroll": &report})
+	return report, err
+}
+
+func (c *Client) GetExchangeRates(ctx context.Context) ([]response.ExchangeRate, error) {
+	var rates []response.ExchangeRate
+	err := c.do(ctx, http.MethodGet, "/exchange-rates", nil, map[string]any{"exchange_rates": &rates})
+	return rates, err
+}
+
+// SetExchangeRate sets the price of one base currency unit in the quote currency.
+func (c *Client) SetExchangeRate(ctx context.Context, base, quote string, body request.ExchangeRate) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/exchange-rates/%s/%s",
+		url.PathEscape(base), url.PathEscape(quote)), body, nil)
+}
+
+func (c *Client) DeleteExchangeRate(ctx context.Context, base, quote string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/exchange-rates/%s/%s",
+		url.PathEscape(base), url.PathEscape(quote)), nil, nil)
+}
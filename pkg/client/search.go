@@ -0,0 +1,26 @@
+package client
+
+import (
+	response "backend/pkg/api/response/cat"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Search runs a full-text query over the given types (target, cat), all if none.
+// Zero limit uses the server default.
+func (c *Client) Search(ctx context.Context, q string, types []string, limit int) ([]response.SearchResult, error) {
+	query := url.Values{"q": {q}}
+	if len(types) > 0 {
+		query.Set("type", strings.Join(types, ","))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var results []response.SearchResult
+	err := c.do(ctx, http.MethodGet, "/search?"+query.Encode(), nil, map[string]any{"results": &results})
+	return results, err
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	response "backend/pkg/api/response/cat"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StreamMission calls fn with each live update of the mission until ctx is done,
+// fn fails or the server ends the stream. The client timeout does not apply,
+// and updates sent while not connected are missed, refetch the mission on reconnect.
+func (c *Client) StreamMission(ctx context.Context, missionID uint, fn func(response.MissionUpdate) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/missions/%d/stream", c.baseURL, missionID), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+
+	err = readEvents(resp.Body, func(data string) error {
+		var update response.MissionUpdate
+		if err := json.Unmarshal([]byte(data), &update); err != nil {
+			return fmt.Errorf("decode mission update: %w", err)
+		}
+		return fn(update)
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// readEvents calls fn with the data of each Server-Sent Event in r.
+func readEvents(r io.Reader, fn func(data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := fn(strings.Join(data, "\n")); err != nil {
+					return err
+				}
+				data = data[:0]
+			}
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+		// comments, event names and ids are not needed
+	}
+	return scanner.Err()
+}
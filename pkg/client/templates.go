@@ -0,0 +1,55 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) GetTemplates(ctx context.Context) ([]response.MissionTemplate, error) {
+	var templates []response.MissionTemplate
+	err := c.do(ctx, http.MethodGet, "/mission-templates", nil, map[string]any{"templates": &templates})
+	return templates, err
+}
+
+func (c *Client) GetTemplate(ctx context.Context, templateID uint) (response.MissionTemplate, error) {
+	var template response.MissionTemplate
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/mission-templates/%d", templateID), nil,
+		map[string]any{"template": &template})
+	return template, err
+}
+
+func (c *Client) CreateTemplate(ctx context.Context, body request.MissionTemplate) (response.MissionTemplate, error) {
+	var template response.MissionTemplate
+	err := c.do(ctx, http.MethodPost, "/mission-templates", body, map[string]any{"template": &template})
+	return template, err
+}
+
+func (c *Client) UpdateTemplate(ctx context.Context, templateID uint, body request.MissionTemplate) (response.MissionTemplate, error) {
+	var template response.MissionTemplate
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/mission-templates/%d", templateID), body,
+		map[string]any{"template": &template})
+	return template, err
+}
+
+func (c *Client) DeleteTemplate(ctx context.Context, templateID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/mission-templates/%d", templateID), nil, nil)
+}
+
+// CreateMissionFromTemplate creates a mission from a template, filling in its targets by position.
+func (c *Client) CreateMissionFromTemplate(ctx context.Context, templateID uint, body request.MissionFromTemplate) (response.Mission, error) {
+	var mission response.Mission
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/from-template/%d", templateID), body,
+		map[string]any{"mission": &mission})
+	return mission, err
+}
+
+// CloneMission creates a mission with the targets of missionID.
+func (c *Client) CloneMission(ctx context.Context, missionID uint, body request.CloneMission) (response.Mission, error) {
+	var mission response.Mission
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/missions/%d/clone", missionID), body,
+		map[string]any{"mission": &mission})
+	return mission, err
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	request "backend/pkg/api/request/cat"
+	response "backend/pkg/api/response/cat"
+	"backend/pkg/signature"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (c *Client) GetWebhooks(ctx context.Context) ([]response.Webhook, error) {
+	var webhooks []response.Webhook
+	err := c.do(ctx, http.MethodGet, "/webhooks", nil, map[string]any{"webhooks": &webhooks})
+	return webhooks, err
+}
+
+func (c *Client) GetWebhook(ctx context.Context, webhookID uint) (response.Webhook, error) {
+	var webhook response.Webhook
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/webhooks/%d", webhookID), nil,
+		map[string]any{"webhook": &webhook})
+	return webhook, err
+}
+
+// CreateWebhook subscribes a URL, the returned webhook holds the signing secret.
+func (c *Client) CreateWebhook(ctx context.Context, body request.Webhook) (response.Webhook, error) {
+	var webhook response.Webhook
+	err := c.do(ctx, http.MethodPost, "/webhooks", body, map[string]any{"webhook": &webhook})
+	return webhook, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/webhooks/%d", webhookID), nil, nil)
+}
+
+func (c *Client) GetWebhookDeliveries(ctx context.Context, webhookID uint) ([]response.WebhookDelivery, error) {
+	var deliveries []response.WebhookDelivery
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/webhooks/%d/deliveries", webhookID), nil,
+		map[string]any{"deliveries": &deliveries})
+	return deliveries, err
+}
+
+// Redeliver replays the deliveries, every failed one if none are given.
+func (c *Client) Redeliver(ctx context.Context, webhookID uint, deliveryIDs ...uint) ([]response.WebhookDelivery, error) {
+	var deliveries []response.WebhookDelivery
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/webhooks/%d/redeliver", webhookID),
+		request.Redeliver{DeliveryIDs: deliveryIDs}, map[string]any{"deliveries": &deliveries})
+	return deliveries, err
+}
+
+// VerifyWebhook checks the signature header of a received webhook body,
+// signed at most tolerance ago.
+func VerifyWebhook(secret string, body []byte, header http.Header, tolerance time.Duration) error {
+	return signature.Verify(secret, body, header.Get(signature.Header), tolerance, time.Now())
+}
@@ -0,0 +1,74 @@
+// Package country resolves country codes, names and common aliases
+// to ISO 3166-1 countries using an embedded dataset.
+package country
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// countries.csv columns: alpha2, alpha3, display name, ';' separated aliases.
+//
+//go:embed countries.csv
+var dataset string
+
+type Country struct {
+	Alpha2 string
+	Alpha3 string
+	Name   string
+}
+
+var (
+	byCode = make(map[string]Country) // alpha-2
+	index  = make(map[string]Country) // any normalized spelling
+)
+
+func init() {
+	records, err := csv.NewReader(strings.NewReader(dataset)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("country: invalid dataset: %v", err))
+	}
+
+	for _, r := range records[1:] {
+		c := Country{Alpha2: r[0], Alpha3: r[1], Name: r[2]}
+		byCode[c.Alpha2] = c
+
+		keys := append([]string{c.Alpha2, c.Alpha3, c.Name}, strings.Split(r[3], ";")...)
+		for _, k := range keys {
+			if k = key(k); k == "" {
+				continue
+			}
+			// first spelling wins, so codes and names beat aliases
+			if _, ok := index[k]; !ok {
+				index[k] = c
+			}
+		}
+	}
+}
+
+// Lookup resolves an alpha-2 or alpha-3 code, name or alias, case-insensitive.
+func Lookup(s string) (Country, bool) {
+	c, ok := index[key(s)]
+	return c, ok
+}
+
+// Normalize returns the alpha-2 code of the country.
+func Normalize(s string) (string, bool) {
+	c, ok := Lookup(s)
+	return c.Alpha2, ok
+}
+
+// Name returns the display name of the alpha-2 code, or the code itself if unknown.
+func Name(code string) string {
+	if c, ok := byCode[strings.ToUpper(code)]; ok {
+		return c.Name
+	}
+	return code
+}
+
+func key(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, ".", ""))
+	return strings.Join(strings.Fields(s), " ")
+}
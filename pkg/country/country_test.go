@@ -0,0 +1,87 @@
+package country
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"GB", "GB", true},
+		{"gb", "GB", true},
+		{"GBR", "GB", true},
+		{"United Kingdom", "GB", true},
+		{"UK", "GB", true},
+		{"U.K.", "GB", true},
+		{"england", "GB", true},
+		{"  great   britain ", "GB", true},
+		{"UKR", "UA", true},
+		{"Ukraine", "UA", true},
+		{"U.S.A.", "US", true},
+		{"United States of America", "US", true},
+		{"Côte d'Ivoire", "CI", true},
+		{"Ivory Coast", "CI", true},
+		{"Korea", "KR", true},
+		{"NA", "NA", true}, // Namibia, not a missing value
+		{"", "", false},
+		{"Atlantis", "", false},
+		{"XX", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Normalize(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("deutschland")
+	if !ok || c != (Country{Alpha2: "DE", Alpha3: "DEU", Name: "Germany"}) {
+		t.Errorf("Lookup = %+v, %v", c, ok)
+	}
+}
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"GB", "United Kingdom"},
+		{"gb", "United Kingdom"},
+		{"XX", "XX"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Name(tt.code); got != tt.want {
+				t.Errorf("Name(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatasetCodesAreUnique(t *testing.T) {
+	alpha3 := make(map[string]string, len(byCode))
+	for code, c := range byCode {
+		if c.Alpha2 != code || len(code) != 2 || len(c.Alpha3) != 3 {
+			t.Errorf("malformed country %+v", c)
+		}
+		if other, ok := alpha3[c.Alpha3]; ok {
+			t.Errorf("alpha-3 %s used by %s and %s", c.Alpha3, other, code)
+		}
+		alpha3[c.Alpha3] = code
+
+		if got, ok := Normalize(c.Alpha3); !ok || got != code {
+			t.Errorf("Normalize(%s) = %s, want %s", c.Alpha3, got, code)
+		}
+		if got, ok := Normalize(c.Name); !ok || got != code {
+			t.Errorf("Normalize(%s) = %s, want %s", c.Name, got, code)
+		}
+	}
+}
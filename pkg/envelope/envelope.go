@@ -0,0 +1,127 @@
+// Package envelope encrypts values with a fresh AES-256-GCM data key each,
+// the data key is wrapped by a KMS master key and stored alongside the value.
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMalformed = errors.New("envelope: malformed ciphertext")
+	ErrNoKMS     = errors.New("envelope: encrypted value but no master key configured")
+)
+
+// Cipher encrypts values through its KMS. The zero Cipher is disabled
+// and passes values through as is.
+//
+// A ciphertext, "<wrapped data key>:<sealed value>", is stored along with the
+// id of the master key wrapping its data key, an empty key id marks plaintext.
+// The additional data binds it to its record, see RecordAAD.
+type Cipher struct {
+	kms KMS
+}
+
+func New(kms KMS) Cipher {
+	return Cipher{kms}
+}
+
+func (c Cipher) Enabled() bool {
+	return c.kms != nil
+}
+
+// RecordAAD returns the additional data binding a value to the record
+// of the table, so it cannot be decrypted as the value of another record.
+func RecordAAD(table string, id uint) []byte {
+	return []byte(table + ":" + strconv.FormatUint(uint64(id), 10))
+}
+
+// Encrypt seals plaintext with a new data key wrapped by the active master key,
+// and returns the ciphertext and the master key id. Empty values and values of
+// a disabled cipher are returned unchanged, with an empty key id.
+func (c Cipher) Encrypt(ctx context.Context, plaintext string, aad []byte) (ciphertext, keyID string, err error) {
+	if !c.Enabled() || plaintext == "" {
+		return plaintext, "", nil
+	}
+
+	dataKey := make([]byte, MasterKeySize)
+	if _, err = rand.Read(dataKey); err != nil {
+		return "", "", err
+	}
+
+	keyID = c.kms.ActiveKeyID()
+	wrapped, err := c.kms.Wrap(ctx, keyID, dataKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", "", err
+	}
+	sealed, err := seal(aead, []byte(plaintext), aad)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), keyID, nil
+}
+
+// Decrypt opens a ciphertext wrapped by the master key keyID with the
+// additional data it was sealed with. Values without a key id are plaintext
+// and returned unchanged.
+func (c Cipher) Decrypt(ctx context.Context, s, keyID string, aad []byte) (string, error) {
+	if keyID == "" {
+		return s, nil
+	}
+	if !c.Enabled() {
+		return "", ErrNoKMS
+	}
+
+	wrapped, sealed, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := c.kms.Unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed, aad)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether a non empty value is plaintext or wrapped by
+// a master key other than the active one.
+func (c Cipher) NeedsRotation(s, keyID string) bool {
+	if !c.Enabled() || s == "" {
+		return false
+	}
+	return keyID != c.kms.ActiveKeyID()
+}
+
+func parse(s string) (wrapped, sealed []byte, err error) {
+	wrappedPart, sealedPart, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, nil, ErrMalformed
+	}
+	if wrapped, err = base64.RawStdEncoding.DecodeString(wrappedPart); err != nil {
+		return nil, nil, ErrMalformed
+	}
+	if sealed, err = base64.RawStdEncoding.DecodeString(sealedPart); err != nil {
+		return nil, nil, ErrMalformed
+	}
+	return wrapped, sealed, nil
+}
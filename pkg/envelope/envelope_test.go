@@ -0,0 +1,139 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKeyring(t *testing.T, active string, ids ...string) *Keyring {
+	t.Helper()
+	keys := make(map[string][]byte, len(ids))
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, MasterKeySize)
+	}
+	k, err := NewKeyring(keys, active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestCipher(t *testing.T) {
+	ctx := context.Background()
+	c := New(testKeyring(t, "k1", "k1"))
+	aad := RecordAAD("targets", 7)
+
+	ciphertext, keyID, err := c.Encrypt(ctx, "meet at the bridge", aad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyID != "k1" || strings.Contains(ciphertext, "bridge") {
+		t.Fatalf("Encrypt = %q, %q", ciphertext, keyID)
+	}
+
+	other, _, err := c.Encrypt(ctx, "meet at the bridge", aad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == ciphertext {
+		t.Error("ciphertexts of the same value are equal, want a fresh data key and nonce")
+	}
+
+	tampered := []byte(ciphertext)
+	tampered[len(tampered)-2] ^= 'A' ^ 'B'
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		keyID      string
+		aad        []byte
+		want       string
+		wantErr    error
+		anyErr     bool
+	}{
+		{name: "round trip", ciphertext: ciphertext, keyID: keyID, aad: aad, want: "meet at the bridge"},
+		{name: "plaintext", ciphertext: "not encrypted", keyID: "", aad: aad, want: "not encrypted"},
+		{name: "other record", ciphertext: ciphertext, keyID: keyID, aad: RecordAAD("targets", 8), anyErr: true},
+		{name: "other table", ciphertext: ciphertext, keyID: keyID, aad: RecordAAD("dossiers", 7), anyErr: true},
+		{name: "tampered", ciphertext: string(tampered), keyID: keyID, aad: aad, anyErr: true},
+		{name: "unknown key", ciphertext: ciphertext, keyID: "k9", aad: aad, wantErr: ErrUnknownKey},
+		{name: "malformed", ciphertext: "no separator", keyID: keyID, aad: aad, wantErr: ErrMalformed},
+		{name: "malformed base64", ciphertext: "!!:!!", keyID: keyID, aad: aad, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Decrypt(ctx, tt.ciphertext, tt.keyID, tt.aad)
+			if tt.anyErr {
+				if err == nil {
+					t.Fatalf("Decrypt = %q, want an error", got)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Decrypt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCipherDisabled(t *testing.T) {
+	ctx := context.Background()
+	var c Cipher
+
+	got, keyID, err := c.Encrypt(ctx, "notes", RecordAAD("targets", 1))
+	if err != nil || got != "notes" || keyID != "" {
+		t.Errorf("Encrypt = %q, %q, %v, want the plaintext", got, keyID, err)
+	}
+	if got, err := c.Decrypt(ctx, "notes", "", nil); err != nil || got != "notes" {
+		t.Errorf("Decrypt plaintext = %q, %v", got, err)
+	}
+	if _, err := c.Decrypt(ctx, "a:b", "k1", nil); !errors.Is(err, ErrNoKMS) {
+		t.Errorf("Decrypt ciphertext = %v, want ErrNoKMS", err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	ctx := context.Background()
+	old := New(testKeyring(t, "k1", "k1", "k2"))
+	rotated := New(testKeyring(t, "k2", "k1", "k2"))
+	aad := RecordAAD("targets", 3)
+
+	ciphertext, keyID, err := old.Encrypt(ctx, "safe house", aad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cipher Cipher
+		value  string
+		keyID  string
+		want   bool
+	}{
+		{name: "active key", cipher: old, value: ciphertext, keyID: keyID, want: false},
+		{name: "previous key", cipher: rotated, value: ciphertext, keyID: keyID, want: true},
+		{name: "plaintext", cipher: rotated, value: "safe house", keyID: "", want: true},
+		{name: "empty", cipher: rotated, value: "", keyID: "", want: false},
+		{name: "disabled", cipher: Cipher{}, value: "safe house", keyID: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cipher.NeedsRotation(tt.value, tt.keyID); got != tt.want {
+				t.Errorf("NeedsRotation = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// values wrapped by the previous key still decrypt once rotated
+	if got, err := rotated.Decrypt(ctx, ciphertext, keyID, aad); err != nil || got != "safe house" {
+		t.Errorf("Decrypt after rotation = %q, %v", got, err)
+	}
+}
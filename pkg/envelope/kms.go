@@ -0,0 +1,158 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MasterKeySize is the size of master and data keys, AES-256.
+const MasterKeySize = 32
+
+var ErrUnknownKey = errors.New("envelope: unknown master key")
+
+type (
+	// KMS wraps data keys with master keys it never hands out.
+	KMS interface {
+		// ActiveKeyID returns the master key new data keys are wrapped with.
+		ActiveKeyID() string
+		Wrap(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+		Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+	}
+
+	// Keyring is a KMS holding master keys in memory, loaded from
+	// the config or a local key file.
+	Keyring struct {
+		active string
+		keys   map[string]cipher.AEAD
+	}
+
+	keyFile struct {
+		Active string            `json:"active"`
+		Keys   map[string]string `json:"keys"`
+	}
+)
+
+// NewKeyring returns a keyring wrapping new data keys with the active key.
+func NewKeyring(keys map[string][]byte, active string) (*Keyring, error) {
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("envelope: active key %q not in keyring", active)
+	}
+
+	k := &Keyring{active: active, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.ContainsAny(id, ":, ") {
+			return nil, fmt.Errorf("envelope: invalid key id %q", id)
+		}
+		if len(key) != MasterKeySize {
+			return nil, fmt.Errorf("envelope: key %q must be %d bytes, got %d", id, MasterKeySize, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyring reads keys from a "id:base64key,id:base64key" list.
+// With a single key, active may be empty.
+func ParseKeyring(spec, active string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(spec, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("envelope: master key entry must be id:base64key")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("envelope: master key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	if active == "" {
+		if len(keys) > 1 {
+			return nil, fmt.Errorf("envelope: active key required with several master keys")
+		}
+		for id := range keys {
+			active = id
+		}
+	}
+	return NewKeyring(keys, active)
+}
+
+// LoadKeyFile reads a local file KMS, a JSON document like
+// {"active": "2025-06", "keys": {"2025-01": "base64key", "2025-06": "base64key"}}.
+func LoadKeyFile(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f keyFile
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("envelope: key file %s: %w", path, err)
+	}
+
+	keys := make(map[string][]byte, len(f.Keys))
+	for id, encoded := range f.Keys {
+		if keys[id], err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return nil, fmt.Errorf("envelope: key file %s, key %q: %w", path, id, err)
+		}
+	}
+	return NewKeyring(keys, f.Active)
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+func (k *Keyring) Wrap(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return seal(aead, dataKey, []byte(keyID))
+}
+
+func (k *Keyring) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return open(aead, wrapped, []byte(keyID))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext under a random nonce, prepended to the result.
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+func open(aead cipher.AEAD, sealed, additional []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additional)
+}
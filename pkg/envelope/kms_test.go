@@ -0,0 +1,106 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseKeyring(t *testing.T) {
+	key1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, MasterKeySize))
+	key2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, MasterKeySize))
+	short := base64.StdEncoding.EncodeToString([]byte("short"))
+
+	tests := []struct {
+		name       string
+		spec       string
+		active     string
+		wantActive string
+		wantErr    bool
+	}{
+		{name: "single key", spec: "k1:" + key1, wantActive: "k1"},
+		{name: "active of several", spec: "k1:" + key1 + ", k2:" + key2, active: "k2", wantActive: "k2"},
+		{name: "several keys without active", spec: "k1:" + key1 + ",k2:" + key2, wantErr: true},
+		{name: "active not in keyring", spec: "k1:" + key1, active: "k2", wantErr: true},
+		{name: "no id", spec: key1, wantErr: true},
+		{name: "empty id", spec: ":" + key1, wantErr: true},
+		{name: "invalid base64", spec: "k1:%%%", wantErr: true},
+		{name: "short key", spec: "k1:" + short, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParseKeyring(tt.spec, tt.active)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && k.ActiveKeyID() != tt.wantActive {
+				t.Errorf("active = %q, want %q", k.ActiveKeyID(), tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, MasterKeySize))
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: `{"active": "2025-06", "keys": {"2025-01": "` + key + `", "2025-06": "` + key + `"}}`},
+		{name: "invalid json", content: `{"active": `, wantErr: true},
+		{name: "invalid key", content: `{"active": "2025-06", "keys": {"2025-06": "%%%"}}`, wantErr: true},
+		{name: "no active key", content: `{"keys": {"2025-06": "` + key + `"}}`, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			k, err := LoadKeyFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && k.ActiveKeyID() != "2025-06" {
+				t.Errorf("active = %q", k.ActiveKeyID())
+			}
+		})
+	}
+
+	if _, err := LoadKeyFile(filepath.Join(dir, "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want not exist", err)
+	}
+}
+
+func TestKeyringWrap(t *testing.T) {
+	ctx := context.Background()
+	k := testKeyring(t, "k1", "k1", "k2")
+	dataKey := bytes.Repeat([]byte{9}, MasterKeySize)
+
+	wrapped, err := k.Wrap(ctx, "k1", dataKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := k.Unwrap(ctx, "k1", wrapped); err != nil || !bytes.Equal(got, dataKey) {
+		t.Errorf("Unwrap = %x, %v", got, err)
+	}
+	// the key id is the additional data, a data key cannot be unwrapped by another id
+	if _, err := k.Unwrap(ctx, "k2", wrapped); err == nil {
+		t.Error("Unwrap with another key succeeded")
+	}
+	if _, err := k.Unwrap(ctx, "k1", wrapped[:4]); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Unwrap truncated = %v, want ErrMalformed", err)
+	}
+	if _, err := k.Wrap(ctx, "k9", dataKey); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Wrap unknown key = %v, want ErrUnknownKey", err)
+	}
+}
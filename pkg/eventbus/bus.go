@@ -0,0 +1,121 @@
+// Package eventbus fans messages out to in-process subscribers of a topic.
+// Delivery is best effort: a subscriber too slow to keep up with its buffer
+// is closed rather than holding back publishers, and is expected to resubscribe.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrClosed = errors.New("eventbus: closed")
+
+// Publisher sends a message to the subscribers of a topic.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// Subscription receives the messages of a topic on C,
+// which is closed when the subscription ends.
+type Subscription struct {
+	C <-chan []byte
+
+	c     chan []byte
+	bus   *Bus
+	topic string
+}
+
+// Close ends the subscription, it is safe to call more than once.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+// Bus delivers messages to the subscribers of this process.
+type Bus struct {
+	mu     sync.Mutex
+	subs   map[string]map[*Subscription]struct{}
+	buffer int
+	closed bool
+}
+
+// New returns a bus buffering up to buffer messages per subscriber.
+func New(buffer int) *Bus {
+	return &Bus{
+		subs:   make(map[string]map[*Subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+func (b *Bus) Subscribe(topic string) *Subscription {
+	c := make(chan []byte, b.buffer)
+	s := &Subscription{C: c, c: c, bus: b, topic: topic}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(c)
+		return s
+	}
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[*Subscription]struct{})
+	}
+	b.subs[topic][s] = struct{}{}
+	return s
+}
+
+// Publish delivers payload to the subscribers of topic, it never blocks.
+func (b *Bus) Publish(_ context.Context, topic string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	for s := range b.subs[topic] {
+		select {
+		case s.c <- payload:
+		default:
+			b.remove(s)
+		}
+	}
+	return nil
+}
+
+// Close ends every subscription, later publishes fail with ErrClosed.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, subs := range b.subs {
+		for s := range subs {
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Bus) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(s)
+}
+
+func (b *Bus) remove(s *Subscription) {
+	subs, ok := b.subs[s.topic]
+	if !ok {
+		return
+	}
+	if _, ok = subs[s]; !ok {
+		return
+	}
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(b.subs, s.topic)
+	}
+	close(s.c)
+}
@@ -0,0 +1,134 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// receive returns the next message of the subscription, ok is false
+// if none is buffered or the subscription is closed.
+func receive(s *Subscription) (msg string, ok bool) {
+	select {
+	case b, open := <-s.C:
+		return string(b), open
+	default:
+		return "", false
+	}
+}
+
+// closed reports whether the subscription ended once its buffer is drained.
+func closed(s *Subscription) bool {
+	for {
+		select {
+		case _, open := <-s.C:
+			if !open {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestPublishFansOut(t *testing.T) {
+	ctx := context.Background()
+	bus := New(4)
+	defer bus.Close()
+
+	a, b := bus.Subscribe("mission:1"), bus.Subscribe("mission:1")
+	other := bus.Subscribe("mission:2")
+
+	if err := bus.Publish(ctx, "mission:1", []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, "mission:1", []byte("second")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, "mission:3", []byte("nobody")); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, s := range map[string]*Subscription{"a": a, "b": b} {
+		for _, want := range []string{"first", "second"} {
+			if got, ok := receive(s); !ok || got != want {
+				t.Errorf("%s received %q, %v, want %q", name, got, ok, want)
+			}
+		}
+	}
+	if got, ok := receive(other); ok {
+		t.Errorf("other topic received %q", got)
+	}
+}
+
+func TestSlowSubscriberIsClosed(t *testing.T) {
+	ctx := context.Background()
+	bus := New(2)
+	defer bus.Close()
+
+	slow, fast := bus.Subscribe("t"), bus.Subscribe("t")
+
+	for i, msg := range []string{"1", "2", "3"} {
+		if err := bus.Publish(ctx, "t", []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := receive(fast); !ok || got != msg {
+			t.Fatalf("fast received %q, %v at %d", got, ok, i)
+		}
+	}
+
+	// the buffered messages are still delivered before the close
+	for _, want := range []string{"1", "2"} {
+		if got, ok := receive(slow); !ok || got != want {
+			t.Errorf("slow received %q, %v, want %q", got, ok, want)
+		}
+	}
+	if !closed(slow) {
+		t.Error("slow subscription not closed")
+	}
+
+	if err := bus.Publish(ctx, "t", []byte("4")); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := receive(fast); !ok || got != "4" {
+		t.Errorf("fast received %q, %v after the slow one left", got, ok)
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	bus := New(1)
+	defer bus.Close()
+
+	s := bus.Subscribe("t")
+	s.Close()
+	s.Close()
+
+	if !closed(s) {
+		t.Fatal("subscription not closed")
+	}
+	if err := bus.Publish(context.Background(), "t", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if len(bus.subs) != 0 {
+		t.Errorf("subscriptions left: %v", bus.subs)
+	}
+}
+
+func TestBusClose(t *testing.T) {
+	bus := New(1)
+	s := bus.Subscribe("t")
+
+	bus.Close()
+	bus.Close()
+	s.Close()
+
+	if !closed(s) {
+		t.Error("subscription not closed with the bus")
+	}
+	if !closed(bus.Subscribe("t")) {
+		t.Error("subscription to a closed bus not closed")
+	}
+	if err := bus.Publish(context.Background(), "t", []byte("x")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish = %v, want ErrClosed", err)
+	}
+}
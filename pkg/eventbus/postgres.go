@@ -0,0 +1,89 @@
+package eventbus
+
+import (
+	"backend/pkg/postgres"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// MaxNotifyPayload is the size limit of a Postgres notification.
+const MaxNotifyPayload = 7999
+
+var ErrTooLarge = errors.New("eventbus: message too large")
+
+type (
+	database interface {
+		postgres.Database
+		Listen(ctx context.Context, channel string, fn func(payload string)) error
+	}
+
+	// Postgres fans messages out to the subscribers of every process listening
+	// on the notification channel, this one included. Payloads must be JSON.
+	Postgres struct {
+		*Bus
+		db      database
+		channel string
+		l       *slog.Logger
+	}
+
+	notification struct {
+		Topic   string          `json:"topic"`
+		Payload json.RawMessage `json:"payload"`
+	}
+)
+
+func NewPostgres(bus *Bus, db database, channel string, l *slog.Logger) Postgres {
+	return Postgres{bus, db, channel, l}
+}
+
+// Publish notifies the channel, the message reaches local subscribers
+// once it comes back through Run.
+func (p Postgres) Publish(ctx context.Context, topic string, payload []byte) error {
+	msg, err := json.Marshal(notification{topic, payload})
+	if err != nil {
+		return err
+	}
+	if len(msg) > MaxNotifyPayload {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, len(msg))
+	}
+	return postgres.Notify(ctx, p.db.Instance(), p.channel, string(msg))
+}
+
+// Run listens on the channel and delivers notifications to local subscribers
+// until ctx is done, reconnecting with backoff when the connection fails.
+func (p Postgres) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := p.db.Listen(ctx, p.channel, p.deliver)
+		if ctx.Err() != nil {
+			return
+		}
+		p.l.Error("event bus listener failed", "channel", p.channel, "err", err)
+
+		if time.Since(started) > time.Minute {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+func (p Postgres) deliver(payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		p.l.Warn("malformed event bus notification", "channel", p.channel, "err", err)
+		return
+	}
+	if err := p.Bus.Publish(context.Background(), n.Topic, n.Payload); err != nil && !errors.Is(err, ErrClosed) {
+		p.l.Warn("event bus delivery failed", "topic", n.Topic, "err", err)
+	}
+}
@@ -0,0 +1,37 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDeliver(t *testing.T) {
+	bus := New(4)
+	defer bus.Close()
+	p := NewPostgres(bus, nil, "events", slog.New(slog.DiscardHandler))
+
+	s := bus.Subscribe("mission:1")
+
+	p.deliver(`{"topic":"mission:1","payload":{"id":1}}`)
+	p.deliver(`{"topic":"mission:2","payload":{"id":2}}`)
+	p.deliver(`not json`)
+
+	if got, ok := receive(s); !ok || got != `{"id":1}` {
+		t.Errorf("received %q, %v", got, ok)
+	}
+	if got, ok := receive(s); ok {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestPublishTooLarge(t *testing.T) {
+	p := NewPostgres(New(1), nil, "events", slog.New(slog.DiscardHandler))
+
+	payload := []byte(`"` + strings.Repeat("x", MaxNotifyPayload) + `"`)
+	if err := p.Publish(context.Background(), "t", payload); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Publish = %v, want ErrTooLarge", err)
+	}
+}
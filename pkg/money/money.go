@@ -0,0 +1,148 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// Money is an amount in minor units of an ISO-4217 currency
+// (e.g. cents for USD). Amounts are never represented as floats.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency" valid:"ISO4217"`
+}
+
+// exponents lists currencies with a number of minor units other than 2.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Exponent returns the number of minor units of the currency.
+func Exponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add sums amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("currency mismatch: %s and %s", m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Mul multiplies the amount by the factor, rounding half away from zero.
+func (m Money) Mul(factor *big.Rat) Money {
+	r := new(big.Rat).SetInt64(m.Amount)
+	return Money{Amount: round(r.Mul(r, factor)), Currency: m.Currency}
+}
+
+// Convert returns the amount in the target currency, where rate is the price
+// of one major unit of m.Currency in major units of to. Rounds half away from zero.
+func (m Money) Convert(to string, rate *big.Rat) Money {
+	if m.Currency == to {
+		return m
+	}
+
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, rate)
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10),
+		big.NewInt(int64(abs(Exponent(to)-Exponent(m.Currency)))), nil))
+	if Exponent(to) > Exponent(m.Currency) {
+		r.Mul(r, scale)
+	} else {
+		r.Quo(r, scale)
+	}
+
+	return Money{Amount: round(r), Currency: to}
+}
+
+// String formats the money in major units, e.g. "12.34 USD".
+func (m Money) String() string {
+	exp := Exponent(m.Currency)
+	if exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	div := int64(1)
+	for range exp {
+		div *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/div, exp, amount%div, m.Currency)
+}
+
+// UnmarshalJSON accepts the {"amount", "currency"} object, and for backward
+// compatibility a bare number of minor units with the currency left empty.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] != '{' {
+		var amount int64
+		if err := json.Unmarshal(b, &amount); err != nil {
+			return fmt.Errorf("money: %w", err)
+		}
+		*m = Money{Amount: amount}
+		return nil
+	}
+
+	type plain Money
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*m = New(p.Amount, p.Currency)
+	return nil
+}
+
+// ParseRate parses a positive decimal rate or multiplier, e.g. "1.0834".
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid rate %q", s)
+	}
+	return rate, nil
+}
+
+// round rounds the rational half away from zero.
+func round(r *big.Rat) int64 {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Num().Sign())))
+	}
+	return q.Int64()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// IsCurrency reports whether the code is a known ISO-4217 currency.
+func IsCurrency(code string) bool {
+	return govalidator.IsISO4217(code)
+}
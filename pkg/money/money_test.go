@@ -0,0 +1,182 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func rat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("invalid rational " + s)
+	}
+	return r
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"12", 12},
+		{"12.4", 12},
+		{"12.5", 13},
+		{"12.6", 13},
+		{"-12.4", -12},
+		{"-12.5", -13},
+		{"-12.6", -13},
+		{"1/3", 0},
+		{"2/3", 1},
+		{"-1/2", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := round(rat(tt.in)); got != tt.want {
+				t.Errorf("round(%s) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	got, err := New(1050, "usd").Add(New(250, "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := New(1300, "USD"); got != want {
+		t.Errorf("Add = %v, want %v", got, want)
+	}
+
+	if _, err := New(100, "USD").Add(New(100, "EUR")); err == nil {
+		t.Error("Add of different currencies succeeded")
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      Money
+		factor string
+		want   int64
+	}{
+		{"identity", New(1999, "USD"), "1", 1999},
+		{"half up", New(15, "USD"), "1.5", 23}, // 22.5
+		{"half of an odd amount", New(101, "USD"), "0.5", 51},
+		{"negative half away from zero", New(-15, "USD"), "1.5", -23},
+		{"proration", New(300000, "USD"), "10/31", 96774}, // 96774.19...
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Mul(rat(tt.factor))
+			if got.Amount != tt.want || got.Currency != tt.m.Currency {
+				t.Errorf("Mul(%s) = %v, want %d %s", tt.factor, got, tt.want, tt.m.Currency)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		to   string
+		rate string
+		want Money
+	}{
+		{"same currency ignores rate", New(1000, "USD"), "USD", "2", New(1000, "USD")},
+		{"same exponent", New(1000, "EUR"), "USD", "1.0834", New(1083, "USD")},
+		{"rounds half away from zero", New(50, "EUR"), "USD", "1.01", New(51, "USD")}, // 50.5
+		{"to fewer minor units", New(1000, "USD"), "JPY", "151.23", New(1512, "JPY")}, // 1512.3
+		{"to more minor units", New(1500, "JPY"), "USD", "0.0066", New(990, "USD")},
+		{"to three minor units", New(1000, "USD"), "KWD", "0.3075", New(3075, "KWD")},
+		{"negative", New(-50, "EUR"), "USD", "1.01", New(-51, "USD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Convert(tt.to, rat(tt.rate)); got != tt.want {
+				t.Errorf("Convert = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1234, "USD"), "12.34 USD"},
+		{New(5, "USD"), "0.05 USD"},
+		{New(-1234, "USD"), "-12.34 USD"},
+		{New(-5, "EUR"), "-0.05 EUR"},
+		{New(1500, "JPY"), "1500 JPY"},
+		{New(1234, "KWD"), "1.234 KWD"},
+		{New(0, "USD"), "0.00 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.m.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: `{"amount":1050,"currency":"usd"}`, want: New(1050, "USD")},
+		{in: `1050`, want: Money{Amount: 1050}},
+		{in: ` 7 `, want: Money{Amount: 7}},
+		{in: `"10.50"`, wantErr: true},
+		{in: `10.5`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "1.0834"},
+		{in: "151"},
+		{in: "0", wantErr: true},
+		{in: "-1.5", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			rate, err := ParseRate(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && rate.Cmp(rat(tt.in)) != 0 {
+				t.Errorf("rate = %s, want %s", rate, tt.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type (
+	// Notification is an event for humans, e.g. a mission gone overdue.
+	Notification struct {
+		Event   string    `json:"event"`
+		Message string    `json:"message"`
+		Data    any       `json:"data,omitempty"`
+		At      time.Time `json:"at"`
+	}
+
+	Notifier interface {
+		Notify(ctx context.Context, n Notification) error
+	}
+
+	// Log writes notifications to the logger.
+	Log struct {
+		l *slog.Logger
+	}
+
+	// Webhook posts notifications as JSON to a URL.
+	Webhook struct {
+		url    string
+		client *http.Client
+	}
+)
+
+func NewLog(l *slog.Logger) Log {
+	return Log{l}
+}
+
+func (n Log) Notify(ctx context.Context, notification Notification) error {
+	n.l.WarnContext(ctx, notification.Message,
+		"event", notification.Event,
+		"data", notification.Data,
+		"at", notification.At)
+	return nil
+}
+
+func NewWebhook(url string, timeout time.Duration) Webhook {
+	return Webhook{url, &http.Client{Timeout: timeout}}
+}
+
+func (n Webhook) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s responded %s", n.url, resp.Status)
+	}
+	return nil
+}
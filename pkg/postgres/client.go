@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
@@ -89,3 +91,50 @@ func (p *Postgres) Ping(ctx context.Context) error {
 	}
 	return p.sqlDB.PingContext(ctx)
 }
+
+// Listen subscribes a dedicated connection to the notification channel and
+// calls fn with the payload of each notification, until ctx is done or the
+// connection fails. Notifications sent while not listening are lost.
+func (p *Postgres) Listen(ctx context.Context, channel string, fn func(payload string)) error {
+	if p.sqlDB == nil {
+		return fmt.Errorf("database connection is closed")
+	}
+
+	conn, err := p.sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("listen requires the pgx driver, got %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return err
+		}
+		defer func() {
+			// the connection goes back to the pool
+			if !pgConn.IsClosed() {
+				pgConn.Exec(context.Background(), "UNLISTEN *")
+			}
+		}()
+
+		for {
+			n, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			fn(n.Payload)
+		}
+	})
+}
+
+// Notify sends payload to the listeners of the notification channel,
+// when tx commits if called in a transaction.
+func Notify(ctx context.Context, db *gorm.DB, channel, payload string) error {
+	return db.WithContext(ctx).Exec(`SELECT pg_notify(?, ?)`, channel, payload).Error
+}
@@ -0,0 +1,182 @@
+// Package postgrestest provides a postgres.Database answering queries with a
+// handler instead of a server, to test repos without Postgres.
+package postgrestest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+type (
+	// Query is a statement run against the DB, with "$n" placeholders.
+	Query struct {
+		SQL  string
+		Args []driver.Value
+	}
+
+	// Result answers a Query. Rows are returned by queries, RowsAffected by
+	// statements, and Err fails both.
+	Result struct {
+		Columns      []string
+		Rows         [][]driver.Value
+		RowsAffected int64
+		Err          error
+	}
+
+	// Handler answers the queries run against a DB.
+	Handler func(q Query) Result
+
+	// DB is a postgres.Database recording the queries it answered.
+	DB struct {
+		db      *gorm.DB
+		sqlDB   *sql.DB
+		mu      sync.Mutex
+		handler Handler
+		queries []Query
+		txs     []string
+	}
+)
+
+// New returns a DB answering queries with h, closed with the test.
+func New(t testing.TB, h Handler) *DB {
+	t.Helper()
+
+	d := &DB{handler: h}
+	d.sqlDB = sql.OpenDB(connector{d})
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: d.sqlDB}), &gorm.Config{
+		Logger:                 gormLogger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.db = db
+	t.Cleanup(func() { d.sqlDB.Close() })
+	return d
+}
+
+func (d *DB) Instance() *gorm.DB {
+	return d.db
+}
+
+func (d *DB) Close() error {
+	return d.sqlDB.Close()
+}
+
+func (d *DB) Ping(ctx context.Context) error {
+	return d.sqlDB.PingContext(ctx)
+}
+
+// Queries returns the queries answered so far, in order.
+func (d *DB) Queries() []Query {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Query(nil), d.queries...)
+}
+
+// Transactions returns how the transactions ended so far, "commit"
+// or "rollback", in order.
+func (d *DB) Transactions() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.txs...)
+}
+
+func (d *DB) answer(query string, args []driver.NamedValue) Result {
+	q := Query{SQL: strings.Join(strings.Fields(query), " ")}
+	for _, arg := range args {
+		q.Args = append(q.Args, arg.Value)
+	}
+
+	d.mu.Lock()
+	d.queries = append(d.queries, q)
+	d.mu.Unlock()
+	return d.handler(q)
+}
+
+func (d *DB) end(how string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.txs = append(d.txs, how)
+	return nil
+}
+
+type (
+	connector struct{ d *DB }
+	conn      struct{ d *DB }
+	tx        struct{ d *DB }
+	result    struct{ affected int64 }
+
+	rows struct {
+		columns []string
+		rows    [][]driver.Value
+	}
+)
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn(c), nil }
+func (c connector) Driver() driver.Driver                        { return nil }
+
+func (c conn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c conn) Close() error                        { return nil }
+func (c conn) Begin() (driver.Tx, error)           { return tx(c), nil }
+
+func (c conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return tx(c), nil
+}
+
+func (c conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	res := c.d.answer(query, args)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return result{res.RowsAffected}, nil
+}
+
+func (c conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res := c.d.answer(query, args)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return &rows{res.Columns, res.Rows}, nil
+}
+
+// CheckNamedValue passes arguments through, as pgx would encode them.
+func (c conn) CheckNamedValue(v *driver.NamedValue) (err error) {
+	if _, ok := v.Value.(driver.Valuer); ok {
+		v.Value, err = driver.DefaultParameterConverter.ConvertValue(v.Value)
+		return err
+	}
+	switch v.Value.(type) {
+	case []string, []uint, []int64:
+		return nil
+	}
+	v.Value, err = driver.DefaultParameterConverter.ConvertValue(v.Value)
+	return err
+}
+
+func (t tx) Commit() error   { return t.d.end("commit") }
+func (t tx) Rollback() error { return t.d.end("rollback") }
+
+func (r result) LastInsertId() (int64, error) { return 0, driver.ErrSkip }
+func (r result) RowsAffected() (int64, error) { return r.affected, nil }
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
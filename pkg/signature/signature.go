@@ -0,0 +1,67 @@
+// Package signature signs webhook payloads with HMAC-SHA256. The header value is
+// "t=<unix seconds>,v1=<hex hmac of "<t>.<body>">", the timestamp guards against replays.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header carries the signature of webhook requests.
+const Header = "X-Spycat-Signature"
+
+var (
+	ErrMalformed = errors.New("signature: malformed header")
+	ErrMismatch  = errors.New("signature: mismatch")
+	ErrExpired   = errors.New("signature: timestamp out of tolerance")
+)
+
+// Sign returns the header value for body signed at t.
+func Sign(secret string, body []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + mac(secret, ts, body)
+}
+
+// Verify checks header was produced by Sign with the secret for body,
+// no longer than tolerance before now.
+func Verify(secret string, body []byte, header string, tolerance time.Duration, now time.Time) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+	if ts == "" || sig == "" {
+		return ErrMalformed
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrMalformed
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrExpired
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(mac(secret, ts, body))) {
+		return ErrMismatch
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
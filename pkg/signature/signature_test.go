@@ -0,0 +1,53 @@
+package signature
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("whsec", []byte(`{"a":1}`), time.Unix(1700000000, 0))
+	want := "t=1700000000,v1=8ad37ba156048ae0e0a5533c75cdf26fee88b07f93cb57ee4c80adb053012032"
+	if got != want {
+		t.Errorf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec"
+	body := []byte(`{"event":"mission.completed"}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := Sign(secret, body, signedAt)
+
+	tests := []struct {
+		name    string
+		secret  string
+		body    []byte
+		header  string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid", secret: secret, body: body, header: header, now: signedAt},
+		{name: "within tolerance", secret: secret, body: body, header: header, now: signedAt.Add(5 * time.Minute)},
+		{name: "clock skew within tolerance", secret: secret, body: body, header: header, now: signedAt.Add(-5 * time.Minute)},
+		{name: "spaces and unknown parts", secret: secret, body: body, header: " v0=x, " + header[:12] + " , " + header[13:], now: signedAt},
+		{name: "expired", secret: secret, body: body, header: header, now: signedAt.Add(5*time.Minute + time.Second), wantErr: ErrExpired},
+		{name: "from the future", secret: secret, body: body, header: header, now: signedAt.Add(-6 * time.Minute), wantErr: ErrExpired},
+		{name: "wrong secret", secret: "other", body: body, header: header, now: signedAt, wantErr: ErrMismatch},
+		{name: "tampered body", secret: secret, body: []byte(`{"event":"mission.deleted"}`), header: header, now: signedAt, wantErr: ErrMismatch},
+		{name: "tampered timestamp", secret: secret, body: body, header: "t=1700000001" + header[12:], now: signedAt, wantErr: ErrMismatch},
+		{name: "empty", secret: secret, body: body, header: "", now: signedAt, wantErr: ErrMalformed},
+		{name: "no signature", secret: secret, body: body, header: "t=1700000000", now: signedAt, wantErr: ErrMalformed},
+		{name: "invalid timestamp", secret: secret, body: body, header: "t=now,v1=abc", now: signedAt, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.body, tt.header, 5*time.Minute, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}